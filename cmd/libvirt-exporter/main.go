@@ -0,0 +1,44 @@
+// Command libvirt-exporter serves libvirt metrics for Prometheus.
+//
+//	libvirt-exporter -listen :9177 -driver QEMU
+//
+// It talks to libvirt-dbus on the system bus, or with -session on the
+// session bus, and serves the metrics described in package exporter on
+// /metrics.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	libvirt "sdstack.com/sdstack/go-libvirt"
+	"sdstack.com/sdstack/go-libvirt/exporter"
+)
+
+var (
+	listen  = flag.String("listen", ":9177", "`address` to serve the metrics on")
+	driver  = flag.String("driver", string(libvirt.DriverQEMU), "libvirt `driver` to read")
+	session = flag.Bool("session", false, "use the session bus instead of the system bus")
+)
+
+func main() {
+	flag.Parse()
+
+	bus := libvirt.WithSystemBus()
+	if *session {
+		bus = libvirt.WithSessionBus()
+	}
+	conn, err := libvirt.NewConnWithOptions(libvirt.Driver(*driver), bus, libvirt.WithReconnect(0, 0))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	e, err := exporter.New(libvirt.NewConnect(conn, ""))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	http.Handle("/metrics", e)
+	log.Fatal(http.ListenAndServe(*listen, nil))
+}
@@ -1,37 +1,139 @@
 package libvirt
 
-//go:generate go run gen.go
+//go:generate go run gen.go -version 1.4.0
 
 import (
+	"context"
 	"errors"
 	"os"
 	"strconv"
+	"sync"
+	"time"
 
 	"github.com/godbus/dbus"
 )
 
 type Conn struct {
-	conn   *dbus.Conn
-	object dbus.BusObject
-}
-
-type Driver uint8
-
-const (
-	DriverVBox Driver = iota
-	DriverVZ
-	DriverQEMU
-	DriverOpenVZ
-	DriverBHyve
-	DriverLXC
-	DriverTest
-	DriverXen
-	DriverUML
-)
+	path   dbus.ObjectPath
+	shared bool
+	opts   connOptions
+
+	busmu   sync.RWMutex
+	conn    *dbus.Conn
+	signals chan *dbus.Signal
+
+	matchmu sync.Mutex
+	matches map[string]int
+
+	submu        sync.Mutex
+	handlers     map[signalKey][]*Subscription
+	dispatchDone bool
+	quit         chan struct{}
+
+	statemu   sync.Mutex
+	listeners map[chan ConnState]struct{}
+	stateDone chan struct{}
+}
+
+var errClosed = errors.New("libvirt: connection closed")
+
+// ConnOption configures how NewConnWithOptions reaches libvirt-dbus.
+type ConnOption func(*connOptions)
+
+type connOptions struct {
+	dial func() (*dbus.Conn, error)
+	auth []dbus.Auth
+	conn *dbus.Conn
+
+	reconnect  bool
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+// WithSystemBus connects to the system bus, where the privileged
+// libvirt-dbus runs. This is the default.
+func WithSystemBus() ConnOption {
+	return func(o *connOptions) {
+		o.dial = dbus.SystemBusPrivate
+	}
+}
+
+// WithSessionBus connects to the session bus of the calling user, where an
+// unprivileged libvirt-dbus serves qemu:///session and friends.
+func WithSessionBus() ConnOption {
+	return func(o *connOptions) {
+		o.dial = dbus.SessionBusPrivate
+	}
+}
+
+// WithBusAddress connects to the bus listening on address, given in D-Bus
+// address syntax such as "unix:path=/run/user/1000/bus".
+func WithBusAddress(address string) ConnOption {
+	return func(o *connOptions) {
+		o.dial = func() (*dbus.Conn, error) {
+			return dbus.Dial(address)
+		}
+	}
+}
+
+// WithAuth replaces the authentication methods tried on a new bus
+// connection. By default only EXTERNAL with the current uid is used.
+func WithAuth(methods ...dbus.Auth) ConnOption {
+	return func(o *connOptions) {
+		o.auth = methods
+	}
+}
+
+// WithDBusConn uses conn, which must already be authenticated and have
+// sent Hello, instead of opening a new connection. Conn.Close leaves conn
+// open for its owner.
+func WithDBusConn(conn *dbus.Conn) ConnOption {
+	return func(o *connOptions) {
+		o.conn = conn
+	}
+}
+
+// WithReconnect makes the Conn redial the bus when the connection is lost,
+// waiting min after the first failure and doubling the delay up to max,
+// and watch libvirt-dbus leaving and rejoining the bus. Subscriptions
+// survive a reconnect. Zero durations select 100ms and 30s. It has no
+// effect together with WithDBusConn.
+func WithReconnect(min, max time.Duration) ConnOption {
+	return func(o *connOptions) {
+		o.reconnect = true
+		o.minBackoff = min
+		o.maxBackoff = max
+	}
+}
 
 // NewConn() establishes a connection to the system bus and authenticates.
 func NewConn(d Driver) (*Conn, error) {
+	return NewConnWithOptions(d)
+}
+
+// NewConnWithOptions is like NewConn but lets opts choose the bus to use.
+func NewConnWithOptions(d Driver, opts ...ConnOption) (*Conn, error) {
+	o := connOptions{dial: dbus.SystemBusPrivate}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.minBackoff <= 0 {
+		o.minBackoff = 100 * time.Millisecond
+	}
+	if o.maxBackoff < o.minBackoff {
+		o.maxBackoff = 30 * time.Second
+		if o.maxBackoff < o.minBackoff {
+			o.maxBackoff = o.minBackoff
+		}
+	}
+
 	c := new(Conn)
+	c.opts = o
+	c.matches = make(map[string]int)
+	c.handlers = make(map[signalKey][]*Subscription)
+	c.quit = make(chan struct{})
+	c.listeners = make(map[chan ConnState]struct{})
+	c.stateDone = make(chan struct{})
 
 	if err := c.initConnection(d); err != nil {
 		return nil, err
@@ -40,53 +142,169 @@ func NewConn(d Driver) (*Conn, error) {
 	return c, nil
 }
 
+// Close closes the bus connection, or, for a connection passed in with
+// WithDBusConn, stops all subscriptions and removes their match rules.
 func (c *Conn) Close() error {
-	return c.conn.Close()
+	c.submu.Lock()
+	select {
+	case <-c.quit:
+		c.submu.Unlock()
+		return nil
+	default:
+	}
+	close(c.quit)
+	c.submu.Unlock()
+
+	c.stopAll()
+	defer c.closeListeners(ConnClosed)
+
+	bus, ch := c.bus()
+	stopDrain := drain(ch)
+	if !c.shared {
+		err := bus.Close()
+		stopDrain()
+		return err
+	}
+	bus.RemoveSignal(ch)
+	stopDrain()
+
+	c.matchmu.Lock()
+	defer c.matchmu.Unlock()
+	var err error
+	for rule := range c.matches {
+		if rerr := bus.BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, rule).Store(); rerr != nil && err == nil {
+			err = rerr
+		}
+		delete(c.matches, rule)
+	}
+	return err
+}
+
+// drain discards the signals sent on ch until the returned function is
+// called. godbus sends every signal from a goroutine holding a lock that
+// bus.Close and RemoveSignal wait for, so once the dispatcher has stopped
+// reading, a full ch would keep them from returning.
+func drain(ch <-chan *dbus.Signal) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case _, ok := <-ch:
+				if !ok {
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() { close(done) }
 }
 
 func (c *Conn) initConnection(d Driver) error {
 	var err error
 
-	drivers := make(map[Driver]string)
-	drivers[DriverVBox] = "VBox"
-	drivers[DriverVZ] = "VZ"
-	drivers[DriverQEMU] = "QEMU"
-	drivers[DriverOpenVZ] = "OpenVZ"
-	drivers[DriverBHyve] = "BHyve"
-	drivers[DriverLXC] = "LXC"
-	drivers[DriverTest] = "Test"
-	drivers[DriverXen] = "Xen"
-	drivers[DriverUML] = "UML"
-
-	c.conn, err = dbus.SystemBusPrivate()
+	if !d.valid() {
+		return errors.New("unknown driver specified")
+	}
+
+	var bus *dbus.Conn
+	if c.opts.conn != nil {
+		bus = c.opts.conn
+		c.shared = true
+		c.opts.reconnect = false
+	} else {
+		bus, err = c.opts.open()
+		if err != nil {
+			return err
+		}
+	}
+
+	c.path = d.path()
+	ch := c.attach(bus)
+
+	if c.opts.reconnect {
+		if err = c.addMatch(ownerChangedRule); err != nil {
+			bus.Close()
+			return err
+		}
+	}
+	go c.dispatch(bus, ch)
+
+	return nil
+}
+
+// open dials a new bus connection and authenticates.
+func (o *connOptions) open() (*dbus.Conn, error) {
+	bus, err := o.dial()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Only use EXTERNAL method, and hardcode the uid (not username)
 	// to avoid a username lookup (which requires a dynamically linked
 	// libc)
-	methods := []dbus.Auth{dbus.AuthExternal(strconv.Itoa(os.Getuid()))}
+	methods := o.auth
+	if len(methods) == 0 {
+		methods = []dbus.Auth{dbus.AuthExternal(strconv.Itoa(os.Getuid()))}
+	}
 
-	err = c.conn.Auth(methods)
+	err = bus.Auth(methods)
 	if err != nil {
-		c.conn.Close()
-		return err
+		bus.Close()
+		return nil, err
 	}
 
-	err = c.conn.Hello()
+	err = bus.Hello()
 	if err != nil {
-		c.conn.Close()
-		return err
+		bus.Close()
+		return nil, err
 	}
+	return bus, nil
+}
 
-	driver, ok := drivers[d]
-	if !ok {
-		c.conn.Close()
-		return errors.New("unknown driver specified")
-	}
+// attach makes bus the current connection and routes its signals to the
+// returned channel.
+func (c *Conn) attach(bus *dbus.Conn) chan *dbus.Signal {
+	ch := make(chan *dbus.Signal, signalBuffer)
+	bus.Signal(ch)
 
-	c.object = c.conn.Object("org.libvirt", dbus.ObjectPath("/org/libvirt/"+driver))
+	c.busmu.Lock()
+	c.conn = bus
+	c.signals = ch
+	c.busmu.Unlock()
+	return ch
+}
 
-	return nil
+// bus returns the current bus connection and its signal channel.
+func (c *Conn) bus() (*dbus.Conn, chan *dbus.Signal) {
+	c.busmu.RLock()
+	defer c.busmu.RUnlock()
+	return c.conn, c.signals
+}
+
+// object returns the libvirt-dbus object at path on the current bus
+// connection.
+func (c *Conn) object(path dbus.ObjectPath) dbus.BusObject {
+	bus, _ := c.bus()
+	return bus.Object("org.libvirt", path)
+}
+
+// callContext calls method on obj and waits for the reply or for ctx to be
+// done, whichever comes first. D-Bus has no way to cancel a call in flight,
+// so on cancellation the reply is simply discarded when it arrives. Errors
+// reported by libvirt are returned as *Error.
+func callContext(ctx context.Context, obj dbus.BusObject, method string, args ...interface{}) *dbus.Call {
+	if err := ctx.Err(); err != nil {
+		return &dbus.Call{Err: err}
+	}
+
+	call := obj.Go(method, 0, make(chan *dbus.Call, 1), args...)
+	select {
+	case <-call.Done:
+		call.Err = wrapError(call.Err)
+		return call
+	case <-ctx.Done():
+		return &dbus.Call{Err: ctx.Err()}
+	}
 }
@@ -0,0 +1,147 @@
+package libvirt
+
+import (
+	"context"
+	"encoding/hex"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus"
+)
+
+func TestCallContext(t *testing.T) {
+	c, f := newTestConn(t)
+	conn := NewConnect(c, "")
+	domain, err := conn.DomainLookupByUUIDObject(fakeDomainUUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// GetHostname does not reply while the fake holds it.
+	f.mu.Lock()
+	f.hold = make(chan struct{})
+	f.mu.Unlock()
+	defer close(f.hold)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err = domain.GetHostnameContext(ctx, 0); err != context.DeadlineExceeded {
+		t.Errorf("got %v, want context.DeadlineExceeded", err)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	if _, err = domain.GetHostnameContext(ctx, 0); err != context.Canceled {
+		t.Errorf("got %v, want context.Canceled", err)
+	}
+	if _, err = domain.GetHostnameContext(ctx, 0); err != context.Canceled {
+		t.Errorf("got %v for a done ctx, want context.Canceled", err)
+	}
+
+	// The connection is still usable while the replies are outstanding.
+	if name, err := domain.GetName(); err != nil || name != "test" {
+		t.Errorf("got name %q, %v", name, err)
+	}
+}
+
+// checkConn fails t unless c reaches the fake.
+func checkConn(t *testing.T, c *Conn) {
+	t.Helper()
+	if host, err := NewConnect(c, "").GetHostname(); err != nil || host != "fake-host" {
+		t.Fatalf("got hostname %q, %v", host, err)
+	}
+}
+
+func TestWithBusAddress(t *testing.T) {
+	f := newFake(t)
+	c, err := NewConnWithOptions(DriverTest, WithBusAddress(f.listen(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	checkConn(t, c)
+
+	f.busmu.Lock()
+	defer f.busmu.Unlock()
+	want := "AUTH EXTERNAL " + hex.EncodeToString([]byte(strconv.Itoa(os.Getuid())))
+	if len(f.auths) != 1 || f.auths[0] != want {
+		t.Errorf("authenticated with %q, want %q", f.auths, want)
+	}
+}
+
+func TestWithAuth(t *testing.T) {
+	f := newFake(t)
+	c, err := NewConnWithOptions(DriverTest, WithBusAddress(f.listen(t)), WithAuth(dbus.AuthExternal("qemu")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	checkConn(t, c)
+
+	f.busmu.Lock()
+	defer f.busmu.Unlock()
+	if want := "AUTH EXTERNAL " + hex.EncodeToString([]byte("qemu")); len(f.auths) != 1 || f.auths[0] != want {
+		t.Errorf("authenticated with %q, want %q", f.auths, want)
+	}
+}
+
+func TestWithSessionBus(t *testing.T) {
+	f := newFake(t)
+	old, ok := os.LookupEnv("DBUS_SESSION_BUS_ADDRESS")
+	os.Setenv("DBUS_SESSION_BUS_ADDRESS", f.listen(t))
+	defer func() {
+		if ok {
+			os.Setenv("DBUS_SESSION_BUS_ADDRESS", old)
+		} else {
+			os.Unsetenv("DBUS_SESSION_BUS_ADDRESS")
+		}
+	}()
+
+	c, err := NewConnWithOptions(DriverTest, WithSessionBus())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	checkConn(t, c)
+}
+
+func TestWithDBusConn(t *testing.T) {
+	f := newFake(t)
+	bus, err := dbus.Dial(f.listen(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer bus.Close()
+	if err = bus.Auth([]dbus.Auth{dbus.AuthExternal(strconv.Itoa(os.Getuid()))}); err != nil {
+		t.Fatal(err)
+	}
+	if err = bus.Hello(); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := NewConnWithOptions(DriverTest, WithDBusConn(bus))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkConn(t, c)
+	if _, err = NewConnect(c, "").SubscribeDomainLifecycle(func(DomainLifecycleEvent) {}); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Close leaves the connection to its owner, without the rules of the
+	// Conn's subscriptions.
+	if rules := f.matchRules(); len(rules) != 0 {
+		t.Errorf("rules %v left on the bus", rules)
+	}
+	var host dbus.Variant
+	err = bus.Object("org.libvirt", fakeRoot).Call("org.freedesktop.DBus.Properties.Get", 0,
+		"org.libvirt.Connect", "Hostname").Store(&host)
+	if err != nil || host.Value() != "fake-host" {
+		t.Errorf("got hostname %v, %v after Close", host, err)
+	}
+}
@@ -1,18 +1,14 @@
 package libvirt
 
 import (
-	"sync"
+	"context"
 
 	"github.com/godbus/dbus"
 )
 
 type Connect struct {
-	conn   *Conn
-	object dbus.BusObject
-	path   dbus.ObjectPath
-
-	sigs  map[<-chan *dbus.Signal]struct{}
-	sigmu sync.Mutex
+	conn *Conn
+	path dbus.ObjectPath
 
 	//Encrypted bool
 	//Hostname string
@@ -21,535 +17,1457 @@ type Connect struct {
 	//Version uint64
 }
 
+// DomainStatsRecord is an element of the records returned by Connect.GetAllDomainStats.
+type DomainStatsRecord struct {
+	Domain string
+	Stats  map[string]interface{}
+}
+
+// NodeSecurityModel holds the secModel returned by Connect.NodeGetSecurityModel.
+type NodeSecurityModel struct {
+	Model string
+	DOI   string
+}
+
 // NewConnect() TODO
 func NewConnect(c *Conn, path dbus.ObjectPath) *Connect {
-	m := &Connect{conn: c}
-	if path != "" {
-		m.object = c.conn.Object("org.libvirt", path)
-	} else {
-		m.object = c.object
+	m := &Connect{conn: c, path: path}
+	if path == "" {
+		m.path = c.path
 	}
-	m.path = c.object.Path()
-
-	m.sigmu.Lock()
-	m.sigs = make(map[<-chan *dbus.Signal]struct{})
-	m.sigmu.Unlock()
 
 	return m
 }
 
 // SubscribeDomainEvent See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventCallback
-func (m *Connect) SubscribeDomainEvent(callback func(domain dbus.ObjectPath, event int32, detail int32)) <-chan *dbus.Signal {
+//
+// The callback sees the signals one at a time but not necessarily in the
+// order they were sent; see Subscription and OverflowPolicy.
+func (m *Connect) SubscribeDomainEvent(callback func(domain dbus.ObjectPath, event int32, detail int32), opts ...SubscribeOption) (*Subscription, error) {
 	if callback == nil {
-		return nil
+		return nil, errNilCallback
 	}
-	m.sigmu.Lock()
-	ch := make(chan *dbus.Signal)
-	m.sigs[ch] = struct{}{}
-	m.conn.conn.Signal(ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, "type='signal',interface='org.libvirt.Connect',member='DomainEvent'")
-	go func() {
-		for v := range ch {
-			if v.Path != m.path || v.Name != "org.libvirt.Connect.DomainEvent" || 3 != len(v.Body) {
-				continue
-			}
-			callback(v.Body[0].(dbus.ObjectPath), v.Body[1].(int32), v.Body[2].(int32))
+	return m.conn.subscribe(signalSpec{"org.libvirt.Connect", "DomainEvent", "oii", m.path}, func(body []interface{}) error {
+		var (
+			domain dbus.ObjectPath
+			event  int32
+			detail int32
+		)
+		if err := dbus.Store(body, &domain, &event, &detail); err != nil {
+			return err
 		}
-	}()
-	return ch
+		callback(domain, event, detail)
+		return nil
+	}, opts)
 }
 
 // UnSubscribeDomainEvent See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventCallback
-func (m *Connect) UnSubscribeDomainEvent(ch <-chan *dbus.Signal) {
-	m.sigmu.Lock()
-	delete(m.sigs, ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, "type='signal',interface='org.libvirt.Connect',member='DomainEvent'")
+func (m *Connect) UnSubscribeDomainEvent(sub *Subscription) error {
+	return sub.Close()
+}
+
+// DomainEvents is like SubscribeDomainLifecycle but delivers the events on a
+// channel that is closed once ctx is done or the connection is lost.
+func (m *Connect) DomainEvents(ctx context.Context, opts ...SubscribeOption) (<-chan DomainLifecycleEvent, error) {
+	ch := make(chan DomainLifecycleEvent)
+	err := stream(ctx, func(quit <-chan struct{}) (*Subscription, error) {
+		return m.SubscribeDomainLifecycle(func(e DomainLifecycleEvent) {
+			select {
+			case ch <- e:
+			case <-quit:
+			}
+		}, opts...)
+	}, func() { close(ch) })
+	if err != nil {
+		return nil, err
+	}
+	return ch, nil
 }
 
 // SubscribeNetworkEvent See https://libvirt.org/html/libvirt-libvirt-network.html#virConnectNetworkEventLifecycleCallback
-func (m *Connect) SubscribeNetworkEvent(callback func(network dbus.ObjectPath, event int32)) <-chan *dbus.Signal {
+//
+// The callback sees the signals one at a time but not necessarily in the
+// order they were sent; see Subscription and OverflowPolicy.
+func (m *Connect) SubscribeNetworkEvent(callback func(network dbus.ObjectPath, event int32), opts ...SubscribeOption) (*Subscription, error) {
 	if callback == nil {
-		return nil
+		return nil, errNilCallback
 	}
-	m.sigmu.Lock()
-	ch := make(chan *dbus.Signal)
-	m.sigs[ch] = struct{}{}
-	m.conn.conn.Signal(ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, "type='signal',interface='org.libvirt.Connect',member='NetworkEvent'")
-	go func() {
-		for v := range ch {
-			if v.Path != m.path || v.Name != "org.libvirt.Connect.NetworkEvent" || 2 != len(v.Body) {
-				continue
-			}
-			callback(v.Body[0].(dbus.ObjectPath), v.Body[1].(int32))
+	return m.conn.subscribe(signalSpec{"org.libvirt.Connect", "NetworkEvent", "oi", m.path}, func(body []interface{}) error {
+		var (
+			network dbus.ObjectPath
+			event   int32
+		)
+		if err := dbus.Store(body, &network, &event); err != nil {
+			return err
 		}
-	}()
-	return ch
+		callback(network, event)
+		return nil
+	}, opts)
 }
 
 // UnSubscribeNetworkEvent See https://libvirt.org/html/libvirt-libvirt-network.html#virConnectNetworkEventLifecycleCallback
-func (m *Connect) UnSubscribeNetworkEvent(ch <-chan *dbus.Signal) {
-	m.sigmu.Lock()
-	delete(m.sigs, ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, "type='signal',interface='org.libvirt.Connect',member='NetworkEvent'")
+func (m *Connect) UnSubscribeNetworkEvent(sub *Subscription) error {
+	return sub.Close()
+}
+
+// NetworkEvents is like SubscribeNetworkLifecycle but delivers the events on a
+// channel that is closed once ctx is done or the connection is lost.
+func (m *Connect) NetworkEvents(ctx context.Context, opts ...SubscribeOption) (<-chan NetworkLifecycleEvent, error) {
+	ch := make(chan NetworkLifecycleEvent)
+	err := stream(ctx, func(quit <-chan struct{}) (*Subscription, error) {
+		return m.SubscribeNetworkLifecycle(func(e NetworkLifecycleEvent) {
+			select {
+			case ch <- e:
+			case <-quit:
+			}
+		}, opts...)
+	}, func() { close(ch) })
+	if err != nil {
+		return nil, err
+	}
+	return ch, nil
 }
 
 // SubscribeNodeDeviceEvent See https://libvirt.org/html/libvirt-libvirt-nodedev.html#virConnectNodeDeviceEventLifecycleCallback
-func (m *Connect) SubscribeNodeDeviceEvent(callback func(dev dbus.ObjectPath, event int32, detail int32)) <-chan *dbus.Signal {
+//
+// The callback sees the signals one at a time but not necessarily in the
+// order they were sent; see Subscription and OverflowPolicy.
+func (m *Connect) SubscribeNodeDeviceEvent(callback func(dev dbus.ObjectPath, event int32, detail int32), opts ...SubscribeOption) (*Subscription, error) {
 	if callback == nil {
-		return nil
+		return nil, errNilCallback
 	}
-	m.sigmu.Lock()
-	ch := make(chan *dbus.Signal)
-	m.sigs[ch] = struct{}{}
-	m.conn.conn.Signal(ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, "type='signal',interface='org.libvirt.Connect',member='NodeDeviceEvent'")
-	go func() {
-		for v := range ch {
-			if v.Path != m.path || v.Name != "org.libvirt.Connect.NodeDeviceEvent" || 3 != len(v.Body) {
-				continue
-			}
-			callback(v.Body[0].(dbus.ObjectPath), v.Body[1].(int32), v.Body[2].(int32))
+	return m.conn.subscribe(signalSpec{"org.libvirt.Connect", "NodeDeviceEvent", "oii", m.path}, func(body []interface{}) error {
+		var (
+			dev    dbus.ObjectPath
+			event  int32
+			detail int32
+		)
+		if err := dbus.Store(body, &dev, &event, &detail); err != nil {
+			return err
 		}
-	}()
-	return ch
+		callback(dev, event, detail)
+		return nil
+	}, opts)
 }
 
 // UnSubscribeNodeDeviceEvent See https://libvirt.org/html/libvirt-libvirt-nodedev.html#virConnectNodeDeviceEventLifecycleCallback
-func (m *Connect) UnSubscribeNodeDeviceEvent(ch <-chan *dbus.Signal) {
-	m.sigmu.Lock()
-	delete(m.sigs, ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, "type='signal',interface='org.libvirt.Connect',member='NodeDeviceEvent'")
+func (m *Connect) UnSubscribeNodeDeviceEvent(sub *Subscription) error {
+	return sub.Close()
+}
+
+// NodeDeviceEvents is like SubscribeNodeDeviceLifecycle but delivers the events on a
+// channel that is closed once ctx is done or the connection is lost.
+func (m *Connect) NodeDeviceEvents(ctx context.Context, opts ...SubscribeOption) (<-chan NodeDeviceLifecycleEvent, error) {
+	ch := make(chan NodeDeviceLifecycleEvent)
+	err := stream(ctx, func(quit <-chan struct{}) (*Subscription, error) {
+		return m.SubscribeNodeDeviceLifecycle(func(e NodeDeviceLifecycleEvent) {
+			select {
+			case ch <- e:
+			case <-quit:
+			}
+		}, opts...)
+	}, func() { close(ch) })
+	if err != nil {
+		return nil, err
+	}
+	return ch, nil
 }
 
 // SubscribeSecretEvent See https://libvirt.org/html/libvirt-libvirt-secret.html#virConnectSecretEventLifecycleCallback
-func (m *Connect) SubscribeSecretEvent(callback func(secret dbus.ObjectPath, event int32, detail int32)) <-chan *dbus.Signal {
+//
+// The callback sees the signals one at a time but not necessarily in the
+// order they were sent; see Subscription and OverflowPolicy.
+func (m *Connect) SubscribeSecretEvent(callback func(secret dbus.ObjectPath, event int32, detail int32), opts ...SubscribeOption) (*Subscription, error) {
 	if callback == nil {
-		return nil
+		return nil, errNilCallback
 	}
-	m.sigmu.Lock()
-	ch := make(chan *dbus.Signal)
-	m.sigs[ch] = struct{}{}
-	m.conn.conn.Signal(ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, "type='signal',interface='org.libvirt.Connect',member='SecretEvent'")
-	go func() {
-		for v := range ch {
-			if v.Path != m.path || v.Name != "org.libvirt.Connect.SecretEvent" || 3 != len(v.Body) {
-				continue
-			}
-			callback(v.Body[0].(dbus.ObjectPath), v.Body[1].(int32), v.Body[2].(int32))
+	return m.conn.subscribe(signalSpec{"org.libvirt.Connect", "SecretEvent", "oii", m.path}, func(body []interface{}) error {
+		var (
+			secret dbus.ObjectPath
+			event  int32
+			detail int32
+		)
+		if err := dbus.Store(body, &secret, &event, &detail); err != nil {
+			return err
 		}
-	}()
-	return ch
+		callback(secret, event, detail)
+		return nil
+	}, opts)
 }
 
 // UnSubscribeSecretEvent See https://libvirt.org/html/libvirt-libvirt-secret.html#virConnectSecretEventLifecycleCallback
-func (m *Connect) UnSubscribeSecretEvent(ch <-chan *dbus.Signal) {
-	m.sigmu.Lock()
-	delete(m.sigs, ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, "type='signal',interface='org.libvirt.Connect',member='SecretEvent'")
+func (m *Connect) UnSubscribeSecretEvent(sub *Subscription) error {
+	return sub.Close()
+}
+
+// SecretEvents is like SubscribeSecretLifecycle but delivers the events on a
+// channel that is closed once ctx is done or the connection is lost.
+func (m *Connect) SecretEvents(ctx context.Context, opts ...SubscribeOption) (<-chan SecretLifecycleEvent, error) {
+	ch := make(chan SecretLifecycleEvent)
+	err := stream(ctx, func(quit <-chan struct{}) (*Subscription, error) {
+		return m.SubscribeSecretLifecycle(func(e SecretLifecycleEvent) {
+			select {
+			case ch <- e:
+			case <-quit:
+			}
+		}, opts...)
+	}, func() { close(ch) })
+	if err != nil {
+		return nil, err
+	}
+	return ch, nil
 }
 
 // SubscribeStoragePoolEvent See https://libvirt.org/html/libvirt-libvirt-storage.html#virConnectStoragePoolEventLifecycleCallback
-func (m *Connect) SubscribeStoragePoolEvent(callback func(storagePool dbus.ObjectPath, event int32, detail int32)) <-chan *dbus.Signal {
+//
+// The callback sees the signals one at a time but not necessarily in the
+// order they were sent; see Subscription and OverflowPolicy.
+func (m *Connect) SubscribeStoragePoolEvent(callback func(storagePool dbus.ObjectPath, event int32, detail int32), opts ...SubscribeOption) (*Subscription, error) {
 	if callback == nil {
-		return nil
+		return nil, errNilCallback
 	}
-	m.sigmu.Lock()
-	ch := make(chan *dbus.Signal)
-	m.sigs[ch] = struct{}{}
-	m.conn.conn.Signal(ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, "type='signal',interface='org.libvirt.Connect',member='StoragePoolEvent'")
-	go func() {
-		for v := range ch {
-			if v.Path != m.path || v.Name != "org.libvirt.Connect.StoragePoolEvent" || 3 != len(v.Body) {
-				continue
-			}
-			callback(v.Body[0].(dbus.ObjectPath), v.Body[1].(int32), v.Body[2].(int32))
+	return m.conn.subscribe(signalSpec{"org.libvirt.Connect", "StoragePoolEvent", "oii", m.path}, func(body []interface{}) error {
+		var (
+			storagePool dbus.ObjectPath
+			event       int32
+			detail      int32
+		)
+		if err := dbus.Store(body, &storagePool, &event, &detail); err != nil {
+			return err
 		}
-	}()
-	return ch
+		callback(storagePool, event, detail)
+		return nil
+	}, opts)
 }
 
 // UnSubscribeStoragePoolEvent See https://libvirt.org/html/libvirt-libvirt-storage.html#virConnectStoragePoolEventLifecycleCallback
-func (m *Connect) UnSubscribeStoragePoolEvent(ch <-chan *dbus.Signal) {
-	m.sigmu.Lock()
-	delete(m.sigs, ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, "type='signal',interface='org.libvirt.Connect',member='StoragePoolEvent'")
+func (m *Connect) UnSubscribeStoragePoolEvent(sub *Subscription) error {
+	return sub.Close()
+}
+
+// StoragePoolEvents is like SubscribeStoragePoolLifecycle but delivers the events on a
+// channel that is closed once ctx is done or the connection is lost.
+func (m *Connect) StoragePoolEvents(ctx context.Context, opts ...SubscribeOption) (<-chan StoragePoolLifecycleEvent, error) {
+	ch := make(chan StoragePoolLifecycleEvent)
+	err := stream(ctx, func(quit <-chan struct{}) (*Subscription, error) {
+		return m.SubscribeStoragePoolLifecycle(func(e StoragePoolLifecycleEvent) {
+			select {
+			case ch <- e:
+			case <-quit:
+			}
+		}, opts...)
+	}, func() { close(ch) })
+	if err != nil {
+		return nil, err
+	}
+	return ch, nil
 }
 
 // BaselineCPU See https://libvirt.org/html/libvirt-libvirt-host.html#virConnectBaselineCPU
-func (m *Connect) BaselineCPU(xmlCPUs []string, flags uint32) (cpu string, err error) {
-	err = m.object.Call("org.libvirt.Connect.BaselineCPU", 0, xmlCPUs, flags).Store(&cpu)
+func (m *Connect) BaselineCPU(xmlCPUs []string, flags ConnectBaselineCPUFlags) (cpu string, err error) {
+	return m.BaselineCPUContext(context.Background(), xmlCPUs, flags)
+}
+
+// BaselineCPUContext is like BaselineCPU but gives up waiting for the reply once ctx is done.
+func (m *Connect) BaselineCPUContext(ctx context.Context, xmlCPUs []string, flags ConnectBaselineCPUFlags) (cpu string, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.BaselineCPU", xmlCPUs, flags).Store(&cpu)
 	return
 }
 
 // CompareCPU See https://libvirt.org/html/libvirt-libvirt-host.html#virConnectCompareCPU
-func (m *Connect) CompareCPU(xmlDesc string, flags uint32) (compareResult int32, err error) {
-	err = m.object.Call("org.libvirt.Connect.CompareCPU", 0, xmlDesc, flags).Store(&compareResult)
+func (m *Connect) CompareCPU(xmlDesc string, flags ConnectCompareCPUFlags) (compareResult int32, err error) {
+	return m.CompareCPUContext(context.Background(), xmlDesc, flags)
+}
+
+// CompareCPUContext is like CompareCPU but gives up waiting for the reply once ctx is done.
+func (m *Connect) CompareCPUContext(ctx context.Context, xmlDesc string, flags ConnectCompareCPUFlags) (compareResult int32, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.CompareCPU", xmlDesc, flags).Store(&compareResult)
 	return
 }
 
 // DomainCreateXML See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainCreateXML
-func (m *Connect) DomainCreateXML(xml string, flags uint32) (domain dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.Connect.DomainCreateXML", 0, xml, flags).Store(&domain)
+func (m *Connect) DomainCreateXML(xml string, flags DomainCreateFlags) (domain dbus.ObjectPath, err error) {
+	return m.DomainCreateXMLContext(context.Background(), xml, flags)
+}
+
+// DomainCreateXMLContext is like DomainCreateXML but gives up waiting for the reply once ctx is done.
+func (m *Connect) DomainCreateXMLContext(ctx context.Context, xml string, flags DomainCreateFlags) (domain dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.DomainCreateXML", xml, flags).Store(&domain)
 	return
 }
 
+// DomainCreateXMLObject is like DomainCreateXML but returns *Domain instead of an object path.
+func (m *Connect) DomainCreateXMLObject(xml string, flags DomainCreateFlags) (domain *Domain, err error) {
+	return m.DomainCreateXMLObjectContext(context.Background(), xml, flags)
+}
+
+// DomainCreateXMLObjectContext is like DomainCreateXMLObject but gives up waiting for the reply once ctx is done.
+func (m *Connect) DomainCreateXMLObjectContext(ctx context.Context, xml string, flags DomainCreateFlags) (domain *Domain, err error) {
+	objPath, err := m.DomainCreateXMLContext(ctx, xml, flags)
+	if err != nil {
+		return nil, err
+	}
+	return NewDomain(m.conn, objPath), nil
+}
+
 // DomainCreateXMLWithFiles See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainCreateXMLWithFiles
-func (m *Connect) DomainCreateXMLWithFiles(xml string, files []uint32, flags uint32) (domain dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.Connect.DomainCreateXMLWithFiles", 0, xml, files, flags).Store(&domain)
+func (m *Connect) DomainCreateXMLWithFiles(xml string, files []uint32, flags DomainCreateFlags) (domain dbus.ObjectPath, err error) {
+	return m.DomainCreateXMLWithFilesContext(context.Background(), xml, files, flags)
+}
+
+// DomainCreateXMLWithFilesContext is like DomainCreateXMLWithFiles but gives up waiting for the reply once ctx is done.
+func (m *Connect) DomainCreateXMLWithFilesContext(ctx context.Context, xml string, files []uint32, flags DomainCreateFlags) (domain dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.DomainCreateXMLWithFiles", xml, files, flags).Store(&domain)
 	return
 }
 
+// DomainCreateXMLWithFilesObject is like DomainCreateXMLWithFiles but returns *Domain instead of an object path.
+func (m *Connect) DomainCreateXMLWithFilesObject(xml string, files []uint32, flags DomainCreateFlags) (domain *Domain, err error) {
+	return m.DomainCreateXMLWithFilesObjectContext(context.Background(), xml, files, flags)
+}
+
+// DomainCreateXMLWithFilesObjectContext is like DomainCreateXMLWithFilesObject but gives up waiting for the reply once ctx is done.
+func (m *Connect) DomainCreateXMLWithFilesObjectContext(ctx context.Context, xml string, files []uint32, flags DomainCreateFlags) (domain *Domain, err error) {
+	objPath, err := m.DomainCreateXMLWithFilesContext(ctx, xml, files, flags)
+	if err != nil {
+		return nil, err
+	}
+	return NewDomain(m.conn, objPath), nil
+}
+
 // DomainDefineXML See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainDefineXML
 func (m *Connect) DomainDefineXML(xml string) (domain dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.Connect.DomainDefineXML", 0, xml).Store(&domain)
+	return m.DomainDefineXMLContext(context.Background(), xml)
+}
+
+// DomainDefineXMLContext is like DomainDefineXML but gives up waiting for the reply once ctx is done.
+func (m *Connect) DomainDefineXMLContext(ctx context.Context, xml string) (domain dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.DomainDefineXML", xml).Store(&domain)
 	return
 }
 
+// DomainDefineXMLObject is like DomainDefineXML but returns *Domain instead of an object path.
+func (m *Connect) DomainDefineXMLObject(xml string) (domain *Domain, err error) {
+	return m.DomainDefineXMLObjectContext(context.Background(), xml)
+}
+
+// DomainDefineXMLObjectContext is like DomainDefineXMLObject but gives up waiting for the reply once ctx is done.
+func (m *Connect) DomainDefineXMLObjectContext(ctx context.Context, xml string) (domain *Domain, err error) {
+	objPath, err := m.DomainDefineXMLContext(ctx, xml)
+	if err != nil {
+		return nil, err
+	}
+	return NewDomain(m.conn, objPath), nil
+}
+
 // DomainLookupByID See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainLookupByID
 func (m *Connect) DomainLookupByID(id int32) (domain dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.Connect.DomainLookupByID", 0, id).Store(&domain)
+	return m.DomainLookupByIDContext(context.Background(), id)
+}
+
+// DomainLookupByIDContext is like DomainLookupByID but gives up waiting for the reply once ctx is done.
+func (m *Connect) DomainLookupByIDContext(ctx context.Context, id int32) (domain dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.DomainLookupByID", id).Store(&domain)
 	return
 }
 
+// DomainLookupByIDObject is like DomainLookupByID but returns *Domain instead of an object path.
+func (m *Connect) DomainLookupByIDObject(id int32) (domain *Domain, err error) {
+	return m.DomainLookupByIDObjectContext(context.Background(), id)
+}
+
+// DomainLookupByIDObjectContext is like DomainLookupByIDObject but gives up waiting for the reply once ctx is done.
+func (m *Connect) DomainLookupByIDObjectContext(ctx context.Context, id int32) (domain *Domain, err error) {
+	objPath, err := m.DomainLookupByIDContext(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return NewDomain(m.conn, objPath), nil
+}
+
 // DomainLookupByName See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainLookupByName
 func (m *Connect) DomainLookupByName(name string) (domain dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.Connect.DomainLookupByName", 0, name).Store(&domain)
+	return m.DomainLookupByNameContext(context.Background(), name)
+}
+
+// DomainLookupByNameContext is like DomainLookupByName but gives up waiting for the reply once ctx is done.
+func (m *Connect) DomainLookupByNameContext(ctx context.Context, name string) (domain dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.DomainLookupByName", name).Store(&domain)
 	return
 }
 
+// DomainLookupByNameObject is like DomainLookupByName but returns *Domain instead of an object path.
+func (m *Connect) DomainLookupByNameObject(name string) (domain *Domain, err error) {
+	return m.DomainLookupByNameObjectContext(context.Background(), name)
+}
+
+// DomainLookupByNameObjectContext is like DomainLookupByNameObject but gives up waiting for the reply once ctx is done.
+func (m *Connect) DomainLookupByNameObjectContext(ctx context.Context, name string) (domain *Domain, err error) {
+	objPath, err := m.DomainLookupByNameContext(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return NewDomain(m.conn, objPath), nil
+}
+
 // DomainLookupByUUID See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainLookupByUUIDString
 func (m *Connect) DomainLookupByUUID(uuid string) (domain dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.Connect.DomainLookupByUUID", 0, uuid).Store(&domain)
+	return m.DomainLookupByUUIDContext(context.Background(), uuid)
+}
+
+// DomainLookupByUUIDContext is like DomainLookupByUUID but gives up waiting for the reply once ctx is done.
+func (m *Connect) DomainLookupByUUIDContext(ctx context.Context, uuid string) (domain dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.DomainLookupByUUID", uuid).Store(&domain)
 	return
 }
 
+// DomainLookupByUUIDObject is like DomainLookupByUUID but returns *Domain instead of an object path.
+func (m *Connect) DomainLookupByUUIDObject(uuid string) (domain *Domain, err error) {
+	return m.DomainLookupByUUIDObjectContext(context.Background(), uuid)
+}
+
+// DomainLookupByUUIDObjectContext is like DomainLookupByUUIDObject but gives up waiting for the reply once ctx is done.
+func (m *Connect) DomainLookupByUUIDObjectContext(ctx context.Context, uuid string) (domain *Domain, err error) {
+	objPath, err := m.DomainLookupByUUIDContext(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	return NewDomain(m.conn, objPath), nil
+}
+
 // DomainRestore See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainRestoreFlags Empty string can be used to pass a NULL as @xml argument.
-func (m *Connect) DomainRestore(from string, xml string, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Connect.DomainRestore", 0, from, xml, flags).Store()
+func (m *Connect) DomainRestore(from string, xml string, flags DomainSaveRestoreFlags) (err error) {
+	return m.DomainRestoreContext(context.Background(), from, xml, flags)
+}
+
+// DomainRestoreContext is like DomainRestore but gives up waiting for the reply once ctx is done.
+func (m *Connect) DomainRestoreContext(ctx context.Context, from string, xml string, flags DomainSaveRestoreFlags) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.DomainRestore", from, xml, flags).Store()
 	return
 }
 
 // DomainSaveImageDefineXML See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainSaveImageDefineXML
-func (m *Connect) DomainSaveImageDefineXML(file string, xml string, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Connect.DomainSaveImageDefineXML", 0, file, xml, flags).Store()
+func (m *Connect) DomainSaveImageDefineXML(file string, xml string, flags DomainSaveRestoreFlags) (err error) {
+	return m.DomainSaveImageDefineXMLContext(context.Background(), file, xml, flags)
+}
+
+// DomainSaveImageDefineXMLContext is like DomainSaveImageDefineXML but gives up waiting for the reply once ctx is done.
+func (m *Connect) DomainSaveImageDefineXMLContext(ctx context.Context, file string, xml string, flags DomainSaveRestoreFlags) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.DomainSaveImageDefineXML", file, xml, flags).Store()
 	return
 }
 
 // DomainSaveImageGetXMLDesc See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainSaveImageGetXMLDesc
-func (m *Connect) DomainSaveImageGetXMLDesc(file string, flags uint32) (xml string, err error) {
-	err = m.object.Call("org.libvirt.Connect.DomainSaveImageGetXMLDesc", 0, file, flags).Store(&xml)
+func (m *Connect) DomainSaveImageGetXMLDesc(file string, flags DomainXMLFlags) (xml string, err error) {
+	return m.DomainSaveImageGetXMLDescContext(context.Background(), file, flags)
+}
+
+// DomainSaveImageGetXMLDescContext is like DomainSaveImageGetXMLDesc but gives up waiting for the reply once ctx is done.
+func (m *Connect) DomainSaveImageGetXMLDescContext(ctx context.Context, file string, flags DomainXMLFlags) (xml string, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.DomainSaveImageGetXMLDesc", file, flags).Store(&xml)
 	return
 }
 
 // FindStoragePoolSources See https://libvirt.org/html/libvirt-libvirt-storage.html#virConnectFindStoragePoolSources Empty string can be used to pass a NULL as @srcSpec argument.
 func (m *Connect) FindStoragePoolSources(itype string, srcSpec string, flags uint32) (storagePoolSources string, err error) {
-	err = m.object.Call("org.libvirt.Connect.FindStoragePoolSources", 0, itype, srcSpec, flags).Store(&storagePoolSources)
+	return m.FindStoragePoolSourcesContext(context.Background(), itype, srcSpec, flags)
+}
+
+// FindStoragePoolSourcesContext is like FindStoragePoolSources but gives up waiting for the reply once ctx is done.
+func (m *Connect) FindStoragePoolSourcesContext(ctx context.Context, itype string, srcSpec string, flags uint32) (storagePoolSources string, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.FindStoragePoolSources", itype, srcSpec, flags).Store(&storagePoolSources)
 	return
 }
 
 // GetAllDomainStats See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectGetAllDomainStats
-func (m *Connect) GetAllDomainStats(stats uint32, flags uint32) (records []interface{}, err error) {
-	err = m.object.Call("org.libvirt.Connect.GetAllDomainStats", 0, stats, flags).Store(&records)
+func (m *Connect) GetAllDomainStats(stats DomainStatsTypes, flags ConnectGetAllDomainStatsFlags) (records []DomainStatsRecord, err error) {
+	return m.GetAllDomainStatsContext(context.Background(), stats, flags)
+}
+
+// GetAllDomainStatsContext is like GetAllDomainStats but gives up waiting for the reply once ctx is done.
+func (m *Connect) GetAllDomainStatsContext(ctx context.Context, stats DomainStatsTypes, flags ConnectGetAllDomainStatsFlags) (records []DomainStatsRecord, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.GetAllDomainStats", stats, flags).Store(&records)
 	return
 }
 
 // GetCapabilities See https://libvirt.org/html/libvirt-libvirt-host.html#virConnectGetCapabilities
 func (m *Connect) GetCapabilities() (capabilities string, err error) {
-	err = m.object.Call("org.libvirt.Connect.GetCapabilities", 0).Store(&capabilities)
+	return m.GetCapabilitiesContext(context.Background())
+}
+
+// GetCapabilitiesContext is like GetCapabilities but gives up waiting for the reply once ctx is done.
+func (m *Connect) GetCapabilitiesContext(ctx context.Context) (capabilities string, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.GetCapabilities").Store(&capabilities)
 	return
 }
 
 // GetCPUModelNames See https://libvirt.org/html/libvirt-libvirt-host.html#virConnectGetCPUModelNames
 func (m *Connect) GetCPUModelNames(arch string, flags uint32) (models []string, err error) {
-	err = m.object.Call("org.libvirt.Connect.GetCPUModelNames", 0, arch, flags).Store(&models)
+	return m.GetCPUModelNamesContext(context.Background(), arch, flags)
+}
+
+// GetCPUModelNamesContext is like GetCPUModelNames but gives up waiting for the reply once ctx is done.
+func (m *Connect) GetCPUModelNamesContext(ctx context.Context, arch string, flags uint32) (models []string, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.GetCPUModelNames", arch, flags).Store(&models)
 	return
 }
 
 // GetDomainCapabilities See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectGetDomainCapabilities Empty string can be used to pass a NULL as @emulatorbin, @arch, @machine or @virttype argument.
 func (m *Connect) GetDomainCapabilities(emulatorbin string, arch string, machine string, virttype string, flags uint32) (domCapabilities string, err error) {
-	err = m.object.Call("org.libvirt.Connect.GetDomainCapabilities", 0, emulatorbin, arch, machine, virttype, flags).Store(&domCapabilities)
+	return m.GetDomainCapabilitiesContext(context.Background(), emulatorbin, arch, machine, virttype, flags)
+}
+
+// GetDomainCapabilitiesContext is like GetDomainCapabilities but gives up waiting for the reply once ctx is done.
+func (m *Connect) GetDomainCapabilitiesContext(ctx context.Context, emulatorbin string, arch string, machine string, virttype string, flags uint32) (domCapabilities string, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.GetDomainCapabilities", emulatorbin, arch, machine, virttype, flags).Store(&domCapabilities)
 	return
 }
 
 // GetSysinfo See https://libvirt.org/html/libvirt-libvirt-host.html#virConnectGetSysinfo
 func (m *Connect) GetSysinfo(flags uint32) (sysinfo string, err error) {
-	err = m.object.Call("org.libvirt.Connect.GetSysinfo", 0, flags).Store(&sysinfo)
+	return m.GetSysinfoContext(context.Background(), flags)
+}
+
+// GetSysinfoContext is like GetSysinfo but gives up waiting for the reply once ctx is done.
+func (m *Connect) GetSysinfoContext(ctx context.Context, flags uint32) (sysinfo string, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.GetSysinfo", flags).Store(&sysinfo)
 	return
 }
 
 // InterfaceChangeBegin See https://libvirt.org/html/libvirt-libvirt-interface.html#virInterfaceChangeBegin
 func (m *Connect) InterfaceChangeBegin(flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Connect.InterfaceChangeBegin", 0, flags).Store()
+	return m.InterfaceChangeBeginContext(context.Background(), flags)
+}
+
+// InterfaceChangeBeginContext is like InterfaceChangeBegin but gives up waiting for the reply once ctx is done.
+func (m *Connect) InterfaceChangeBeginContext(ctx context.Context, flags uint32) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.InterfaceChangeBegin", flags).Store()
 	return
 }
 
 // InterfaceChangeCommit See https://libvirt.org/html/libvirt-libvirt-interface.html#virInterfaceChangeCommit
 func (m *Connect) InterfaceChangeCommit(flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Connect.InterfaceChangeCommit", 0, flags).Store()
+	return m.InterfaceChangeCommitContext(context.Background(), flags)
+}
+
+// InterfaceChangeCommitContext is like InterfaceChangeCommit but gives up waiting for the reply once ctx is done.
+func (m *Connect) InterfaceChangeCommitContext(ctx context.Context, flags uint32) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.InterfaceChangeCommit", flags).Store()
 	return
 }
 
 // InterfaceChangeRollback See https://libvirt.org/html/libvirt-libvirt-interface.html#virInterfaceChangeRollback
 func (m *Connect) InterfaceChangeRollback(flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Connect.InterfaceChangeRollback", 0, flags).Store()
+	return m.InterfaceChangeRollbackContext(context.Background(), flags)
+}
+
+// InterfaceChangeRollbackContext is like InterfaceChangeRollback but gives up waiting for the reply once ctx is done.
+func (m *Connect) InterfaceChangeRollbackContext(ctx context.Context, flags uint32) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.InterfaceChangeRollback", flags).Store()
 	return
 }
 
 // InterfaceDefineXML See https://libvirt.org/html/libvirt-libvirt-interface.html#virInterfaceDefineXML
 func (m *Connect) InterfaceDefineXML(xml string, flags uint32) (ointerface dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.Connect.InterfaceDefineXML", 0, xml, flags).Store(&ointerface)
+	return m.InterfaceDefineXMLContext(context.Background(), xml, flags)
+}
+
+// InterfaceDefineXMLContext is like InterfaceDefineXML but gives up waiting for the reply once ctx is done.
+func (m *Connect) InterfaceDefineXMLContext(ctx context.Context, xml string, flags uint32) (ointerface dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.InterfaceDefineXML", xml, flags).Store(&ointerface)
 	return
 }
 
+// InterfaceDefineXMLObject is like InterfaceDefineXML but returns *Interface instead of an object path.
+func (m *Connect) InterfaceDefineXMLObject(xml string, flags uint32) (ointerface *Interface, err error) {
+	return m.InterfaceDefineXMLObjectContext(context.Background(), xml, flags)
+}
+
+// InterfaceDefineXMLObjectContext is like InterfaceDefineXMLObject but gives up waiting for the reply once ctx is done.
+func (m *Connect) InterfaceDefineXMLObjectContext(ctx context.Context, xml string, flags uint32) (ointerface *Interface, err error) {
+	objPath, err := m.InterfaceDefineXMLContext(ctx, xml, flags)
+	if err != nil {
+		return nil, err
+	}
+	return NewInterface(m.conn, objPath), nil
+}
+
 // InterfaceLookupByMAC See https://libvirt.org/html/libvirt-libvirt-interface.html#virInterfaceLookupByMACString
 func (m *Connect) InterfaceLookupByMAC(mac string) (ointerface dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.Connect.InterfaceLookupByMAC", 0, mac).Store(&ointerface)
+	return m.InterfaceLookupByMACContext(context.Background(), mac)
+}
+
+// InterfaceLookupByMACContext is like InterfaceLookupByMAC but gives up waiting for the reply once ctx is done.
+func (m *Connect) InterfaceLookupByMACContext(ctx context.Context, mac string) (ointerface dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.InterfaceLookupByMAC", mac).Store(&ointerface)
 	return
 }
 
+// InterfaceLookupByMACObject is like InterfaceLookupByMAC but returns *Interface instead of an object path.
+func (m *Connect) InterfaceLookupByMACObject(mac string) (ointerface *Interface, err error) {
+	return m.InterfaceLookupByMACObjectContext(context.Background(), mac)
+}
+
+// InterfaceLookupByMACObjectContext is like InterfaceLookupByMACObject but gives up waiting for the reply once ctx is done.
+func (m *Connect) InterfaceLookupByMACObjectContext(ctx context.Context, mac string) (ointerface *Interface, err error) {
+	objPath, err := m.InterfaceLookupByMACContext(ctx, mac)
+	if err != nil {
+		return nil, err
+	}
+	return NewInterface(m.conn, objPath), nil
+}
+
 // InterfaceLookupByName See https://libvirt.org/html/libvirt-libvirt-interface.html#virInterfaceLookupByName
 func (m *Connect) InterfaceLookupByName(name string) (ointerface dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.Connect.InterfaceLookupByName", 0, name).Store(&ointerface)
+	return m.InterfaceLookupByNameContext(context.Background(), name)
+}
+
+// InterfaceLookupByNameContext is like InterfaceLookupByName but gives up waiting for the reply once ctx is done.
+func (m *Connect) InterfaceLookupByNameContext(ctx context.Context, name string) (ointerface dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.InterfaceLookupByName", name).Store(&ointerface)
 	return
 }
 
+// InterfaceLookupByNameObject is like InterfaceLookupByName but returns *Interface instead of an object path.
+func (m *Connect) InterfaceLookupByNameObject(name string) (ointerface *Interface, err error) {
+	return m.InterfaceLookupByNameObjectContext(context.Background(), name)
+}
+
+// InterfaceLookupByNameObjectContext is like InterfaceLookupByNameObject but gives up waiting for the reply once ctx is done.
+func (m *Connect) InterfaceLookupByNameObjectContext(ctx context.Context, name string) (ointerface *Interface, err error) {
+	objPath, err := m.InterfaceLookupByNameContext(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return NewInterface(m.conn, objPath), nil
+}
+
 // ListDomains See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectListAllDomains
-func (m *Connect) ListDomains(flags uint32) (domains []dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.Connect.ListDomains", 0, flags).Store(&domains)
+func (m *Connect) ListDomains(flags ConnectListAllDomainsFlags) (domains []dbus.ObjectPath, err error) {
+	return m.ListDomainsContext(context.Background(), flags)
+}
+
+// ListDomainsContext is like ListDomains but gives up waiting for the reply once ctx is done.
+func (m *Connect) ListDomainsContext(ctx context.Context, flags ConnectListAllDomainsFlags) (domains []dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.ListDomains", flags).Store(&domains)
 	return
 }
 
+// ListDomainsObjects is like ListDomains but returns []*Domain instead of object paths.
+func (m *Connect) ListDomainsObjects(flags ConnectListAllDomainsFlags) (domains []*Domain, err error) {
+	return m.ListDomainsObjectsContext(context.Background(), flags)
+}
+
+// ListDomainsObjectsContext is like ListDomainsObjects but gives up waiting for the reply once ctx is done.
+func (m *Connect) ListDomainsObjectsContext(ctx context.Context, flags ConnectListAllDomainsFlags) (domains []*Domain, err error) {
+	objPaths, err := m.ListDomainsContext(ctx, flags)
+	if err != nil {
+		return nil, err
+	}
+	domains = make([]*Domain, len(objPaths))
+	for i, objPath := range objPaths {
+		domains[i] = NewDomain(m.conn, objPath)
+	}
+	return domains, nil
+}
+
 // ListInterfaces See https://libvirt.org/html/libvirt-libvirt-interface.html#virConnectListAllInterfaces
-func (m *Connect) ListInterfaces(flags uint32) (interfaces []dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.Connect.ListInterfaces", 0, flags).Store(&interfaces)
+func (m *Connect) ListInterfaces(flags ConnectListAllInterfacesFlags) (interfaces []dbus.ObjectPath, err error) {
+	return m.ListInterfacesContext(context.Background(), flags)
+}
+
+// ListInterfacesContext is like ListInterfaces but gives up waiting for the reply once ctx is done.
+func (m *Connect) ListInterfacesContext(ctx context.Context, flags ConnectListAllInterfacesFlags) (interfaces []dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.ListInterfaces", flags).Store(&interfaces)
 	return
 }
 
+// ListInterfacesObjects is like ListInterfaces but returns []*Interface instead of object paths.
+func (m *Connect) ListInterfacesObjects(flags ConnectListAllInterfacesFlags) (interfaces []*Interface, err error) {
+	return m.ListInterfacesObjectsContext(context.Background(), flags)
+}
+
+// ListInterfacesObjectsContext is like ListInterfacesObjects but gives up waiting for the reply once ctx is done.
+func (m *Connect) ListInterfacesObjectsContext(ctx context.Context, flags ConnectListAllInterfacesFlags) (interfaces []*Interface, err error) {
+	objPaths, err := m.ListInterfacesContext(ctx, flags)
+	if err != nil {
+		return nil, err
+	}
+	interfaces = make([]*Interface, len(objPaths))
+	for i, objPath := range objPaths {
+		interfaces[i] = NewInterface(m.conn, objPath)
+	}
+	return interfaces, nil
+}
+
 // ListNetworks See https://libvirt.org/html/libvirt-libvirt-network.html#virConnectListAllNetworks
-func (m *Connect) ListNetworks(flags uint32) (networks []dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.Connect.ListNetworks", 0, flags).Store(&networks)
+func (m *Connect) ListNetworks(flags ConnectListAllNetworksFlags) (networks []dbus.ObjectPath, err error) {
+	return m.ListNetworksContext(context.Background(), flags)
+}
+
+// ListNetworksContext is like ListNetworks but gives up waiting for the reply once ctx is done.
+func (m *Connect) ListNetworksContext(ctx context.Context, flags ConnectListAllNetworksFlags) (networks []dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.ListNetworks", flags).Store(&networks)
 	return
 }
 
+// ListNetworksObjects is like ListNetworks but returns []*Network instead of object paths.
+func (m *Connect) ListNetworksObjects(flags ConnectListAllNetworksFlags) (networks []*Network, err error) {
+	return m.ListNetworksObjectsContext(context.Background(), flags)
+}
+
+// ListNetworksObjectsContext is like ListNetworksObjects but gives up waiting for the reply once ctx is done.
+func (m *Connect) ListNetworksObjectsContext(ctx context.Context, flags ConnectListAllNetworksFlags) (networks []*Network, err error) {
+	objPaths, err := m.ListNetworksContext(ctx, flags)
+	if err != nil {
+		return nil, err
+	}
+	networks = make([]*Network, len(objPaths))
+	for i, objPath := range objPaths {
+		networks[i] = NewNetwork(m.conn, objPath)
+	}
+	return networks, nil
+}
+
 // ListNodeDevices See https://libvirt.org/html/libvirt-libvirt-nodedev.html#virConnectListAllNodeDevices
-func (m *Connect) ListNodeDevices(flags uint32) (devs []dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.Connect.ListNodeDevices", 0, flags).Store(&devs)
+func (m *Connect) ListNodeDevices(flags ConnectListAllNodeDeviceFlags) (devs []dbus.ObjectPath, err error) {
+	return m.ListNodeDevicesContext(context.Background(), flags)
+}
+
+// ListNodeDevicesContext is like ListNodeDevices but gives up waiting for the reply once ctx is done.
+func (m *Connect) ListNodeDevicesContext(ctx context.Context, flags ConnectListAllNodeDeviceFlags) (devs []dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.ListNodeDevices", flags).Store(&devs)
 	return
 }
 
+// ListNodeDevicesObjects is like ListNodeDevices but returns []*NodeDevice instead of object paths.
+func (m *Connect) ListNodeDevicesObjects(flags ConnectListAllNodeDeviceFlags) (devs []*NodeDevice, err error) {
+	return m.ListNodeDevicesObjectsContext(context.Background(), flags)
+}
+
+// ListNodeDevicesObjectsContext is like ListNodeDevicesObjects but gives up waiting for the reply once ctx is done.
+func (m *Connect) ListNodeDevicesObjectsContext(ctx context.Context, flags ConnectListAllNodeDeviceFlags) (devs []*NodeDevice, err error) {
+	objPaths, err := m.ListNodeDevicesContext(ctx, flags)
+	if err != nil {
+		return nil, err
+	}
+	devs = make([]*NodeDevice, len(objPaths))
+	for i, objPath := range objPaths {
+		devs[i] = NewNodeDevice(m.conn, objPath)
+	}
+	return devs, nil
+}
+
 // ListNWFilters See https://libvirt.org/html/libvirt-libvirt-nwfilter.html#virConnectListAllNWFilters
 func (m *Connect) ListNWFilters(flags uint32) (nwfilters []dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.Connect.ListNWFilters", 0, flags).Store(&nwfilters)
+	return m.ListNWFiltersContext(context.Background(), flags)
+}
+
+// ListNWFiltersContext is like ListNWFilters but gives up waiting for the reply once ctx is done.
+func (m *Connect) ListNWFiltersContext(ctx context.Context, flags uint32) (nwfilters []dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.ListNWFilters", flags).Store(&nwfilters)
 	return
 }
 
+// ListNWFiltersObjects is like ListNWFilters but returns []*NWFilter instead of object paths.
+func (m *Connect) ListNWFiltersObjects(flags uint32) (nwfilters []*NWFilter, err error) {
+	return m.ListNWFiltersObjectsContext(context.Background(), flags)
+}
+
+// ListNWFiltersObjectsContext is like ListNWFiltersObjects but gives up waiting for the reply once ctx is done.
+func (m *Connect) ListNWFiltersObjectsContext(ctx context.Context, flags uint32) (nwfilters []*NWFilter, err error) {
+	objPaths, err := m.ListNWFiltersContext(ctx, flags)
+	if err != nil {
+		return nil, err
+	}
+	nwfilters = make([]*NWFilter, len(objPaths))
+	for i, objPath := range objPaths {
+		nwfilters[i] = NewNWFilter(m.conn, objPath)
+	}
+	return nwfilters, nil
+}
+
 // ListSecrets See https://libvirt.org/html/libvirt-libvirt-secret.html#virConnectListAllSecrets
-func (m *Connect) ListSecrets(flags uint32) (secrets []dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.Connect.ListSecrets", 0, flags).Store(&secrets)
+func (m *Connect) ListSecrets(flags ConnectListAllSecretsFlags) (secrets []dbus.ObjectPath, err error) {
+	return m.ListSecretsContext(context.Background(), flags)
+}
+
+// ListSecretsContext is like ListSecrets but gives up waiting for the reply once ctx is done.
+func (m *Connect) ListSecretsContext(ctx context.Context, flags ConnectListAllSecretsFlags) (secrets []dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.ListSecrets", flags).Store(&secrets)
 	return
 }
 
+// ListSecretsObjects is like ListSecrets but returns []*Secret instead of object paths.
+func (m *Connect) ListSecretsObjects(flags ConnectListAllSecretsFlags) (secrets []*Secret, err error) {
+	return m.ListSecretsObjectsContext(context.Background(), flags)
+}
+
+// ListSecretsObjectsContext is like ListSecretsObjects but gives up waiting for the reply once ctx is done.
+func (m *Connect) ListSecretsObjectsContext(ctx context.Context, flags ConnectListAllSecretsFlags) (secrets []*Secret, err error) {
+	objPaths, err := m.ListSecretsContext(ctx, flags)
+	if err != nil {
+		return nil, err
+	}
+	secrets = make([]*Secret, len(objPaths))
+	for i, objPath := range objPaths {
+		secrets[i] = NewSecret(m.conn, objPath)
+	}
+	return secrets, nil
+}
+
 // ListStoragePools See https://libvirt.org/html/libvirt-libvirt-storage.html#virConnectListAllStoragePools
-func (m *Connect) ListStoragePools(flags uint32) (storagePools []dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.Connect.ListStoragePools", 0, flags).Store(&storagePools)
+func (m *Connect) ListStoragePools(flags ConnectListAllStoragePoolsFlags) (storagePools []dbus.ObjectPath, err error) {
+	return m.ListStoragePoolsContext(context.Background(), flags)
+}
+
+// ListStoragePoolsContext is like ListStoragePools but gives up waiting for the reply once ctx is done.
+func (m *Connect) ListStoragePoolsContext(ctx context.Context, flags ConnectListAllStoragePoolsFlags) (storagePools []dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.ListStoragePools", flags).Store(&storagePools)
 	return
 }
 
+// ListStoragePoolsObjects is like ListStoragePools but returns []*StoragePool instead of object paths.
+func (m *Connect) ListStoragePoolsObjects(flags ConnectListAllStoragePoolsFlags) (storagePools []*StoragePool, err error) {
+	return m.ListStoragePoolsObjectsContext(context.Background(), flags)
+}
+
+// ListStoragePoolsObjectsContext is like ListStoragePoolsObjects but gives up waiting for the reply once ctx is done.
+func (m *Connect) ListStoragePoolsObjectsContext(ctx context.Context, flags ConnectListAllStoragePoolsFlags) (storagePools []*StoragePool, err error) {
+	objPaths, err := m.ListStoragePoolsContext(ctx, flags)
+	if err != nil {
+		return nil, err
+	}
+	storagePools = make([]*StoragePool, len(objPaths))
+	for i, objPath := range objPaths {
+		storagePools[i] = NewStoragePool(m.conn, objPath)
+	}
+	return storagePools, nil
+}
+
 // NetworkCreateXML See https://libvirt.org/html/libvirt-libvirt-network.html#virNetworkCreateXML
 func (m *Connect) NetworkCreateXML(xml string) (network dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.Connect.NetworkCreateXML", 0, xml).Store(&network)
+	return m.NetworkCreateXMLContext(context.Background(), xml)
+}
+
+// NetworkCreateXMLContext is like NetworkCreateXML but gives up waiting for the reply once ctx is done.
+func (m *Connect) NetworkCreateXMLContext(ctx context.Context, xml string) (network dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.NetworkCreateXML", xml).Store(&network)
 	return
 }
 
+// NetworkCreateXMLObject is like NetworkCreateXML but returns *Network instead of an object path.
+func (m *Connect) NetworkCreateXMLObject(xml string) (network *Network, err error) {
+	return m.NetworkCreateXMLObjectContext(context.Background(), xml)
+}
+
+// NetworkCreateXMLObjectContext is like NetworkCreateXMLObject but gives up waiting for the reply once ctx is done.
+func (m *Connect) NetworkCreateXMLObjectContext(ctx context.Context, xml string) (network *Network, err error) {
+	objPath, err := m.NetworkCreateXMLContext(ctx, xml)
+	if err != nil {
+		return nil, err
+	}
+	return NewNetwork(m.conn, objPath), nil
+}
+
 // NetworkDefineXML See https://libvirt.org/html/libvirt-libvirt-network.html#virNetworkDefineXML
 func (m *Connect) NetworkDefineXML(xml string) (network dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.Connect.NetworkDefineXML", 0, xml).Store(&network)
+	return m.NetworkDefineXMLContext(context.Background(), xml)
+}
+
+// NetworkDefineXMLContext is like NetworkDefineXML but gives up waiting for the reply once ctx is done.
+func (m *Connect) NetworkDefineXMLContext(ctx context.Context, xml string) (network dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.NetworkDefineXML", xml).Store(&network)
 	return
 }
 
+// NetworkDefineXMLObject is like NetworkDefineXML but returns *Network instead of an object path.
+func (m *Connect) NetworkDefineXMLObject(xml string) (network *Network, err error) {
+	return m.NetworkDefineXMLObjectContext(context.Background(), xml)
+}
+
+// NetworkDefineXMLObjectContext is like NetworkDefineXMLObject but gives up waiting for the reply once ctx is done.
+func (m *Connect) NetworkDefineXMLObjectContext(ctx context.Context, xml string) (network *Network, err error) {
+	objPath, err := m.NetworkDefineXMLContext(ctx, xml)
+	if err != nil {
+		return nil, err
+	}
+	return NewNetwork(m.conn, objPath), nil
+}
+
 // NetworkLookupByName See https://libvirt.org/html/libvirt-libvirt-network.html#virNetworkLookupByName
 func (m *Connect) NetworkLookupByName(name string) (network dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.Connect.NetworkLookupByName", 0, name).Store(&network)
+	return m.NetworkLookupByNameContext(context.Background(), name)
+}
+
+// NetworkLookupByNameContext is like NetworkLookupByName but gives up waiting for the reply once ctx is done.
+func (m *Connect) NetworkLookupByNameContext(ctx context.Context, name string) (network dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.NetworkLookupByName", name).Store(&network)
 	return
 }
 
+// NetworkLookupByNameObject is like NetworkLookupByName but returns *Network instead of an object path.
+func (m *Connect) NetworkLookupByNameObject(name string) (network *Network, err error) {
+	return m.NetworkLookupByNameObjectContext(context.Background(), name)
+}
+
+// NetworkLookupByNameObjectContext is like NetworkLookupByNameObject but gives up waiting for the reply once ctx is done.
+func (m *Connect) NetworkLookupByNameObjectContext(ctx context.Context, name string) (network *Network, err error) {
+	objPath, err := m.NetworkLookupByNameContext(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return NewNetwork(m.conn, objPath), nil
+}
+
 // NetworkLookupByUUID See https://libvirt.org/html/libvirt-libvirt-network.html#virNetworkLookupByUUIDString
 func (m *Connect) NetworkLookupByUUID(uuid string) (network dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.Connect.NetworkLookupByUUID", 0, uuid).Store(&network)
+	return m.NetworkLookupByUUIDContext(context.Background(), uuid)
+}
+
+// NetworkLookupByUUIDContext is like NetworkLookupByUUID but gives up waiting for the reply once ctx is done.
+func (m *Connect) NetworkLookupByUUIDContext(ctx context.Context, uuid string) (network dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.NetworkLookupByUUID", uuid).Store(&network)
 	return
 }
 
+// NetworkLookupByUUIDObject is like NetworkLookupByUUID but returns *Network instead of an object path.
+func (m *Connect) NetworkLookupByUUIDObject(uuid string) (network *Network, err error) {
+	return m.NetworkLookupByUUIDObjectContext(context.Background(), uuid)
+}
+
+// NetworkLookupByUUIDObjectContext is like NetworkLookupByUUIDObject but gives up waiting for the reply once ctx is done.
+func (m *Connect) NetworkLookupByUUIDObjectContext(ctx context.Context, uuid string) (network *Network, err error) {
+	objPath, err := m.NetworkLookupByUUIDContext(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	return NewNetwork(m.conn, objPath), nil
+}
+
 // NodeDeviceCreateXML See https://libvirt.org/html/libvirt-libvirt-nodedev.html#virNodeDeviceCreateXML
 func (m *Connect) NodeDeviceCreateXML(xml string, flags uint32) (dev dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.Connect.NodeDeviceCreateXML", 0, xml, flags).Store(&dev)
+	return m.NodeDeviceCreateXMLContext(context.Background(), xml, flags)
+}
+
+// NodeDeviceCreateXMLContext is like NodeDeviceCreateXML but gives up waiting for the reply once ctx is done.
+func (m *Connect) NodeDeviceCreateXMLContext(ctx context.Context, xml string, flags uint32) (dev dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.NodeDeviceCreateXML", xml, flags).Store(&dev)
 	return
 }
 
+// NodeDeviceCreateXMLObject is like NodeDeviceCreateXML but returns *NodeDevice instead of an object path.
+func (m *Connect) NodeDeviceCreateXMLObject(xml string, flags uint32) (dev *NodeDevice, err error) {
+	return m.NodeDeviceCreateXMLObjectContext(context.Background(), xml, flags)
+}
+
+// NodeDeviceCreateXMLObjectContext is like NodeDeviceCreateXMLObject but gives up waiting for the reply once ctx is done.
+func (m *Connect) NodeDeviceCreateXMLObjectContext(ctx context.Context, xml string, flags uint32) (dev *NodeDevice, err error) {
+	objPath, err := m.NodeDeviceCreateXMLContext(ctx, xml, flags)
+	if err != nil {
+		return nil, err
+	}
+	return NewNodeDevice(m.conn, objPath), nil
+}
+
 // NodeDeviceLookupByName See https://libvirt.org/html/libvirt-libvirt-nodedev.html#virNodeDeviceLookupByName
 func (m *Connect) NodeDeviceLookupByName(name string) (dev dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.Connect.NodeDeviceLookupByName", 0, name).Store(&dev)
+	return m.NodeDeviceLookupByNameContext(context.Background(), name)
+}
+
+// NodeDeviceLookupByNameContext is like NodeDeviceLookupByName but gives up waiting for the reply once ctx is done.
+func (m *Connect) NodeDeviceLookupByNameContext(ctx context.Context, name string) (dev dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.NodeDeviceLookupByName", name).Store(&dev)
 	return
 }
 
+// NodeDeviceLookupByNameObject is like NodeDeviceLookupByName but returns *NodeDevice instead of an object path.
+func (m *Connect) NodeDeviceLookupByNameObject(name string) (dev *NodeDevice, err error) {
+	return m.NodeDeviceLookupByNameObjectContext(context.Background(), name)
+}
+
+// NodeDeviceLookupByNameObjectContext is like NodeDeviceLookupByNameObject but gives up waiting for the reply once ctx is done.
+func (m *Connect) NodeDeviceLookupByNameObjectContext(ctx context.Context, name string) (dev *NodeDevice, err error) {
+	objPath, err := m.NodeDeviceLookupByNameContext(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return NewNodeDevice(m.conn, objPath), nil
+}
+
 // NodeDeviceLookupSCSIHostByWWN See https://libvirt.org/html/libvirt-libvirt-nodedev.html#virNodeDeviceLookupSCSIHostByWWN
 func (m *Connect) NodeDeviceLookupSCSIHostByWWN(wwnn string, wwpn string, flags uint32) (dev dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.Connect.NodeDeviceLookupSCSIHostByWWN", 0, wwnn, wwpn, flags).Store(&dev)
+	return m.NodeDeviceLookupSCSIHostByWWNContext(context.Background(), wwnn, wwpn, flags)
+}
+
+// NodeDeviceLookupSCSIHostByWWNContext is like NodeDeviceLookupSCSIHostByWWN but gives up waiting for the reply once ctx is done.
+func (m *Connect) NodeDeviceLookupSCSIHostByWWNContext(ctx context.Context, wwnn string, wwpn string, flags uint32) (dev dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.NodeDeviceLookupSCSIHostByWWN", wwnn, wwpn, flags).Store(&dev)
 	return
 }
 
+// NodeDeviceLookupSCSIHostByWWNObject is like NodeDeviceLookupSCSIHostByWWN but returns *NodeDevice instead of an object path.
+func (m *Connect) NodeDeviceLookupSCSIHostByWWNObject(wwnn string, wwpn string, flags uint32) (dev *NodeDevice, err error) {
+	return m.NodeDeviceLookupSCSIHostByWWNObjectContext(context.Background(), wwnn, wwpn, flags)
+}
+
+// NodeDeviceLookupSCSIHostByWWNObjectContext is like NodeDeviceLookupSCSIHostByWWNObject but gives up waiting for the reply once ctx is done.
+func (m *Connect) NodeDeviceLookupSCSIHostByWWNObjectContext(ctx context.Context, wwnn string, wwpn string, flags uint32) (dev *NodeDevice, err error) {
+	objPath, err := m.NodeDeviceLookupSCSIHostByWWNContext(ctx, wwnn, wwpn, flags)
+	if err != nil {
+		return nil, err
+	}
+	return NewNodeDevice(m.conn, objPath), nil
+}
+
 // NWFilterDefineXML See https://libvirt.org/html/libvirt-libvirt-nwfilter.html#virNWFilterDefineXML
 func (m *Connect) NWFilterDefineXML(xml string) (nwfilter dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.Connect.NWFilterDefineXML", 0, xml).Store(&nwfilter)
+	return m.NWFilterDefineXMLContext(context.Background(), xml)
+}
+
+// NWFilterDefineXMLContext is like NWFilterDefineXML but gives up waiting for the reply once ctx is done.
+func (m *Connect) NWFilterDefineXMLContext(ctx context.Context, xml string) (nwfilter dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.NWFilterDefineXML", xml).Store(&nwfilter)
 	return
 }
 
+// NWFilterDefineXMLObject is like NWFilterDefineXML but returns *NWFilter instead of an object path.
+func (m *Connect) NWFilterDefineXMLObject(xml string) (nwfilter *NWFilter, err error) {
+	return m.NWFilterDefineXMLObjectContext(context.Background(), xml)
+}
+
+// NWFilterDefineXMLObjectContext is like NWFilterDefineXMLObject but gives up waiting for the reply once ctx is done.
+func (m *Connect) NWFilterDefineXMLObjectContext(ctx context.Context, xml string) (nwfilter *NWFilter, err error) {
+	objPath, err := m.NWFilterDefineXMLContext(ctx, xml)
+	if err != nil {
+		return nil, err
+	}
+	return NewNWFilter(m.conn, objPath), nil
+}
+
 // NWFilterLookupByName See https://libvirt.org/html/libvirt-libvirt-nwfilter.html#virNWFilterLookupByName
 func (m *Connect) NWFilterLookupByName(name string) (nwfilter dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.Connect.NWFilterLookupByName", 0, name).Store(&nwfilter)
+	return m.NWFilterLookupByNameContext(context.Background(), name)
+}
+
+// NWFilterLookupByNameContext is like NWFilterLookupByName but gives up waiting for the reply once ctx is done.
+func (m *Connect) NWFilterLookupByNameContext(ctx context.Context, name string) (nwfilter dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.NWFilterLookupByName", name).Store(&nwfilter)
 	return
 }
 
+// NWFilterLookupByNameObject is like NWFilterLookupByName but returns *NWFilter instead of an object path.
+func (m *Connect) NWFilterLookupByNameObject(name string) (nwfilter *NWFilter, err error) {
+	return m.NWFilterLookupByNameObjectContext(context.Background(), name)
+}
+
+// NWFilterLookupByNameObjectContext is like NWFilterLookupByNameObject but gives up waiting for the reply once ctx is done.
+func (m *Connect) NWFilterLookupByNameObjectContext(ctx context.Context, name string) (nwfilter *NWFilter, err error) {
+	objPath, err := m.NWFilterLookupByNameContext(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return NewNWFilter(m.conn, objPath), nil
+}
+
 // NWFilterLookupByUUID See https://libvirt.org/html/libvirt-libvirt-nwfilter.html#virNWFilterLookupByUUIDString
 func (m *Connect) NWFilterLookupByUUID(uuid string) (nwfilter dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.Connect.NWFilterLookupByUUID", 0, uuid).Store(&nwfilter)
+	return m.NWFilterLookupByUUIDContext(context.Background(), uuid)
+}
+
+// NWFilterLookupByUUIDContext is like NWFilterLookupByUUID but gives up waiting for the reply once ctx is done.
+func (m *Connect) NWFilterLookupByUUIDContext(ctx context.Context, uuid string) (nwfilter dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.NWFilterLookupByUUID", uuid).Store(&nwfilter)
 	return
 }
 
+// NWFilterLookupByUUIDObject is like NWFilterLookupByUUID but returns *NWFilter instead of an object path.
+func (m *Connect) NWFilterLookupByUUIDObject(uuid string) (nwfilter *NWFilter, err error) {
+	return m.NWFilterLookupByUUIDObjectContext(context.Background(), uuid)
+}
+
+// NWFilterLookupByUUIDObjectContext is like NWFilterLookupByUUIDObject but gives up waiting for the reply once ctx is done.
+func (m *Connect) NWFilterLookupByUUIDObjectContext(ctx context.Context, uuid string) (nwfilter *NWFilter, err error) {
+	objPath, err := m.NWFilterLookupByUUIDContext(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	return NewNWFilter(m.conn, objPath), nil
+}
+
 // NodeGetCPUMap See https://libvirt.org/html/libvirt-libvirt-host.html#virNodeGetCPUMap
 func (m *Connect) NodeGetCPUMap(flags uint32) (res []bool, err error) {
-	err = m.object.Call("org.libvirt.Connect.NodeGetCPUMap", 0, flags).Store(&res)
+	return m.NodeGetCPUMapContext(context.Background(), flags)
+}
+
+// NodeGetCPUMapContext is like NodeGetCPUMap but gives up waiting for the reply once ctx is done.
+func (m *Connect) NodeGetCPUMapContext(ctx context.Context, flags uint32) (res []bool, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.NodeGetCPUMap", flags).Store(&res)
 	return
 }
 
 // NodeGetCPUStats See https://libvirt.org/html/libvirt-libvirt-host.html#virNodeGetCPUStats
 func (m *Connect) NodeGetCPUStats(cpuNum int32, flags uint32) (cpuStats map[string]uint64, err error) {
-	err = m.object.Call("org.libvirt.Connect.NodeGetCPUStats", 0, cpuNum, flags).Store(&cpuStats)
+	return m.NodeGetCPUStatsContext(context.Background(), cpuNum, flags)
+}
+
+// NodeGetCPUStatsContext is like NodeGetCPUStats but gives up waiting for the reply once ctx is done.
+func (m *Connect) NodeGetCPUStatsContext(ctx context.Context, cpuNum int32, flags uint32) (cpuStats map[string]uint64, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.NodeGetCPUStats", cpuNum, flags).Store(&cpuStats)
 	return
 }
 
 // NodeGetFreeMemory See https://libvirt.org/html/libvirt-libvirt-host.html#virNodeGetFreeMemory
 func (m *Connect) NodeGetFreeMemory() (freemem uint64, err error) {
-	err = m.object.Call("org.libvirt.Connect.NodeGetFreeMemory", 0).Store(&freemem)
+	return m.NodeGetFreeMemoryContext(context.Background())
+}
+
+// NodeGetFreeMemoryContext is like NodeGetFreeMemory but gives up waiting for the reply once ctx is done.
+func (m *Connect) NodeGetFreeMemoryContext(ctx context.Context) (freemem uint64, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.NodeGetFreeMemory").Store(&freemem)
 	return
 }
 
 // NodeGetMemoryParameters See https://libvirt.org/html/libvirt-libvirt-host.html#virNodeGetMemoryParameters
 func (m *Connect) NodeGetMemoryParameters(flags uint32) (memoryParameters map[string]interface{}, err error) {
-	err = m.object.Call("org.libvirt.Connect.NodeGetMemoryParameters", 0, flags).Store(&memoryParameters)
+	return m.NodeGetMemoryParametersContext(context.Background(), flags)
+}
+
+// NodeGetMemoryParametersContext is like NodeGetMemoryParameters but gives up waiting for the reply once ctx is done.
+func (m *Connect) NodeGetMemoryParametersContext(ctx context.Context, flags uint32) (memoryParameters map[string]interface{}, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.NodeGetMemoryParameters", flags).Store(&memoryParameters)
 	return
 }
 
 // NodeGetMemoryStats See https://libvirt.org/html/libvirt-libvirt-host.html#virNodeGetMemoryStats
 func (m *Connect) NodeGetMemoryStats(cellNum int32, flags uint32) (stats map[string]uint64, err error) {
-	err = m.object.Call("org.libvirt.Connect.NodeGetMemoryStats", 0, cellNum, flags).Store(&stats)
+	return m.NodeGetMemoryStatsContext(context.Background(), cellNum, flags)
+}
+
+// NodeGetMemoryStatsContext is like NodeGetMemoryStats but gives up waiting for the reply once ctx is done.
+func (m *Connect) NodeGetMemoryStatsContext(ctx context.Context, cellNum int32, flags uint32) (stats map[string]uint64, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.NodeGetMemoryStats", cellNum, flags).Store(&stats)
 	return
 }
 
 // NodeGetSecurityModel See https://libvirt.org/html/libvirt-libvirt-host.html#virNodeGetSecurityModel
-func (m *Connect) NodeGetSecurityModel() (secModel interface{}, err error) {
-	err = m.object.Call("org.libvirt.Connect.NodeGetSecurityModel", 0).Store(&secModel)
+func (m *Connect) NodeGetSecurityModel() (secModel NodeSecurityModel, err error) {
+	return m.NodeGetSecurityModelContext(context.Background())
+}
+
+// NodeGetSecurityModelContext is like NodeGetSecurityModel but gives up waiting for the reply once ctx is done.
+func (m *Connect) NodeGetSecurityModelContext(ctx context.Context) (secModel NodeSecurityModel, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.NodeGetSecurityModel").Store(&secModel)
 	return
 }
 
 // NodeSetMemoryParameters See https://libvirt.org/html/libvirt-libvirt-host.html#virNodeSetMemoryParameters
 func (m *Connect) NodeSetMemoryParameters(params map[string]interface{}, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Connect.NodeSetMemoryParameters", 0, params, flags).Store()
+	return m.NodeSetMemoryParametersContext(context.Background(), params, flags)
+}
+
+// NodeSetMemoryParametersContext is like NodeSetMemoryParameters but gives up waiting for the reply once ctx is done.
+func (m *Connect) NodeSetMemoryParametersContext(ctx context.Context, params map[string]interface{}, flags uint32) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.NodeSetMemoryParameters", params, flags).Store()
 	return
 }
 
 // SecretDefineXML See https://libvirt.org/html/libvirt-libvirt-secret.html#virSecretDefineXML
 func (m *Connect) SecretDefineXML(xml string, flags uint32) (secret dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.Connect.SecretDefineXML", 0, xml, flags).Store(&secret)
+	return m.SecretDefineXMLContext(context.Background(), xml, flags)
+}
+
+// SecretDefineXMLContext is like SecretDefineXML but gives up waiting for the reply once ctx is done.
+func (m *Connect) SecretDefineXMLContext(ctx context.Context, xml string, flags uint32) (secret dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.SecretDefineXML", xml, flags).Store(&secret)
 	return
 }
 
+// SecretDefineXMLObject is like SecretDefineXML but returns *Secret instead of an object path.
+func (m *Connect) SecretDefineXMLObject(xml string, flags uint32) (secret *Secret, err error) {
+	return m.SecretDefineXMLObjectContext(context.Background(), xml, flags)
+}
+
+// SecretDefineXMLObjectContext is like SecretDefineXMLObject but gives up waiting for the reply once ctx is done.
+func (m *Connect) SecretDefineXMLObjectContext(ctx context.Context, xml string, flags uint32) (secret *Secret, err error) {
+	objPath, err := m.SecretDefineXMLContext(ctx, xml, flags)
+	if err != nil {
+		return nil, err
+	}
+	return NewSecret(m.conn, objPath), nil
+}
+
 // SecretLookupByUUID See https://libvirt.org/html/libvirt-libvirt-secret.html#virSecretLookupByUUIDString
 func (m *Connect) SecretLookupByUUID(uuid string) (secret dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.Connect.SecretLookupByUUID", 0, uuid).Store(&secret)
+	return m.SecretLookupByUUIDContext(context.Background(), uuid)
+}
+
+// SecretLookupByUUIDContext is like SecretLookupByUUID but gives up waiting for the reply once ctx is done.
+func (m *Connect) SecretLookupByUUIDContext(ctx context.Context, uuid string) (secret dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.SecretLookupByUUID", uuid).Store(&secret)
 	return
 }
 
+// SecretLookupByUUIDObject is like SecretLookupByUUID but returns *Secret instead of an object path.
+func (m *Connect) SecretLookupByUUIDObject(uuid string) (secret *Secret, err error) {
+	return m.SecretLookupByUUIDObjectContext(context.Background(), uuid)
+}
+
+// SecretLookupByUUIDObjectContext is like SecretLookupByUUIDObject but gives up waiting for the reply once ctx is done.
+func (m *Connect) SecretLookupByUUIDObjectContext(ctx context.Context, uuid string) (secret *Secret, err error) {
+	objPath, err := m.SecretLookupByUUIDContext(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	return NewSecret(m.conn, objPath), nil
+}
+
 // SecretLookupByUsage See https://libvirt.org/html/libvirt-libvirt-secret.html#virSecretLookupByUsage
-func (m *Connect) SecretLookupByUsage(usageType int32, usageID string) (secret dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.Connect.SecretLookupByUsage", 0, usageType, usageID).Store(&secret)
+func (m *Connect) SecretLookupByUsage(usageType SecretUsageType, usageID string) (secret dbus.ObjectPath, err error) {
+	return m.SecretLookupByUsageContext(context.Background(), usageType, usageID)
+}
+
+// SecretLookupByUsageContext is like SecretLookupByUsage but gives up waiting for the reply once ctx is done.
+func (m *Connect) SecretLookupByUsageContext(ctx context.Context, usageType SecretUsageType, usageID string) (secret dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.SecretLookupByUsage", usageType, usageID).Store(&secret)
 	return
 }
 
+// SecretLookupByUsageObject is like SecretLookupByUsage but returns *Secret instead of an object path.
+func (m *Connect) SecretLookupByUsageObject(usageType SecretUsageType, usageID string) (secret *Secret, err error) {
+	return m.SecretLookupByUsageObjectContext(context.Background(), usageType, usageID)
+}
+
+// SecretLookupByUsageObjectContext is like SecretLookupByUsageObject but gives up waiting for the reply once ctx is done.
+func (m *Connect) SecretLookupByUsageObjectContext(ctx context.Context, usageType SecretUsageType, usageID string) (secret *Secret, err error) {
+	objPath, err := m.SecretLookupByUsageContext(ctx, usageType, usageID)
+	if err != nil {
+		return nil, err
+	}
+	return NewSecret(m.conn, objPath), nil
+}
+
 // StoragePoolCreateXML See https://libvirt.org/html/libvirt-libvirt-storage.html#virStoragePoolCreateXML
-func (m *Connect) StoragePoolCreateXML(xml string, flags uint32) (storagePool dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.Connect.StoragePoolCreateXML", 0, xml, flags).Store(&storagePool)
+func (m *Connect) StoragePoolCreateXML(xml string, flags StoragePoolCreateFlags) (storagePool dbus.ObjectPath, err error) {
+	return m.StoragePoolCreateXMLContext(context.Background(), xml, flags)
+}
+
+// StoragePoolCreateXMLContext is like StoragePoolCreateXML but gives up waiting for the reply once ctx is done.
+func (m *Connect) StoragePoolCreateXMLContext(ctx context.Context, xml string, flags StoragePoolCreateFlags) (storagePool dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.StoragePoolCreateXML", xml, flags).Store(&storagePool)
 	return
 }
 
+// StoragePoolCreateXMLObject is like StoragePoolCreateXML but returns *StoragePool instead of an object path.
+func (m *Connect) StoragePoolCreateXMLObject(xml string, flags StoragePoolCreateFlags) (storagePool *StoragePool, err error) {
+	return m.StoragePoolCreateXMLObjectContext(context.Background(), xml, flags)
+}
+
+// StoragePoolCreateXMLObjectContext is like StoragePoolCreateXMLObject but gives up waiting for the reply once ctx is done.
+func (m *Connect) StoragePoolCreateXMLObjectContext(ctx context.Context, xml string, flags StoragePoolCreateFlags) (storagePool *StoragePool, err error) {
+	objPath, err := m.StoragePoolCreateXMLContext(ctx, xml, flags)
+	if err != nil {
+		return nil, err
+	}
+	return NewStoragePool(m.conn, objPath), nil
+}
+
 // StoragePoolDefineXML See https://libvirt.org/html/libvirt-libvirt-storage.html#virStoragePoolDefineXML
 func (m *Connect) StoragePoolDefineXML(xml string, flags uint32) (storagePool dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.Connect.StoragePoolDefineXML", 0, xml, flags).Store(&storagePool)
+	return m.StoragePoolDefineXMLContext(context.Background(), xml, flags)
+}
+
+// StoragePoolDefineXMLContext is like StoragePoolDefineXML but gives up waiting for the reply once ctx is done.
+func (m *Connect) StoragePoolDefineXMLContext(ctx context.Context, xml string, flags uint32) (storagePool dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.StoragePoolDefineXML", xml, flags).Store(&storagePool)
 	return
 }
 
+// StoragePoolDefineXMLObject is like StoragePoolDefineXML but returns *StoragePool instead of an object path.
+func (m *Connect) StoragePoolDefineXMLObject(xml string, flags uint32) (storagePool *StoragePool, err error) {
+	return m.StoragePoolDefineXMLObjectContext(context.Background(), xml, flags)
+}
+
+// StoragePoolDefineXMLObjectContext is like StoragePoolDefineXMLObject but gives up waiting for the reply once ctx is done.
+func (m *Connect) StoragePoolDefineXMLObjectContext(ctx context.Context, xml string, flags uint32) (storagePool *StoragePool, err error) {
+	objPath, err := m.StoragePoolDefineXMLContext(ctx, xml, flags)
+	if err != nil {
+		return nil, err
+	}
+	return NewStoragePool(m.conn, objPath), nil
+}
+
 // StoragePoolLookupByName See https://libvirt.org/html/libvirt-libvirt-storage.html#virStoragePoolLookupByName
 func (m *Connect) StoragePoolLookupByName(name string) (storagePool dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.Connect.StoragePoolLookupByName", 0, name).Store(&storagePool)
+	return m.StoragePoolLookupByNameContext(context.Background(), name)
+}
+
+// StoragePoolLookupByNameContext is like StoragePoolLookupByName but gives up waiting for the reply once ctx is done.
+func (m *Connect) StoragePoolLookupByNameContext(ctx context.Context, name string) (storagePool dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.StoragePoolLookupByName", name).Store(&storagePool)
 	return
 }
 
+// StoragePoolLookupByNameObject is like StoragePoolLookupByName but returns *StoragePool instead of an object path.
+func (m *Connect) StoragePoolLookupByNameObject(name string) (storagePool *StoragePool, err error) {
+	return m.StoragePoolLookupByNameObjectContext(context.Background(), name)
+}
+
+// StoragePoolLookupByNameObjectContext is like StoragePoolLookupByNameObject but gives up waiting for the reply once ctx is done.
+func (m *Connect) StoragePoolLookupByNameObjectContext(ctx context.Context, name string) (storagePool *StoragePool, err error) {
+	objPath, err := m.StoragePoolLookupByNameContext(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return NewStoragePool(m.conn, objPath), nil
+}
+
 // StoragePoolLookupByUUID See https://libvirt.org/html/libvirt-libvirt-storage.html#virStoragePoolLookupByUUIDString
 func (m *Connect) StoragePoolLookupByUUID(uuid string) (storagePool dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.Connect.StoragePoolLookupByUUID", 0, uuid).Store(&storagePool)
+	return m.StoragePoolLookupByUUIDContext(context.Background(), uuid)
+}
+
+// StoragePoolLookupByUUIDContext is like StoragePoolLookupByUUID but gives up waiting for the reply once ctx is done.
+func (m *Connect) StoragePoolLookupByUUIDContext(ctx context.Context, uuid string) (storagePool dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.StoragePoolLookupByUUID", uuid).Store(&storagePool)
 	return
 }
 
+// StoragePoolLookupByUUIDObject is like StoragePoolLookupByUUID but returns *StoragePool instead of an object path.
+func (m *Connect) StoragePoolLookupByUUIDObject(uuid string) (storagePool *StoragePool, err error) {
+	return m.StoragePoolLookupByUUIDObjectContext(context.Background(), uuid)
+}
+
+// StoragePoolLookupByUUIDObjectContext is like StoragePoolLookupByUUIDObject but gives up waiting for the reply once ctx is done.
+func (m *Connect) StoragePoolLookupByUUIDObjectContext(ctx context.Context, uuid string) (storagePool *StoragePool, err error) {
+	objPath, err := m.StoragePoolLookupByUUIDContext(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	return NewStoragePool(m.conn, objPath), nil
+}
+
 // StorageVolLookupByKey See https://libvirt.org/html/libvirt-libvirt-storage.html#virStorageVolLookupByKey
 func (m *Connect) StorageVolLookupByKey(key string) (storageVol dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.Connect.StorageVolLookupByKey", 0, key).Store(&storageVol)
+	return m.StorageVolLookupByKeyContext(context.Background(), key)
+}
+
+// StorageVolLookupByKeyContext is like StorageVolLookupByKey but gives up waiting for the reply once ctx is done.
+func (m *Connect) StorageVolLookupByKeyContext(ctx context.Context, key string) (storageVol dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.StorageVolLookupByKey", key).Store(&storageVol)
 	return
 }
 
+// StorageVolLookupByKeyObject is like StorageVolLookupByKey but returns *StorageVol instead of an object path.
+func (m *Connect) StorageVolLookupByKeyObject(key string) (storageVol *StorageVol, err error) {
+	return m.StorageVolLookupByKeyObjectContext(context.Background(), key)
+}
+
+// StorageVolLookupByKeyObjectContext is like StorageVolLookupByKeyObject but gives up waiting for the reply once ctx is done.
+func (m *Connect) StorageVolLookupByKeyObjectContext(ctx context.Context, key string) (storageVol *StorageVol, err error) {
+	objPath, err := m.StorageVolLookupByKeyContext(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return NewStorageVol(m.conn, objPath), nil
+}
+
 // StorageVolLookupByPath See https://libvirt.org/html/libvirt-libvirt-storage.html#virStorageVolLookupByPath
 func (m *Connect) StorageVolLookupByPath(path string) (storageVol dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.Connect.StorageVolLookupByPath", 0, path).Store(&storageVol)
+	return m.StorageVolLookupByPathContext(context.Background(), path)
+}
+
+// StorageVolLookupByPathContext is like StorageVolLookupByPath but gives up waiting for the reply once ctx is done.
+func (m *Connect) StorageVolLookupByPathContext(ctx context.Context, path string) (storageVol dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Connect.StorageVolLookupByPath", path).Store(&storageVol)
 	return
 }
 
+// StorageVolLookupByPathObject is like StorageVolLookupByPath but returns *StorageVol instead of an object path.
+func (m *Connect) StorageVolLookupByPathObject(path string) (storageVol *StorageVol, err error) {
+	return m.StorageVolLookupByPathObjectContext(context.Background(), path)
+}
+
+// StorageVolLookupByPathObjectContext is like StorageVolLookupByPathObject but gives up waiting for the reply once ctx is done.
+func (m *Connect) StorageVolLookupByPathObjectContext(ctx context.Context, path string) (storageVol *StorageVol, err error) {
+	objPath, err := m.StorageVolLookupByPathContext(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	return NewStorageVol(m.conn, objPath), nil
+}
+
 // GetEncrypted See https://libvirt.org/html/libvirt-libvirt-host.html#virConnectIsEncrypted Note that monitoring of traffic on the D-Bus message bus is out of the scope of this property
 func (m *Connect) GetEncrypted() (v bool, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.Connect", "Encrypted").Store(&v)
+	return m.GetEncryptedContext(context.Background())
+}
+
+// GetEncryptedContext is like GetEncrypted but gives up waiting for the reply once ctx is done.
+func (m *Connect) GetEncryptedContext(ctx context.Context) (v bool, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.Connect", "Encrypted").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
 
 // GetHostname See https://libvirt.org/html/libvirt-libvirt-host.html#virConnectGetHostname
 func (m *Connect) GetHostname() (v string, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.Connect", "Hostname").Store(&v)
+	return m.GetHostnameContext(context.Background())
+}
+
+// GetHostnameContext is like GetHostname but gives up waiting for the reply once ctx is done.
+func (m *Connect) GetHostnameContext(ctx context.Context) (v string, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.Connect", "Hostname").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
 
 // GetLibVersion See https://libvirt.org/html/libvirt-libvirt-host.html#virConnectGetLibVersion
 func (m *Connect) GetLibVersion() (v uint64, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.Connect", "LibVersion").Store(&v)
+	return m.GetLibVersionContext(context.Background())
+}
+
+// GetLibVersionContext is like GetLibVersion but gives up waiting for the reply once ctx is done.
+func (m *Connect) GetLibVersionContext(ctx context.Context) (v uint64, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.Connect", "LibVersion").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
 
 // GetSecure See https://libvirt.org/html/libvirt-libvirt-host.html#virConnectIsSecure Note that monitoring of traffic on the D-Bus message bus is out of the scope of this property
 func (m *Connect) GetSecure() (v bool, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.Connect", "Secure").Store(&v)
+	return m.GetSecureContext(context.Background())
+}
+
+// GetSecureContext is like GetSecure but gives up waiting for the reply once ctx is done.
+func (m *Connect) GetSecureContext(ctx context.Context) (v bool, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.Connect", "Secure").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
 
 // GetVersion See https://libvirt.org/html/libvirt-libvirt-host.html#virConnectGetVersion
 func (m *Connect) GetVersion() (v uint64, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.Connect", "Version").Store(&v)
+	return m.GetVersionContext(context.Background())
+}
+
+// GetVersionContext is like GetVersion but gives up waiting for the reply once ctx is done.
+func (m *Connect) GetVersionContext(ctx context.Context) (v uint64, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.Connect", "Version").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
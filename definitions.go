@@ -0,0 +1,162 @@
+package libvirt
+
+import (
+	"context"
+
+	"sdstack.com/sdstack/go-libvirt/libvirtxml"
+)
+
+// document is implemented by the libvirtxml types.
+type document interface {
+	Unmarshal(doc string) error
+}
+
+// parseXML returns a function parsing the document returned by one of the
+// XML getters into v.
+func parseXML(v document) func(doc string, err error) error {
+	return func(doc string, err error) error {
+		if err != nil {
+			return err
+		}
+		return v.Unmarshal(doc)
+	}
+}
+
+// Definition returns the persistent definition of the domain parsed into a
+// libvirtxml.Domain. It includes secrets such as graphics passwords, so
+// it can be changed and passed back to DomainDefineXML, but needs a
+// connection that is not read-only. Use GetXMLDesc with flags for the live
+// or migratable XML.
+func (m *Domain) Definition() (*libvirtxml.Domain, error) {
+	return m.DefinitionContext(context.Background())
+}
+
+// DefinitionContext is like Definition but gives up waiting for the reply once ctx is done.
+func (m *Domain) DefinitionContext(ctx context.Context) (*libvirtxml.Domain, error) {
+	v := new(libvirtxml.Domain)
+	if err := parseXML(v)(m.GetXMLDescContext(ctx, DomainXMLInactive|DomainXMLSecure)); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Definition returns the persistent definition of the network parsed into
+// a libvirtxml.Network.
+func (m *Network) Definition() (*libvirtxml.Network, error) {
+	return m.DefinitionContext(context.Background())
+}
+
+// DefinitionContext is like Definition but gives up waiting for the reply once ctx is done.
+func (m *Network) DefinitionContext(ctx context.Context) (*libvirtxml.Network, error) {
+	v := new(libvirtxml.Network)
+	if err := parseXML(v)(m.GetXMLDescContext(ctx, NetworkXMLInactive)); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Definition returns the persistent definition of the storage pool parsed
+// into a libvirtxml.StoragePool.
+func (m *StoragePool) Definition() (*libvirtxml.StoragePool, error) {
+	return m.DefinitionContext(context.Background())
+}
+
+// DefinitionContext is like Definition but gives up waiting for the reply once ctx is done.
+func (m *StoragePool) DefinitionContext(ctx context.Context) (*libvirtxml.StoragePool, error) {
+	v := new(libvirtxml.StoragePool)
+	if err := parseXML(v)(m.GetXMLDescContext(ctx, StorageXMLInactive)); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Definition returns the XML of the storage volume parsed into a
+// libvirtxml.StorageVolume.
+func (m *StorageVol) Definition() (*libvirtxml.StorageVolume, error) {
+	return m.DefinitionContext(context.Background())
+}
+
+// DefinitionContext is like Definition but gives up waiting for the reply once ctx is done.
+func (m *StorageVol) DefinitionContext(ctx context.Context) (*libvirtxml.StorageVolume, error) {
+	v := new(libvirtxml.StorageVolume)
+	if err := parseXML(v)(m.GetXMLDescContext(ctx, 0)); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Definition returns the XML of the secret parsed into a
+// libvirtxml.Secret.
+func (m *Secret) Definition() (*libvirtxml.Secret, error) {
+	return m.DefinitionContext(context.Background())
+}
+
+// DefinitionContext is like Definition but gives up waiting for the reply once ctx is done.
+func (m *Secret) DefinitionContext(ctx context.Context) (*libvirtxml.Secret, error) {
+	v := new(libvirtxml.Secret)
+	if err := parseXML(v)(m.GetXMLDescContext(ctx, 0)); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Definition returns the XML of the network filter parsed into a
+// libvirtxml.NWFilter.
+func (m *NWFilter) Definition() (*libvirtxml.NWFilter, error) {
+	return m.DefinitionContext(context.Background())
+}
+
+// DefinitionContext is like Definition but gives up waiting for the reply once ctx is done.
+func (m *NWFilter) DefinitionContext(ctx context.Context) (*libvirtxml.NWFilter, error) {
+	v := new(libvirtxml.NWFilter)
+	if err := parseXML(v)(m.GetXMLDescContext(ctx, 0)); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Definition returns the XML of the node device parsed into a
+// libvirtxml.NodeDevice.
+func (m *NodeDevice) Definition() (*libvirtxml.NodeDevice, error) {
+	return m.DefinitionContext(context.Background())
+}
+
+// DefinitionContext is like Definition but gives up waiting for the reply once ctx is done.
+func (m *NodeDevice) DefinitionContext(ctx context.Context) (*libvirtxml.NodeDevice, error) {
+	v := new(libvirtxml.NodeDevice)
+	if err := parseXML(v)(m.GetXMLDescContext(ctx, 0)); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Capabilities returns the capabilities of the hypervisor and host parsed
+// into a libvirtxml.Caps.
+func (m *Connect) Capabilities() (*libvirtxml.Caps, error) {
+	return m.CapabilitiesContext(context.Background())
+}
+
+// CapabilitiesContext is like Capabilities but gives up waiting for the reply once ctx is done.
+func (m *Connect) CapabilitiesContext(ctx context.Context) (*libvirtxml.Caps, error) {
+	v := new(libvirtxml.Caps)
+	if err := parseXML(v)(m.GetCapabilitiesContext(ctx)); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// DomainCapabilities returns what the given emulator, architecture, machine
+// type and virtualization type offer to domains, parsed into a
+// libvirtxml.DomainCaps. Empty arguments select the hypervisor defaults.
+func (m *Connect) DomainCapabilities(emulatorbin, arch, machine, virttype string) (*libvirtxml.DomainCaps, error) {
+	return m.DomainCapabilitiesContext(context.Background(), emulatorbin, arch, machine, virttype)
+}
+
+// DomainCapabilitiesContext is like DomainCapabilities but gives up waiting for the reply once ctx is done.
+func (m *Connect) DomainCapabilitiesContext(ctx context.Context, emulatorbin, arch, machine, virttype string) (*libvirtxml.DomainCaps, error) {
+	v := new(libvirtxml.DomainCaps)
+	if err := parseXML(v)(m.GetDomainCapabilitiesContext(ctx, emulatorbin, arch, machine, virttype, 0)); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
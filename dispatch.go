@@ -0,0 +1,159 @@
+package libvirt
+
+import (
+	"errors"
+	"time"
+
+	"github.com/godbus/dbus"
+)
+
+const (
+	// signalBuffer is the depth of the single channel godbus delivers all
+	// signals of a Conn to.
+	signalBuffer = 1024
+
+	defaultQueueDepth = 64
+
+	// blockTimeout is how long OverflowBlock waits for room in a queue.
+	blockTimeout = time.Second
+)
+
+// ErrQueueFull is reported on Subscription.Errors when a signal is dropped
+// under OverflowReport, or under OverflowBlock once the wait times out.
+var ErrQueueFull = errors.New("libvirt: subscription queue full")
+
+// OverflowPolicy decides what the dispatcher does with a signal when the
+// queue of the subscription it belongs to is full.
+type OverflowPolicy uint8
+
+const (
+	// OverflowDropOldest discards the oldest queued signal to make room.
+	OverflowDropOldest OverflowPolicy = iota
+	// OverflowBlock waits up to a second for the callback to catch up,
+	// then discards the signal and reports ErrQueueFull on
+	// Subscription.Errors. The wait stalls the delivery of every other
+	// signal on the connection, and once the 1024 signals buffered for
+	// the connection are used up, godbus piles up a goroutine per further
+	// signal until it ends. A callback under OverflowBlock should
+	// therefore return quickly and must not wait for D-Bus calls.
+	OverflowBlock
+	// OverflowReport discards the new signal and reports ErrQueueFull on
+	// Subscription.Errors.
+	OverflowReport
+)
+
+type subscribeConfig struct {
+	depth   int
+	policy  OverflowPolicy
+	timeout time.Duration
+}
+
+// SubscribeOption configures a subscription.
+type SubscribeOption func(*subscribeConfig)
+
+// WithQueueDepth sets how many signals may wait for the callback before the
+// overflow policy applies. The default is 64.
+func WithQueueDepth(n int) SubscribeOption {
+	return func(cfg *subscribeConfig) {
+		if n > 0 {
+			cfg.depth = n
+		}
+	}
+}
+
+// WithOverflowPolicy sets what happens when the queue is full. The default
+// is OverflowDropOldest.
+func WithOverflowPolicy(p OverflowPolicy) SubscribeOption {
+	return func(cfg *subscribeConfig) {
+		cfg.policy = p
+	}
+}
+
+// signalKey is what the dispatcher demultiplexes signals on.
+type signalKey struct {
+	name string
+	path dbus.ObjectPath
+}
+
+// register adds s to the dispatcher. It reports false if the dispatcher
+// has stopped.
+func (c *Conn) register(s *Subscription) bool {
+	key := signalKey{s.spec.iface + "." + s.spec.member, s.spec.path}
+
+	c.submu.Lock()
+	defer c.submu.Unlock()
+
+	if c.dispatchDone {
+		return false
+	}
+	c.handlers[key] = append(c.handlers[key], s)
+	return true
+}
+
+// unregister removes s from the dispatcher.
+func (c *Conn) unregister(s *Subscription) {
+	key := signalKey{s.spec.iface + "." + s.spec.member, s.spec.path}
+
+	c.submu.Lock()
+	defer c.submu.Unlock()
+
+	subs := c.handlers[key]
+	for i, sub := range subs {
+		if sub == s {
+			subs = append(subs[:i:i], subs[i+1:]...)
+			break
+		}
+	}
+	if len(subs) == 0 {
+		delete(c.handlers, key)
+	} else {
+		c.handlers[key] = subs
+	}
+}
+
+// dispatch reads every signal of the connection and queues it on the
+// subscriptions registered for its name and path. godbus closes ch when
+// the connection is closed or lost; unless the Conn reconnects, all
+// remaining subscriptions are stopped then.
+func (c *Conn) dispatch(bus *dbus.Conn, ch <-chan *dbus.Signal) {
+	for {
+		select {
+		case v, ok := <-ch:
+			if !ok {
+				if bus, ch = c.lost(); bus == nil {
+					return
+				}
+				continue
+			}
+			if v.Name == ownerChangedSignal {
+				c.ownerChanged(v)
+				continue
+			}
+
+			c.submu.Lock()
+			subs := c.handlers[signalKey{v.Name, v.Path}]
+			c.submu.Unlock()
+
+			for _, s := range subs {
+				s.enqueue(v)
+			}
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// stopAll ends every subscription and makes later subscribe calls fail.
+func (c *Conn) stopAll() {
+	c.submu.Lock()
+	c.dispatchDone = true
+	handlers := c.handlers
+	c.handlers = make(map[signalKey][]*Subscription)
+	c.submu.Unlock()
+
+	for _, subs := range handlers {
+		for _, s := range subs {
+			s.stop()
+		}
+	}
+}
@@ -1,18 +1,14 @@
 package libvirt
 
 import (
-	"sync"
+	"context"
 
 	"github.com/godbus/dbus"
 )
 
 type Domain struct {
-	conn   *Conn
-	object dbus.BusObject
-	path   dbus.ObjectPath
-
-	sigs  map[<-chan *dbus.Signal]struct{}
-	sigmu sync.Mutex
+	conn *Conn
+	path dbus.ObjectPath
 
 	//Active bool
 	//Autostart bool
@@ -20,1248 +16,2398 @@ type Domain struct {
 	//Name string
 	//OSType string
 	//Persistent bool
-	//SchedulerType interface{}
+	//SchedulerType DomainSchedulerType
 	//Updated bool
 	//UUID string
 }
 
+// DomainBlockJobInfo holds the blockJobInfo returned by Domain.GetBlockJobInfo.
+type DomainBlockJobInfo struct {
+	Type      int32
+	Bandwidth uint64
+	Cur       uint64
+	End       uint64
+}
+
+// DomainControlInfo holds the controlInfo returned by Domain.GetControlInfo.
+type DomainControlInfo struct {
+	State     int32
+	Details   int32
+	StateTime uint64
+}
+
+// DomainDiskError is an element of the diskErrors returned by Domain.GetDiskErrors.
+type DomainDiskError struct {
+	Disk  string
+	Error int32
+}
+
+// DomainFSInfo is an element of the fsInfo returned by Domain.GetFSInfo.
+type DomainFSInfo struct {
+	MountPoint string
+	Name       string
+	FSType     string
+	DevAliases []string
+}
+
+// DomainIOThreadInfo is an element of the ioThreadInfo returned by Domain.GetIOThreadInfo.
+type DomainIOThreadInfo struct {
+	IOThreadID uint32
+	CPUMap     []bool
+}
+
+// DomainJobInfo holds the jobInfo returned by Domain.GetJobInfo.
+type DomainJobInfo struct {
+	Type          int32
+	TimeElapsed   uint64
+	TimeRemaining uint64
+	DataTotal     uint64
+	DataProcessed uint64
+	DataRemaining uint64
+	MemTotal      uint64
+	MemProcessed  uint64
+	MemRemaining  uint64
+	FileTotal     uint64
+	FileProcessed uint64
+	FileRemaining uint64
+}
+
+// DomainJobStats holds the stats returned by Domain.GetJobStats.
+type DomainJobStats struct {
+	Type  int32
+	Stats map[string]interface{}
+}
+
+// DomainSecurityLabel is an element of the securityLabels returned by Domain.GetSecurityLabelList.
+type DomainSecurityLabel struct {
+	Label     string
+	Enforcing bool
+}
+
+// DomainState holds the state returned by Domain.GetState.
+type DomainState struct {
+	State  int32
+	Reason int32
+}
+
+// DomainTime holds the time returned by Domain.GetTime.
+type DomainTime struct {
+	Seconds  int64
+	Nseconds uint32
+}
+
+// DomainIPAddress is an element of DomainInterface.Addrs.
+type DomainIPAddress struct {
+	Type   int32
+	Addr   string
+	Prefix uint32
+}
+
+// DomainInterface is an element of the ifaces returned by Domain.InterfaceAddresses.
+type DomainInterface struct {
+	Name   string
+	HwAddr string
+	Addrs  []DomainIPAddress
+}
+
+// DomainGraphicsAddress holds the local sent with Domain.Graphics.
+type DomainGraphicsAddress struct {
+	Family  int32
+	Node    string
+	Service string
+}
+
+// DomainGraphicsIdentity is an element of the identities sent with Domain.Graphics.
+type DomainGraphicsIdentity struct {
+	Type string
+	Name string
+}
+
+// DomainSchedulerType holds the SchedulerType of Domain.
+type DomainSchedulerType struct {
+	Type    string
+	NParams int32
+}
+
 // NewDomain() TODO
 func NewDomain(c *Conn, path dbus.ObjectPath) *Domain {
-	m := &Domain{conn: c}
-	if path != "" {
-		m.object = c.conn.Object("org.libvirt", path)
-	} else {
-		m.object = c.object
+	m := &Domain{conn: c, path: path}
+	if path == "" {
+		m.path = c.path
 	}
-	m.path = c.object.Path()
-
-	m.sigmu.Lock()
-	m.sigs = make(map[<-chan *dbus.Signal]struct{})
-	m.sigmu.Unlock()
 
 	return m
 }
 
 // SubscribeAgentEvent See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventAgentLifecycleCallback
-func (m *Domain) SubscribeAgentEvent(callback func(state int32, reason int32)) <-chan *dbus.Signal {
+//
+// The callback sees the signals one at a time but not necessarily in the
+// order they were sent; see Subscription and OverflowPolicy.
+func (m *Domain) SubscribeAgentEvent(callback func(state int32, reason int32), opts ...SubscribeOption) (*Subscription, error) {
 	if callback == nil {
-		return nil
+		return nil, errNilCallback
 	}
-	m.sigmu.Lock()
-	ch := make(chan *dbus.Signal)
-	m.sigs[ch] = struct{}{}
-	m.conn.conn.Signal(ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, "type='signal',interface='org.libvirt.Domain',member='AgentEvent'")
-	go func() {
-		for v := range ch {
-			if v.Path != m.path || v.Name != "org.libvirt.Domain.AgentEvent" || 2 != len(v.Body) {
-				continue
-			}
-			callback(v.Body[0].(int32), v.Body[1].(int32))
+	return m.conn.subscribe(signalSpec{"org.libvirt.Domain", "AgentEvent", "ii", m.path}, func(body []interface{}) error {
+		var (
+			state  int32
+			reason int32
+		)
+		if err := dbus.Store(body, &state, &reason); err != nil {
+			return err
 		}
-	}()
-	return ch
+		callback(state, reason)
+		return nil
+	}, opts)
 }
 
 // UnSubscribeAgentEvent See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventAgentLifecycleCallback
-func (m *Domain) UnSubscribeAgentEvent(ch <-chan *dbus.Signal) {
-	m.sigmu.Lock()
-	delete(m.sigs, ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, "type='signal',interface='org.libvirt.Domain',member='AgentEvent'")
+func (m *Domain) UnSubscribeAgentEvent(sub *Subscription) error {
+	return sub.Close()
+}
+
+// DomainAgentEvent carries the arguments of the AgentEvent signal.
+type DomainAgentEvent struct {
+	State  int32
+	Reason int32
+}
+
+// AgentEvents is like SubscribeAgentEvent but delivers the signals on a channel that is closed once ctx is done or the connection is lost.
+func (m *Domain) AgentEvents(ctx context.Context, opts ...SubscribeOption) (<-chan DomainAgentEvent, error) {
+	ch := make(chan DomainAgentEvent)
+	err := stream(ctx, func(quit <-chan struct{}) (*Subscription, error) {
+		return m.SubscribeAgentEvent(func(state int32, reason int32) {
+			select {
+			case ch <- DomainAgentEvent{state, reason}:
+			case <-quit:
+			}
+		}, opts...)
+	}, func() { close(ch) })
+	if err != nil {
+		return nil, err
+	}
+	return ch, nil
 }
 
 // SubscribeBalloonChange See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventBalloonChangeCallback
-func (m *Domain) SubscribeBalloonChange(callback func(actual uint64)) <-chan *dbus.Signal {
+//
+// The callback sees the signals one at a time but not necessarily in the
+// order they were sent; see Subscription and OverflowPolicy.
+func (m *Domain) SubscribeBalloonChange(callback func(actual uint64), opts ...SubscribeOption) (*Subscription, error) {
 	if callback == nil {
-		return nil
+		return nil, errNilCallback
 	}
-	m.sigmu.Lock()
-	ch := make(chan *dbus.Signal)
-	m.sigs[ch] = struct{}{}
-	m.conn.conn.Signal(ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, "type='signal',interface='org.libvirt.Domain',member='BalloonChange'")
-	go func() {
-		for v := range ch {
-			if v.Path != m.path || v.Name != "org.libvirt.Domain.BalloonChange" || 1 != len(v.Body) {
-				continue
-			}
-			callback(v.Body[0].(uint64))
+	return m.conn.subscribe(signalSpec{"org.libvirt.Domain", "BalloonChange", "t", m.path}, func(body []interface{}) error {
+		var (
+			actual uint64
+		)
+		if err := dbus.Store(body, &actual); err != nil {
+			return err
 		}
-	}()
-	return ch
+		callback(actual)
+		return nil
+	}, opts)
 }
 
 // UnSubscribeBalloonChange See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventBalloonChangeCallback
-func (m *Domain) UnSubscribeBalloonChange(ch <-chan *dbus.Signal) {
-	m.sigmu.Lock()
-	delete(m.sigs, ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, "type='signal',interface='org.libvirt.Domain',member='BalloonChange'")
+func (m *Domain) UnSubscribeBalloonChange(sub *Subscription) error {
+	return sub.Close()
+}
+
+// DomainBalloonChangeEvent carries the arguments of the BalloonChange signal.
+type DomainBalloonChangeEvent struct {
+	Actual uint64
+}
+
+// BalloonChangeEvents is like SubscribeBalloonChange but delivers the signals on a channel that is closed once ctx is done or the connection is lost.
+func (m *Domain) BalloonChangeEvents(ctx context.Context, opts ...SubscribeOption) (<-chan DomainBalloonChangeEvent, error) {
+	ch := make(chan DomainBalloonChangeEvent)
+	err := stream(ctx, func(quit <-chan struct{}) (*Subscription, error) {
+		return m.SubscribeBalloonChange(func(actual uint64) {
+			select {
+			case ch <- DomainBalloonChangeEvent{actual}:
+			case <-quit:
+			}
+		}, opts...)
+	}, func() { close(ch) })
+	if err != nil {
+		return nil, err
+	}
+	return ch, nil
 }
 
 // SubscribeBlockJob See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventBlockJobCallback Callback was registered using VIR_DOMAIN_EVENT_ID_BLOCK_JOB_2
-func (m *Domain) SubscribeBlockJob(callback func(disk string, otype int32, status int32)) <-chan *dbus.Signal {
+//
+// The callback sees the signals one at a time but not necessarily in the
+// order they were sent; see Subscription and OverflowPolicy.
+func (m *Domain) SubscribeBlockJob(callback func(disk string, otype int32, status int32), opts ...SubscribeOption) (*Subscription, error) {
 	if callback == nil {
-		return nil
+		return nil, errNilCallback
 	}
-	m.sigmu.Lock()
-	ch := make(chan *dbus.Signal)
-	m.sigs[ch] = struct{}{}
-	m.conn.conn.Signal(ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, "type='signal',interface='org.libvirt.Domain',member='BlockJob'")
-	go func() {
-		for v := range ch {
-			if v.Path != m.path || v.Name != "org.libvirt.Domain.BlockJob" || 3 != len(v.Body) {
-				continue
-			}
-			callback(v.Body[0].(string), v.Body[1].(int32), v.Body[2].(int32))
+	return m.conn.subscribe(signalSpec{"org.libvirt.Domain", "BlockJob", "sii", m.path}, func(body []interface{}) error {
+		var (
+			disk   string
+			otype  int32
+			status int32
+		)
+		if err := dbus.Store(body, &disk, &otype, &status); err != nil {
+			return err
 		}
-	}()
-	return ch
+		callback(disk, otype, status)
+		return nil
+	}, opts)
 }
 
 // UnSubscribeBlockJob See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventBlockJobCallback Callback was registered using VIR_DOMAIN_EVENT_ID_BLOCK_JOB_2
-func (m *Domain) UnSubscribeBlockJob(ch <-chan *dbus.Signal) {
-	m.sigmu.Lock()
-	delete(m.sigs, ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, "type='signal',interface='org.libvirt.Domain',member='BlockJob'")
+func (m *Domain) UnSubscribeBlockJob(sub *Subscription) error {
+	return sub.Close()
+}
+
+// DomainBlockJobEvent carries the arguments of the BlockJob signal.
+type DomainBlockJobEvent struct {
+	Disk   string
+	Type   int32
+	Status int32
+}
+
+// BlockJobEvents is like SubscribeBlockJob but delivers the signals on a channel that is closed once ctx is done or the connection is lost.
+func (m *Domain) BlockJobEvents(ctx context.Context, opts ...SubscribeOption) (<-chan DomainBlockJobEvent, error) {
+	ch := make(chan DomainBlockJobEvent)
+	err := stream(ctx, func(quit <-chan struct{}) (*Subscription, error) {
+		return m.SubscribeBlockJob(func(disk string, otype int32, status int32) {
+			select {
+			case ch <- DomainBlockJobEvent{disk, otype, status}:
+			case <-quit:
+			}
+		}, opts...)
+	}, func() { close(ch) })
+	if err != nil {
+		return nil, err
+	}
+	return ch, nil
 }
 
 // SubscribeControlError See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventGenericCallback
-func (m *Domain) SubscribeControlError(callback func()) <-chan *dbus.Signal {
+//
+// The callback sees the signals one at a time but not necessarily in the
+// order they were sent; see Subscription and OverflowPolicy.
+func (m *Domain) SubscribeControlError(callback func(), opts ...SubscribeOption) (*Subscription, error) {
 	if callback == nil {
-		return nil
+		return nil, errNilCallback
 	}
-	m.sigmu.Lock()
-	ch := make(chan *dbus.Signal)
-	m.sigs[ch] = struct{}{}
-	m.conn.conn.Signal(ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, "type='signal',interface='org.libvirt.Domain',member='ControlError'")
-	go func() {
-		for v := range ch {
-			if v.Path != m.path || v.Name != "org.libvirt.Domain.ControlError" || 0 != len(v.Body) {
-				continue
-			}
-			callback()
+	return m.conn.subscribe(signalSpec{"org.libvirt.Domain", "ControlError", "", m.path}, func(body []interface{}) error {
+		if err := dbus.Store(body); err != nil {
+			return err
 		}
-	}()
-	return ch
+		callback()
+		return nil
+	}, opts)
 }
 
 // UnSubscribeControlError See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventGenericCallback
-func (m *Domain) UnSubscribeControlError(ch <-chan *dbus.Signal) {
-	m.sigmu.Lock()
-	delete(m.sigs, ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, "type='signal',interface='org.libvirt.Domain',member='ControlError'")
+func (m *Domain) UnSubscribeControlError(sub *Subscription) error {
+	return sub.Close()
+}
+
+// DomainControlErrorEvent carries the arguments of the ControlError signal.
+type DomainControlErrorEvent struct {
+}
+
+// ControlErrorEvents is like SubscribeControlError but delivers the signals on a channel that is closed once ctx is done or the connection is lost.
+func (m *Domain) ControlErrorEvents(ctx context.Context, opts ...SubscribeOption) (<-chan DomainControlErrorEvent, error) {
+	ch := make(chan DomainControlErrorEvent)
+	err := stream(ctx, func(quit <-chan struct{}) (*Subscription, error) {
+		return m.SubscribeControlError(func() {
+			select {
+			case ch <- DomainControlErrorEvent{}:
+			case <-quit:
+			}
+		}, opts...)
+	}, func() { close(ch) })
+	if err != nil {
+		return nil, err
+	}
+	return ch, nil
 }
 
 // SubscribeDeviceAdded See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventDeviceAddedCallback
-func (m *Domain) SubscribeDeviceAdded(callback func(device string)) <-chan *dbus.Signal {
+//
+// The callback sees the signals one at a time but not necessarily in the
+// order they were sent; see Subscription and OverflowPolicy.
+func (m *Domain) SubscribeDeviceAdded(callback func(device string), opts ...SubscribeOption) (*Subscription, error) {
 	if callback == nil {
-		return nil
+		return nil, errNilCallback
 	}
-	m.sigmu.Lock()
-	ch := make(chan *dbus.Signal)
-	m.sigs[ch] = struct{}{}
-	m.conn.conn.Signal(ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, "type='signal',interface='org.libvirt.Domain',member='DeviceAdded'")
-	go func() {
-		for v := range ch {
-			if v.Path != m.path || v.Name != "org.libvirt.Domain.DeviceAdded" || 1 != len(v.Body) {
-				continue
-			}
-			callback(v.Body[0].(string))
+	return m.conn.subscribe(signalSpec{"org.libvirt.Domain", "DeviceAdded", "s", m.path}, func(body []interface{}) error {
+		var (
+			device string
+		)
+		if err := dbus.Store(body, &device); err != nil {
+			return err
 		}
-	}()
-	return ch
+		callback(device)
+		return nil
+	}, opts)
 }
 
 // UnSubscribeDeviceAdded See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventDeviceAddedCallback
-func (m *Domain) UnSubscribeDeviceAdded(ch <-chan *dbus.Signal) {
-	m.sigmu.Lock()
-	delete(m.sigs, ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, "type='signal',interface='org.libvirt.Domain',member='DeviceAdded'")
+func (m *Domain) UnSubscribeDeviceAdded(sub *Subscription) error {
+	return sub.Close()
+}
+
+// DomainDeviceAddedEvent carries the arguments of the DeviceAdded signal.
+type DomainDeviceAddedEvent struct {
+	Device string
+}
+
+// DeviceAddedEvents is like SubscribeDeviceAdded but delivers the signals on a channel that is closed once ctx is done or the connection is lost.
+func (m *Domain) DeviceAddedEvents(ctx context.Context, opts ...SubscribeOption) (<-chan DomainDeviceAddedEvent, error) {
+	ch := make(chan DomainDeviceAddedEvent)
+	err := stream(ctx, func(quit <-chan struct{}) (*Subscription, error) {
+		return m.SubscribeDeviceAdded(func(device string) {
+			select {
+			case ch <- DomainDeviceAddedEvent{device}:
+			case <-quit:
+			}
+		}, opts...)
+	}, func() { close(ch) })
+	if err != nil {
+		return nil, err
+	}
+	return ch, nil
 }
 
 // SubscribeDeviceRemovalFailed See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventDeviceRemovalFailedCallback
-func (m *Domain) SubscribeDeviceRemovalFailed(callback func(device string)) <-chan *dbus.Signal {
+//
+// The callback sees the signals one at a time but not necessarily in the
+// order they were sent; see Subscription and OverflowPolicy.
+func (m *Domain) SubscribeDeviceRemovalFailed(callback func(device string), opts ...SubscribeOption) (*Subscription, error) {
 	if callback == nil {
-		return nil
+		return nil, errNilCallback
 	}
-	m.sigmu.Lock()
-	ch := make(chan *dbus.Signal)
-	m.sigs[ch] = struct{}{}
-	m.conn.conn.Signal(ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, "type='signal',interface='org.libvirt.Domain',member='DeviceRemovalFailed'")
-	go func() {
-		for v := range ch {
-			if v.Path != m.path || v.Name != "org.libvirt.Domain.DeviceRemovalFailed" || 1 != len(v.Body) {
-				continue
-			}
-			callback(v.Body[0].(string))
+	return m.conn.subscribe(signalSpec{"org.libvirt.Domain", "DeviceRemovalFailed", "s", m.path}, func(body []interface{}) error {
+		var (
+			device string
+		)
+		if err := dbus.Store(body, &device); err != nil {
+			return err
 		}
-	}()
-	return ch
+		callback(device)
+		return nil
+	}, opts)
 }
 
 // UnSubscribeDeviceRemovalFailed See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventDeviceRemovalFailedCallback
-func (m *Domain) UnSubscribeDeviceRemovalFailed(ch <-chan *dbus.Signal) {
-	m.sigmu.Lock()
-	delete(m.sigs, ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, "type='signal',interface='org.libvirt.Domain',member='DeviceRemovalFailed'")
+func (m *Domain) UnSubscribeDeviceRemovalFailed(sub *Subscription) error {
+	return sub.Close()
+}
+
+// DomainDeviceRemovalFailedEvent carries the arguments of the DeviceRemovalFailed signal.
+type DomainDeviceRemovalFailedEvent struct {
+	Device string
+}
+
+// DeviceRemovalFailedEvents is like SubscribeDeviceRemovalFailed but delivers the signals on a channel that is closed once ctx is done or the connection is lost.
+func (m *Domain) DeviceRemovalFailedEvents(ctx context.Context, opts ...SubscribeOption) (<-chan DomainDeviceRemovalFailedEvent, error) {
+	ch := make(chan DomainDeviceRemovalFailedEvent)
+	err := stream(ctx, func(quit <-chan struct{}) (*Subscription, error) {
+		return m.SubscribeDeviceRemovalFailed(func(device string) {
+			select {
+			case ch <- DomainDeviceRemovalFailedEvent{device}:
+			case <-quit:
+			}
+		}, opts...)
+	}, func() { close(ch) })
+	if err != nil {
+		return nil, err
+	}
+	return ch, nil
 }
 
 // SubscribeDeviceRemoved See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventDeviceRemovedCallback
-func (m *Domain) SubscribeDeviceRemoved(callback func(device string)) <-chan *dbus.Signal {
+//
+// The callback sees the signals one at a time but not necessarily in the
+// order they were sent; see Subscription and OverflowPolicy.
+func (m *Domain) SubscribeDeviceRemoved(callback func(device string), opts ...SubscribeOption) (*Subscription, error) {
 	if callback == nil {
-		return nil
+		return nil, errNilCallback
 	}
-	m.sigmu.Lock()
-	ch := make(chan *dbus.Signal)
-	m.sigs[ch] = struct{}{}
-	m.conn.conn.Signal(ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, "type='signal',interface='org.libvirt.Domain',member='DeviceRemoved'")
-	go func() {
-		for v := range ch {
-			if v.Path != m.path || v.Name != "org.libvirt.Domain.DeviceRemoved" || 1 != len(v.Body) {
-				continue
-			}
-			callback(v.Body[0].(string))
+	return m.conn.subscribe(signalSpec{"org.libvirt.Domain", "DeviceRemoved", "s", m.path}, func(body []interface{}) error {
+		var (
+			device string
+		)
+		if err := dbus.Store(body, &device); err != nil {
+			return err
 		}
-	}()
-	return ch
+		callback(device)
+		return nil
+	}, opts)
 }
 
 // UnSubscribeDeviceRemoved See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventDeviceRemovedCallback
-func (m *Domain) UnSubscribeDeviceRemoved(ch <-chan *dbus.Signal) {
-	m.sigmu.Lock()
-	delete(m.sigs, ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, "type='signal',interface='org.libvirt.Domain',member='DeviceRemoved'")
+func (m *Domain) UnSubscribeDeviceRemoved(sub *Subscription) error {
+	return sub.Close()
+}
+
+// DomainDeviceRemovedEvent carries the arguments of the DeviceRemoved signal.
+type DomainDeviceRemovedEvent struct {
+	Device string
+}
+
+// DeviceRemovedEvents is like SubscribeDeviceRemoved but delivers the signals on a channel that is closed once ctx is done or the connection is lost.
+func (m *Domain) DeviceRemovedEvents(ctx context.Context, opts ...SubscribeOption) (<-chan DomainDeviceRemovedEvent, error) {
+	ch := make(chan DomainDeviceRemovedEvent)
+	err := stream(ctx, func(quit <-chan struct{}) (*Subscription, error) {
+		return m.SubscribeDeviceRemoved(func(device string) {
+			select {
+			case ch <- DomainDeviceRemovedEvent{device}:
+			case <-quit:
+			}
+		}, opts...)
+	}, func() { close(ch) })
+	if err != nil {
+		return nil, err
+	}
+	return ch, nil
 }
 
 // SubscribeDiskChange See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventDiskChangeCallback
-func (m *Domain) SubscribeDiskChange(callback func(oldSrcPath string, newSrcPath string, device string, reason int32)) <-chan *dbus.Signal {
+//
+// The callback sees the signals one at a time but not necessarily in the
+// order they were sent; see Subscription and OverflowPolicy.
+func (m *Domain) SubscribeDiskChange(callback func(oldSrcPath string, newSrcPath string, device string, reason int32), opts ...SubscribeOption) (*Subscription, error) {
 	if callback == nil {
-		return nil
+		return nil, errNilCallback
 	}
-	m.sigmu.Lock()
-	ch := make(chan *dbus.Signal)
-	m.sigs[ch] = struct{}{}
-	m.conn.conn.Signal(ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, "type='signal',interface='org.libvirt.Domain',member='DiskChange'")
-	go func() {
-		for v := range ch {
-			if v.Path != m.path || v.Name != "org.libvirt.Domain.DiskChange" || 4 != len(v.Body) {
-				continue
-			}
-			callback(v.Body[0].(string), v.Body[1].(string), v.Body[2].(string), v.Body[3].(int32))
+	return m.conn.subscribe(signalSpec{"org.libvirt.Domain", "DiskChange", "sssi", m.path}, func(body []interface{}) error {
+		var (
+			oldSrcPath string
+			newSrcPath string
+			device     string
+			reason     int32
+		)
+		if err := dbus.Store(body, &oldSrcPath, &newSrcPath, &device, &reason); err != nil {
+			return err
 		}
-	}()
-	return ch
+		callback(oldSrcPath, newSrcPath, device, reason)
+		return nil
+	}, opts)
 }
 
 // UnSubscribeDiskChange See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventDiskChangeCallback
-func (m *Domain) UnSubscribeDiskChange(ch <-chan *dbus.Signal) {
-	m.sigmu.Lock()
-	delete(m.sigs, ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, "type='signal',interface='org.libvirt.Domain',member='DiskChange'")
+func (m *Domain) UnSubscribeDiskChange(sub *Subscription) error {
+	return sub.Close()
+}
+
+// DomainDiskChangeEvent carries the arguments of the DiskChange signal.
+type DomainDiskChangeEvent struct {
+	OldSrcPath string
+	NewSrcPath string
+	Device     string
+	Reason     int32
+}
+
+// DiskChangeEvents is like SubscribeDiskChange but delivers the signals on a channel that is closed once ctx is done or the connection is lost.
+func (m *Domain) DiskChangeEvents(ctx context.Context, opts ...SubscribeOption) (<-chan DomainDiskChangeEvent, error) {
+	ch := make(chan DomainDiskChangeEvent)
+	err := stream(ctx, func(quit <-chan struct{}) (*Subscription, error) {
+		return m.SubscribeDiskChange(func(oldSrcPath string, newSrcPath string, device string, reason int32) {
+			select {
+			case ch <- DomainDiskChangeEvent{oldSrcPath, newSrcPath, device, reason}:
+			case <-quit:
+			}
+		}, opts...)
+	}, func() { close(ch) })
+	if err != nil {
+		return nil, err
+	}
+	return ch, nil
 }
 
 // SubscribeGraphics See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventGraphicsCallback
-func (m *Domain) SubscribeGraphics(callback func(phase int32, local interface{}, remote interface{}, authScheme string, identities []interface{})) <-chan *dbus.Signal {
+//
+// The callback sees the signals one at a time but not necessarily in the
+// order they were sent; see Subscription and OverflowPolicy.
+func (m *Domain) SubscribeGraphics(callback func(phase int32, local DomainGraphicsAddress, remote DomainGraphicsAddress, authScheme string, identities []DomainGraphicsIdentity), opts ...SubscribeOption) (*Subscription, error) {
 	if callback == nil {
-		return nil
+		return nil, errNilCallback
 	}
-	m.sigmu.Lock()
-	ch := make(chan *dbus.Signal)
-	m.sigs[ch] = struct{}{}
-	m.conn.conn.Signal(ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, "type='signal',interface='org.libvirt.Domain',member='Graphics'")
-	go func() {
-		for v := range ch {
-			if v.Path != m.path || v.Name != "org.libvirt.Domain.Graphics" || 5 != len(v.Body) {
-				continue
-			}
-			callback(v.Body[0].(int32), v.Body[1].(interface{}), v.Body[2].(interface{}), v.Body[3].(string), v.Body[4].([]interface{}))
+	return m.conn.subscribe(signalSpec{"org.libvirt.Domain", "Graphics", "i(iss)(iss)sa(ss)", m.path}, func(body []interface{}) error {
+		var (
+			phase      int32
+			local      DomainGraphicsAddress
+			remote     DomainGraphicsAddress
+			authScheme string
+			identities []DomainGraphicsIdentity
+		)
+		if err := dbus.Store(body, &phase, &local, &remote, &authScheme, &identities); err != nil {
+			return err
 		}
-	}()
-	return ch
+		callback(phase, local, remote, authScheme, identities)
+		return nil
+	}, opts)
 }
 
 // UnSubscribeGraphics See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventGraphicsCallback
-func (m *Domain) UnSubscribeGraphics(ch <-chan *dbus.Signal) {
-	m.sigmu.Lock()
-	delete(m.sigs, ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, "type='signal',interface='org.libvirt.Domain',member='Graphics'")
+func (m *Domain) UnSubscribeGraphics(sub *Subscription) error {
+	return sub.Close()
+}
+
+// DomainGraphicsEvent carries the arguments of the Graphics signal.
+type DomainGraphicsEvent struct {
+	Phase      int32
+	Local      DomainGraphicsAddress
+	Remote     DomainGraphicsAddress
+	AuthScheme string
+	Identities []DomainGraphicsIdentity
+}
+
+// GraphicsEvents is like SubscribeGraphics but delivers the signals on a channel that is closed once ctx is done or the connection is lost.
+func (m *Domain) GraphicsEvents(ctx context.Context, opts ...SubscribeOption) (<-chan DomainGraphicsEvent, error) {
+	ch := make(chan DomainGraphicsEvent)
+	err := stream(ctx, func(quit <-chan struct{}) (*Subscription, error) {
+		return m.SubscribeGraphics(func(phase int32, local DomainGraphicsAddress, remote DomainGraphicsAddress, authScheme string, identities []DomainGraphicsIdentity) {
+			select {
+			case ch <- DomainGraphicsEvent{phase, local, remote, authScheme, identities}:
+			case <-quit:
+			}
+		}, opts...)
+	}, func() { close(ch) })
+	if err != nil {
+		return nil, err
+	}
+	return ch, nil
 }
 
 // SubscribeIOError See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventIOErrorReasonCallback
-func (m *Domain) SubscribeIOError(callback func(srcPath string, device string, action int32, reason string)) <-chan *dbus.Signal {
+//
+// The callback sees the signals one at a time but not necessarily in the
+// order they were sent; see Subscription and OverflowPolicy.
+func (m *Domain) SubscribeIOError(callback func(srcPath string, device string, action int32, reason string), opts ...SubscribeOption) (*Subscription, error) {
 	if callback == nil {
-		return nil
+		return nil, errNilCallback
 	}
-	m.sigmu.Lock()
-	ch := make(chan *dbus.Signal)
-	m.sigs[ch] = struct{}{}
-	m.conn.conn.Signal(ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, "type='signal',interface='org.libvirt.Domain',member='IOError'")
-	go func() {
-		for v := range ch {
-			if v.Path != m.path || v.Name != "org.libvirt.Domain.IOError" || 4 != len(v.Body) {
-				continue
-			}
-			callback(v.Body[0].(string), v.Body[1].(string), v.Body[2].(int32), v.Body[3].(string))
+	return m.conn.subscribe(signalSpec{"org.libvirt.Domain", "IOError", "ssis", m.path}, func(body []interface{}) error {
+		var (
+			srcPath string
+			device  string
+			action  int32
+			reason  string
+		)
+		if err := dbus.Store(body, &srcPath, &device, &action, &reason); err != nil {
+			return err
 		}
-	}()
-	return ch
+		callback(srcPath, device, action, reason)
+		return nil
+	}, opts)
 }
 
 // UnSubscribeIOError See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventIOErrorReasonCallback
-func (m *Domain) UnSubscribeIOError(ch <-chan *dbus.Signal) {
-	m.sigmu.Lock()
-	delete(m.sigs, ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, "type='signal',interface='org.libvirt.Domain',member='IOError'")
+func (m *Domain) UnSubscribeIOError(sub *Subscription) error {
+	return sub.Close()
+}
+
+// DomainIOErrorEvent carries the arguments of the IOError signal.
+type DomainIOErrorEvent struct {
+	SrcPath string
+	Device  string
+	Action  int32
+	Reason  string
+}
+
+// IOErrorEvents is like SubscribeIOError but delivers the signals on a channel that is closed once ctx is done or the connection is lost.
+func (m *Domain) IOErrorEvents(ctx context.Context, opts ...SubscribeOption) (<-chan DomainIOErrorEvent, error) {
+	ch := make(chan DomainIOErrorEvent)
+	err := stream(ctx, func(quit <-chan struct{}) (*Subscription, error) {
+		return m.SubscribeIOError(func(srcPath string, device string, action int32, reason string) {
+			select {
+			case ch <- DomainIOErrorEvent{srcPath, device, action, reason}:
+			case <-quit:
+			}
+		}, opts...)
+	}, func() { close(ch) })
+	if err != nil {
+		return nil, err
+	}
+	return ch, nil
 }
 
 // SubscribeJobCompleted See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventJobCompletedCallback
-func (m *Domain) SubscribeJobCompleted(callback func(params map[string]interface{})) <-chan *dbus.Signal {
+//
+// The callback sees the signals one at a time but not necessarily in the
+// order they were sent; see Subscription and OverflowPolicy.
+func (m *Domain) SubscribeJobCompleted(callback func(params map[string]interface{}), opts ...SubscribeOption) (*Subscription, error) {
 	if callback == nil {
-		return nil
+		return nil, errNilCallback
 	}
-	m.sigmu.Lock()
-	ch := make(chan *dbus.Signal)
-	m.sigs[ch] = struct{}{}
-	m.conn.conn.Signal(ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, "type='signal',interface='org.libvirt.Domain',member='JobCompleted'")
-	go func() {
-		for v := range ch {
-			if v.Path != m.path || v.Name != "org.libvirt.Domain.JobCompleted" || 1 != len(v.Body) {
-				continue
-			}
-			callback(v.Body[0].(map[string]interface{}))
+	return m.conn.subscribe(signalSpec{"org.libvirt.Domain", "JobCompleted", "a{sv}", m.path}, func(body []interface{}) error {
+		var (
+			params map[string]interface{}
+		)
+		if err := dbus.Store(body, &params); err != nil {
+			return err
 		}
-	}()
-	return ch
+		callback(params)
+		return nil
+	}, opts)
 }
 
 // UnSubscribeJobCompleted See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventJobCompletedCallback
-func (m *Domain) UnSubscribeJobCompleted(ch <-chan *dbus.Signal) {
-	m.sigmu.Lock()
-	delete(m.sigs, ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, "type='signal',interface='org.libvirt.Domain',member='JobCompleted'")
+func (m *Domain) UnSubscribeJobCompleted(sub *Subscription) error {
+	return sub.Close()
+}
+
+// DomainJobCompletedEvent carries the arguments of the JobCompleted signal.
+type DomainJobCompletedEvent struct {
+	Params map[string]interface{}
+}
+
+// JobCompletedEvents is like SubscribeJobCompleted but delivers the signals on a channel that is closed once ctx is done or the connection is lost.
+func (m *Domain) JobCompletedEvents(ctx context.Context, opts ...SubscribeOption) (<-chan DomainJobCompletedEvent, error) {
+	ch := make(chan DomainJobCompletedEvent)
+	err := stream(ctx, func(quit <-chan struct{}) (*Subscription, error) {
+		return m.SubscribeJobCompleted(func(params map[string]interface{}) {
+			select {
+			case ch <- DomainJobCompletedEvent{params}:
+			case <-quit:
+			}
+		}, opts...)
+	}, func() { close(ch) })
+	if err != nil {
+		return nil, err
+	}
+	return ch, nil
 }
 
 // SubscribeMetadataChange See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventMetadataChangeCallback
-func (m *Domain) SubscribeMetadataChange(callback func(otype int32, nsuri string)) <-chan *dbus.Signal {
+//
+// The callback sees the signals one at a time but not necessarily in the
+// order they were sent; see Subscription and OverflowPolicy.
+func (m *Domain) SubscribeMetadataChange(callback func(otype int32, nsuri string), opts ...SubscribeOption) (*Subscription, error) {
 	if callback == nil {
-		return nil
+		return nil, errNilCallback
 	}
-	m.sigmu.Lock()
-	ch := make(chan *dbus.Signal)
-	m.sigs[ch] = struct{}{}
-	m.conn.conn.Signal(ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, "type='signal',interface='org.libvirt.Domain',member='MetadataChange'")
-	go func() {
-		for v := range ch {
-			if v.Path != m.path || v.Name != "org.libvirt.Domain.MetadataChange" || 2 != len(v.Body) {
-				continue
-			}
-			callback(v.Body[0].(int32), v.Body[1].(string))
+	return m.conn.subscribe(signalSpec{"org.libvirt.Domain", "MetadataChange", "is", m.path}, func(body []interface{}) error {
+		var (
+			otype int32
+			nsuri string
+		)
+		if err := dbus.Store(body, &otype, &nsuri); err != nil {
+			return err
 		}
-	}()
-	return ch
+		callback(otype, nsuri)
+		return nil
+	}, opts)
 }
 
 // UnSubscribeMetadataChange See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventMetadataChangeCallback
-func (m *Domain) UnSubscribeMetadataChange(ch <-chan *dbus.Signal) {
-	m.sigmu.Lock()
-	delete(m.sigs, ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, "type='signal',interface='org.libvirt.Domain',member='MetadataChange'")
+func (m *Domain) UnSubscribeMetadataChange(sub *Subscription) error {
+	return sub.Close()
+}
+
+// DomainMetadataChangeEvent carries the arguments of the MetadataChange signal.
+type DomainMetadataChangeEvent struct {
+	Type  int32
+	Nsuri string
+}
+
+// MetadataChangeEvents is like SubscribeMetadataChange but delivers the signals on a channel that is closed once ctx is done or the connection is lost.
+func (m *Domain) MetadataChangeEvents(ctx context.Context, opts ...SubscribeOption) (<-chan DomainMetadataChangeEvent, error) {
+	ch := make(chan DomainMetadataChangeEvent)
+	err := stream(ctx, func(quit <-chan struct{}) (*Subscription, error) {
+		return m.SubscribeMetadataChange(func(otype int32, nsuri string) {
+			select {
+			case ch <- DomainMetadataChangeEvent{otype, nsuri}:
+			case <-quit:
+			}
+		}, opts...)
+	}, func() { close(ch) })
+	if err != nil {
+		return nil, err
+	}
+	return ch, nil
 }
 
 // SubscribeMigrationIteration See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventMigrationIterationCallback
-func (m *Domain) SubscribeMigrationIteration(callback func(iteration int32)) <-chan *dbus.Signal {
+//
+// The callback sees the signals one at a time but not necessarily in the
+// order they were sent; see Subscription and OverflowPolicy.
+func (m *Domain) SubscribeMigrationIteration(callback func(iteration int32), opts ...SubscribeOption) (*Subscription, error) {
 	if callback == nil {
-		return nil
+		return nil, errNilCallback
 	}
-	m.sigmu.Lock()
-	ch := make(chan *dbus.Signal)
-	m.sigs[ch] = struct{}{}
-	m.conn.conn.Signal(ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, "type='signal',interface='org.libvirt.Domain',member='MigrationIteration'")
-	go func() {
-		for v := range ch {
-			if v.Path != m.path || v.Name != "org.libvirt.Domain.MigrationIteration" || 1 != len(v.Body) {
-				continue
-			}
-			callback(v.Body[0].(int32))
+	return m.conn.subscribe(signalSpec{"org.libvirt.Domain", "MigrationIteration", "i", m.path}, func(body []interface{}) error {
+		var (
+			iteration int32
+		)
+		if err := dbus.Store(body, &iteration); err != nil {
+			return err
 		}
-	}()
-	return ch
+		callback(iteration)
+		return nil
+	}, opts)
 }
 
 // UnSubscribeMigrationIteration See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventMigrationIterationCallback
-func (m *Domain) UnSubscribeMigrationIteration(ch <-chan *dbus.Signal) {
-	m.sigmu.Lock()
-	delete(m.sigs, ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, "type='signal',interface='org.libvirt.Domain',member='MigrationIteration'")
+func (m *Domain) UnSubscribeMigrationIteration(sub *Subscription) error {
+	return sub.Close()
+}
+
+// DomainMigrationIterationEvent carries the arguments of the MigrationIteration signal.
+type DomainMigrationIterationEvent struct {
+	Iteration int32
+}
+
+// MigrationIterationEvents is like SubscribeMigrationIteration but delivers the signals on a channel that is closed once ctx is done or the connection is lost.
+func (m *Domain) MigrationIterationEvents(ctx context.Context, opts ...SubscribeOption) (<-chan DomainMigrationIterationEvent, error) {
+	ch := make(chan DomainMigrationIterationEvent)
+	err := stream(ctx, func(quit <-chan struct{}) (*Subscription, error) {
+		return m.SubscribeMigrationIteration(func(iteration int32) {
+			select {
+			case ch <- DomainMigrationIterationEvent{iteration}:
+			case <-quit:
+			}
+		}, opts...)
+	}, func() { close(ch) })
+	if err != nil {
+		return nil, err
+	}
+	return ch, nil
 }
 
 // SubscribePMSuspend See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventPMSuspendCallback
-func (m *Domain) SubscribePMSuspend(callback func(reason int32)) <-chan *dbus.Signal {
+//
+// The callback sees the signals one at a time but not necessarily in the
+// order they were sent; see Subscription and OverflowPolicy.
+func (m *Domain) SubscribePMSuspend(callback func(reason int32), opts ...SubscribeOption) (*Subscription, error) {
 	if callback == nil {
-		return nil
+		return nil, errNilCallback
 	}
-	m.sigmu.Lock()
-	ch := make(chan *dbus.Signal)
-	m.sigs[ch] = struct{}{}
-	m.conn.conn.Signal(ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, "type='signal',interface='org.libvirt.Domain',member='PMSuspend'")
-	go func() {
-		for v := range ch {
-			if v.Path != m.path || v.Name != "org.libvirt.Domain.PMSuspend" || 1 != len(v.Body) {
-				continue
-			}
-			callback(v.Body[0].(int32))
+	return m.conn.subscribe(signalSpec{"org.libvirt.Domain", "PMSuspend", "i", m.path}, func(body []interface{}) error {
+		var (
+			reason int32
+		)
+		if err := dbus.Store(body, &reason); err != nil {
+			return err
 		}
-	}()
-	return ch
+		callback(reason)
+		return nil
+	}, opts)
 }
 
 // UnSubscribePMSuspend See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventPMSuspendCallback
-func (m *Domain) UnSubscribePMSuspend(ch <-chan *dbus.Signal) {
-	m.sigmu.Lock()
-	delete(m.sigs, ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, "type='signal',interface='org.libvirt.Domain',member='PMSuspend'")
+func (m *Domain) UnSubscribePMSuspend(sub *Subscription) error {
+	return sub.Close()
+}
+
+// DomainPMSuspendEvent carries the arguments of the PMSuspend signal.
+type DomainPMSuspendEvent struct {
+	Reason int32
+}
+
+// PMSuspendEvents is like SubscribePMSuspend but delivers the signals on a channel that is closed once ctx is done or the connection is lost.
+func (m *Domain) PMSuspendEvents(ctx context.Context, opts ...SubscribeOption) (<-chan DomainPMSuspendEvent, error) {
+	ch := make(chan DomainPMSuspendEvent)
+	err := stream(ctx, func(quit <-chan struct{}) (*Subscription, error) {
+		return m.SubscribePMSuspend(func(reason int32) {
+			select {
+			case ch <- DomainPMSuspendEvent{reason}:
+			case <-quit:
+			}
+		}, opts...)
+	}, func() { close(ch) })
+	if err != nil {
+		return nil, err
+	}
+	return ch, nil
 }
 
 // SubscribePMSuspendDisk See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventPMSuspendDiskCallback
-func (m *Domain) SubscribePMSuspendDisk(callback func(reason int32)) <-chan *dbus.Signal {
+//
+// The callback sees the signals one at a time but not necessarily in the
+// order they were sent; see Subscription and OverflowPolicy.
+func (m *Domain) SubscribePMSuspendDisk(callback func(reason int32), opts ...SubscribeOption) (*Subscription, error) {
 	if callback == nil {
-		return nil
+		return nil, errNilCallback
 	}
-	m.sigmu.Lock()
-	ch := make(chan *dbus.Signal)
-	m.sigs[ch] = struct{}{}
-	m.conn.conn.Signal(ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, "type='signal',interface='org.libvirt.Domain',member='PMSuspendDisk'")
-	go func() {
-		for v := range ch {
-			if v.Path != m.path || v.Name != "org.libvirt.Domain.PMSuspendDisk" || 1 != len(v.Body) {
-				continue
-			}
-			callback(v.Body[0].(int32))
+	return m.conn.subscribe(signalSpec{"org.libvirt.Domain", "PMSuspendDisk", "i", m.path}, func(body []interface{}) error {
+		var (
+			reason int32
+		)
+		if err := dbus.Store(body, &reason); err != nil {
+			return err
 		}
-	}()
-	return ch
+		callback(reason)
+		return nil
+	}, opts)
 }
 
 // UnSubscribePMSuspendDisk See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventPMSuspendDiskCallback
-func (m *Domain) UnSubscribePMSuspendDisk(ch <-chan *dbus.Signal) {
-	m.sigmu.Lock()
-	delete(m.sigs, ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, "type='signal',interface='org.libvirt.Domain',member='PMSuspendDisk'")
+func (m *Domain) UnSubscribePMSuspendDisk(sub *Subscription) error {
+	return sub.Close()
+}
+
+// DomainPMSuspendDiskEvent carries the arguments of the PMSuspendDisk signal.
+type DomainPMSuspendDiskEvent struct {
+	Reason int32
+}
+
+// PMSuspendDiskEvents is like SubscribePMSuspendDisk but delivers the signals on a channel that is closed once ctx is done or the connection is lost.
+func (m *Domain) PMSuspendDiskEvents(ctx context.Context, opts ...SubscribeOption) (<-chan DomainPMSuspendDiskEvent, error) {
+	ch := make(chan DomainPMSuspendDiskEvent)
+	err := stream(ctx, func(quit <-chan struct{}) (*Subscription, error) {
+		return m.SubscribePMSuspendDisk(func(reason int32) {
+			select {
+			case ch <- DomainPMSuspendDiskEvent{reason}:
+			case <-quit:
+			}
+		}, opts...)
+	}, func() { close(ch) })
+	if err != nil {
+		return nil, err
+	}
+	return ch, nil
 }
 
 // SubscribePMWakeup See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventPMWakeupCallback
-func (m *Domain) SubscribePMWakeup(callback func(reason int32)) <-chan *dbus.Signal {
+//
+// The callback sees the signals one at a time but not necessarily in the
+// order they were sent; see Subscription and OverflowPolicy.
+func (m *Domain) SubscribePMWakeup(callback func(reason int32), opts ...SubscribeOption) (*Subscription, error) {
 	if callback == nil {
-		return nil
+		return nil, errNilCallback
 	}
-	m.sigmu.Lock()
-	ch := make(chan *dbus.Signal)
-	m.sigs[ch] = struct{}{}
-	m.conn.conn.Signal(ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, "type='signal',interface='org.libvirt.Domain',member='PMWakeup'")
-	go func() {
-		for v := range ch {
-			if v.Path != m.path || v.Name != "org.libvirt.Domain.PMWakeup" || 1 != len(v.Body) {
-				continue
-			}
-			callback(v.Body[0].(int32))
+	return m.conn.subscribe(signalSpec{"org.libvirt.Domain", "PMWakeup", "i", m.path}, func(body []interface{}) error {
+		var (
+			reason int32
+		)
+		if err := dbus.Store(body, &reason); err != nil {
+			return err
 		}
-	}()
-	return ch
+		callback(reason)
+		return nil
+	}, opts)
 }
 
 // UnSubscribePMWakeup See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventPMWakeupCallback
-func (m *Domain) UnSubscribePMWakeup(ch <-chan *dbus.Signal) {
-	m.sigmu.Lock()
-	delete(m.sigs, ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, "type='signal',interface='org.libvirt.Domain',member='PMWakeup'")
+func (m *Domain) UnSubscribePMWakeup(sub *Subscription) error {
+	return sub.Close()
+}
+
+// DomainPMWakeupEvent carries the arguments of the PMWakeup signal.
+type DomainPMWakeupEvent struct {
+	Reason int32
+}
+
+// PMWakeupEvents is like SubscribePMWakeup but delivers the signals on a channel that is closed once ctx is done or the connection is lost.
+func (m *Domain) PMWakeupEvents(ctx context.Context, opts ...SubscribeOption) (<-chan DomainPMWakeupEvent, error) {
+	ch := make(chan DomainPMWakeupEvent)
+	err := stream(ctx, func(quit <-chan struct{}) (*Subscription, error) {
+		return m.SubscribePMWakeup(func(reason int32) {
+			select {
+			case ch <- DomainPMWakeupEvent{reason}:
+			case <-quit:
+			}
+		}, opts...)
+	}, func() { close(ch) })
+	if err != nil {
+		return nil, err
+	}
+	return ch, nil
 }
 
 // SubscribeReboot See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventGenericCallback
-func (m *Domain) SubscribeReboot(callback func()) <-chan *dbus.Signal {
+//
+// The callback sees the signals one at a time but not necessarily in the
+// order they were sent; see Subscription and OverflowPolicy.
+func (m *Domain) SubscribeReboot(callback func(), opts ...SubscribeOption) (*Subscription, error) {
 	if callback == nil {
-		return nil
+		return nil, errNilCallback
 	}
-	m.sigmu.Lock()
-	ch := make(chan *dbus.Signal)
-	m.sigs[ch] = struct{}{}
-	m.conn.conn.Signal(ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, "type='signal',interface='org.libvirt.Domain',member='Reboot'")
-	go func() {
-		for v := range ch {
-			if v.Path != m.path || v.Name != "org.libvirt.Domain.Reboot" || 0 != len(v.Body) {
-				continue
-			}
-			callback()
+	return m.conn.subscribe(signalSpec{"org.libvirt.Domain", "Reboot", "", m.path}, func(body []interface{}) error {
+		if err := dbus.Store(body); err != nil {
+			return err
 		}
-	}()
-	return ch
+		callback()
+		return nil
+	}, opts)
 }
 
 // UnSubscribeReboot See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventGenericCallback
-func (m *Domain) UnSubscribeReboot(ch <-chan *dbus.Signal) {
-	m.sigmu.Lock()
-	delete(m.sigs, ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, "type='signal',interface='org.libvirt.Domain',member='Reboot'")
+func (m *Domain) UnSubscribeReboot(sub *Subscription) error {
+	return sub.Close()
+}
+
+// DomainRebootEvent carries the arguments of the Reboot signal.
+type DomainRebootEvent struct {
+}
+
+// RebootEvents is like SubscribeReboot but delivers the signals on a channel that is closed once ctx is done or the connection is lost.
+func (m *Domain) RebootEvents(ctx context.Context, opts ...SubscribeOption) (<-chan DomainRebootEvent, error) {
+	ch := make(chan DomainRebootEvent)
+	err := stream(ctx, func(quit <-chan struct{}) (*Subscription, error) {
+		return m.SubscribeReboot(func() {
+			select {
+			case ch <- DomainRebootEvent{}:
+			case <-quit:
+			}
+		}, opts...)
+	}, func() { close(ch) })
+	if err != nil {
+		return nil, err
+	}
+	return ch, nil
 }
 
 // SubscribeRTCChange See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventRTCChangeCallback
-func (m *Domain) SubscribeRTCChange(callback func(utcoffset int64)) <-chan *dbus.Signal {
+//
+// The callback sees the signals one at a time but not necessarily in the
+// order they were sent; see Subscription and OverflowPolicy.
+func (m *Domain) SubscribeRTCChange(callback func(utcoffset int64), opts ...SubscribeOption) (*Subscription, error) {
 	if callback == nil {
-		return nil
+		return nil, errNilCallback
 	}
-	m.sigmu.Lock()
-	ch := make(chan *dbus.Signal)
-	m.sigs[ch] = struct{}{}
-	m.conn.conn.Signal(ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, "type='signal',interface='org.libvirt.Domain',member='RTCChange'")
-	go func() {
-		for v := range ch {
-			if v.Path != m.path || v.Name != "org.libvirt.Domain.RTCChange" || 1 != len(v.Body) {
-				continue
-			}
-			callback(v.Body[0].(int64))
+	return m.conn.subscribe(signalSpec{"org.libvirt.Domain", "RTCChange", "x", m.path}, func(body []interface{}) error {
+		var (
+			utcoffset int64
+		)
+		if err := dbus.Store(body, &utcoffset); err != nil {
+			return err
 		}
-	}()
-	return ch
+		callback(utcoffset)
+		return nil
+	}, opts)
 }
 
 // UnSubscribeRTCChange See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventRTCChangeCallback
-func (m *Domain) UnSubscribeRTCChange(ch <-chan *dbus.Signal) {
-	m.sigmu.Lock()
-	delete(m.sigs, ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, "type='signal',interface='org.libvirt.Domain',member='RTCChange'")
+func (m *Domain) UnSubscribeRTCChange(sub *Subscription) error {
+	return sub.Close()
+}
+
+// DomainRTCChangeEvent carries the arguments of the RTCChange signal.
+type DomainRTCChangeEvent struct {
+	Utcoffset int64
+}
+
+// RTCChangeEvents is like SubscribeRTCChange but delivers the signals on a channel that is closed once ctx is done or the connection is lost.
+func (m *Domain) RTCChangeEvents(ctx context.Context, opts ...SubscribeOption) (<-chan DomainRTCChangeEvent, error) {
+	ch := make(chan DomainRTCChangeEvent)
+	err := stream(ctx, func(quit <-chan struct{}) (*Subscription, error) {
+		return m.SubscribeRTCChange(func(utcoffset int64) {
+			select {
+			case ch <- DomainRTCChangeEvent{utcoffset}:
+			case <-quit:
+			}
+		}, opts...)
+	}, func() { close(ch) })
+	if err != nil {
+		return nil, err
+	}
+	return ch, nil
 }
 
 // SubscribeTrayChange See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventTrayChangeCallback
-func (m *Domain) SubscribeTrayChange(callback func(device string, reason int32)) <-chan *dbus.Signal {
+//
+// The callback sees the signals one at a time but not necessarily in the
+// order they were sent; see Subscription and OverflowPolicy.
+func (m *Domain) SubscribeTrayChange(callback func(device string, reason int32), opts ...SubscribeOption) (*Subscription, error) {
 	if callback == nil {
-		return nil
+		return nil, errNilCallback
 	}
-	m.sigmu.Lock()
-	ch := make(chan *dbus.Signal)
-	m.sigs[ch] = struct{}{}
-	m.conn.conn.Signal(ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, "type='signal',interface='org.libvirt.Domain',member='TrayChange'")
-	go func() {
-		for v := range ch {
-			if v.Path != m.path || v.Name != "org.libvirt.Domain.TrayChange" || 2 != len(v.Body) {
-				continue
-			}
-			callback(v.Body[0].(string), v.Body[1].(int32))
+	return m.conn.subscribe(signalSpec{"org.libvirt.Domain", "TrayChange", "si", m.path}, func(body []interface{}) error {
+		var (
+			device string
+			reason int32
+		)
+		if err := dbus.Store(body, &device, &reason); err != nil {
+			return err
 		}
-	}()
-	return ch
+		callback(device, reason)
+		return nil
+	}, opts)
 }
 
 // UnSubscribeTrayChange See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventTrayChangeCallback
-func (m *Domain) UnSubscribeTrayChange(ch <-chan *dbus.Signal) {
-	m.sigmu.Lock()
-	delete(m.sigs, ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, "type='signal',interface='org.libvirt.Domain',member='TrayChange'")
+func (m *Domain) UnSubscribeTrayChange(sub *Subscription) error {
+	return sub.Close()
+}
+
+// DomainTrayChangeEvent carries the arguments of the TrayChange signal.
+type DomainTrayChangeEvent struct {
+	Device string
+	Reason int32
+}
+
+// TrayChangeEvents is like SubscribeTrayChange but delivers the signals on a channel that is closed once ctx is done or the connection is lost.
+func (m *Domain) TrayChangeEvents(ctx context.Context, opts ...SubscribeOption) (<-chan DomainTrayChangeEvent, error) {
+	ch := make(chan DomainTrayChangeEvent)
+	err := stream(ctx, func(quit <-chan struct{}) (*Subscription, error) {
+		return m.SubscribeTrayChange(func(device string, reason int32) {
+			select {
+			case ch <- DomainTrayChangeEvent{device, reason}:
+			case <-quit:
+			}
+		}, opts...)
+	}, func() { close(ch) })
+	if err != nil {
+		return nil, err
+	}
+	return ch, nil
 }
 
 // SubscribeTunable See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventTunableCallback
-func (m *Domain) SubscribeTunable(callback func(params map[string]interface{})) <-chan *dbus.Signal {
+//
+// The callback sees the signals one at a time but not necessarily in the
+// order they were sent; see Subscription and OverflowPolicy.
+func (m *Domain) SubscribeTunable(callback func(params map[string]interface{}), opts ...SubscribeOption) (*Subscription, error) {
 	if callback == nil {
-		return nil
+		return nil, errNilCallback
 	}
-	m.sigmu.Lock()
-	ch := make(chan *dbus.Signal)
-	m.sigs[ch] = struct{}{}
-	m.conn.conn.Signal(ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, "type='signal',interface='org.libvirt.Domain',member='Tunable'")
-	go func() {
-		for v := range ch {
-			if v.Path != m.path || v.Name != "org.libvirt.Domain.Tunable" || 1 != len(v.Body) {
-				continue
-			}
-			callback(v.Body[0].(map[string]interface{}))
+	return m.conn.subscribe(signalSpec{"org.libvirt.Domain", "Tunable", "a{sv}", m.path}, func(body []interface{}) error {
+		var (
+			params map[string]interface{}
+		)
+		if err := dbus.Store(body, &params); err != nil {
+			return err
 		}
-	}()
-	return ch
+		callback(params)
+		return nil
+	}, opts)
 }
 
 // UnSubscribeTunable See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventTunableCallback
-func (m *Domain) UnSubscribeTunable(ch <-chan *dbus.Signal) {
-	m.sigmu.Lock()
-	delete(m.sigs, ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, "type='signal',interface='org.libvirt.Domain',member='Tunable'")
+func (m *Domain) UnSubscribeTunable(sub *Subscription) error {
+	return sub.Close()
+}
+
+// DomainTunableEvent carries the arguments of the Tunable signal.
+type DomainTunableEvent struct {
+	Params map[string]interface{}
+}
+
+// TunableEvents is like SubscribeTunable but delivers the signals on a channel that is closed once ctx is done or the connection is lost.
+func (m *Domain) TunableEvents(ctx context.Context, opts ...SubscribeOption) (<-chan DomainTunableEvent, error) {
+	ch := make(chan DomainTunableEvent)
+	err := stream(ctx, func(quit <-chan struct{}) (*Subscription, error) {
+		return m.SubscribeTunable(func(params map[string]interface{}) {
+			select {
+			case ch <- DomainTunableEvent{params}:
+			case <-quit:
+			}
+		}, opts...)
+	}, func() { close(ch) })
+	if err != nil {
+		return nil, err
+	}
+	return ch, nil
 }
 
 // SubscribeWatchdog See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventWatchdogCallback
-func (m *Domain) SubscribeWatchdog(callback func(action int32)) <-chan *dbus.Signal {
+//
+// The callback sees the signals one at a time but not necessarily in the
+// order they were sent; see Subscription and OverflowPolicy.
+func (m *Domain) SubscribeWatchdog(callback func(action int32), opts ...SubscribeOption) (*Subscription, error) {
 	if callback == nil {
-		return nil
+		return nil, errNilCallback
 	}
-	m.sigmu.Lock()
-	ch := make(chan *dbus.Signal)
-	m.sigs[ch] = struct{}{}
-	m.conn.conn.Signal(ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, "type='signal',interface='org.libvirt.Domain',member='Watchdog'")
-	go func() {
-		for v := range ch {
-			if v.Path != m.path || v.Name != "org.libvirt.Domain.Watchdog" || 1 != len(v.Body) {
-				continue
-			}
-			callback(v.Body[0].(int32))
+	return m.conn.subscribe(signalSpec{"org.libvirt.Domain", "Watchdog", "i", m.path}, func(body []interface{}) error {
+		var (
+			action int32
+		)
+		if err := dbus.Store(body, &action); err != nil {
+			return err
 		}
-	}()
-	return ch
+		callback(action)
+		return nil
+	}, opts)
 }
 
 // UnSubscribeWatchdog See https://libvirt.org/html/libvirt-libvirt-domain.html#virConnectDomainEventWatchdogCallback
-func (m *Domain) UnSubscribeWatchdog(ch <-chan *dbus.Signal) {
-	m.sigmu.Lock()
-	delete(m.sigs, ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, "type='signal',interface='org.libvirt.Domain',member='Watchdog'")
+func (m *Domain) UnSubscribeWatchdog(sub *Subscription) error {
+	return sub.Close()
+}
+
+// DomainWatchdogEvent carries the arguments of the Watchdog signal.
+type DomainWatchdogEvent struct {
+	Action int32
+}
+
+// WatchdogEvents is like SubscribeWatchdog but delivers the signals on a channel that is closed once ctx is done or the connection is lost.
+func (m *Domain) WatchdogEvents(ctx context.Context, opts ...SubscribeOption) (<-chan DomainWatchdogEvent, error) {
+	ch := make(chan DomainWatchdogEvent)
+	err := stream(ctx, func(quit <-chan struct{}) (*Subscription, error) {
+		return m.SubscribeWatchdog(func(action int32) {
+			select {
+			case ch <- DomainWatchdogEvent{action}:
+			case <-quit:
+			}
+		}, opts...)
+	}, func() { close(ch) })
+	if err != nil {
+		return nil, err
+	}
+	return ch, nil
 }
 
 // AbortJob See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainAbortJob
 func (m *Domain) AbortJob() (err error) {
-	err = m.object.Call("org.libvirt.Domain.AbortJob", 0).Store()
+	return m.AbortJobContext(context.Background())
+}
+
+// AbortJobContext is like AbortJob but gives up waiting for the reply once ctx is done.
+func (m *Domain) AbortJobContext(ctx context.Context) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.AbortJob").Store()
 	return
 }
 
 // AddIOThread See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainAddIOThread
-func (m *Domain) AddIOThread(iothreadId uint32, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.AddIOThread", 0, iothreadId, flags).Store()
+func (m *Domain) AddIOThread(iothreadId uint32, flags DomainModificationImpact) (err error) {
+	return m.AddIOThreadContext(context.Background(), iothreadId, flags)
+}
+
+// AddIOThreadContext is like AddIOThread but gives up waiting for the reply once ctx is done.
+func (m *Domain) AddIOThreadContext(ctx context.Context, iothreadId uint32, flags DomainModificationImpact) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.AddIOThread", iothreadId, flags).Store()
 	return
 }
 
 // AttachDevice See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainAttachDeviceFlags
-func (m *Domain) AttachDevice(xml string, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.AttachDevice", 0, xml, flags).Store()
+func (m *Domain) AttachDevice(xml string, flags DomainDeviceModifyFlags) (err error) {
+	return m.AttachDeviceContext(context.Background(), xml, flags)
+}
+
+// AttachDeviceContext is like AttachDevice but gives up waiting for the reply once ctx is done.
+func (m *Domain) AttachDeviceContext(ctx context.Context, xml string, flags DomainDeviceModifyFlags) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.AttachDevice", xml, flags).Store()
 	return
 }
 
 // BlockCommit See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainBlockCommit
-func (m *Domain) BlockCommit(disk string, base string, top string, bandwidth uint64, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.BlockCommit", 0, disk, base, top, bandwidth, flags).Store()
+func (m *Domain) BlockCommit(disk string, base string, top string, bandwidth uint64, flags DomainBlockCommitFlags) (err error) {
+	return m.BlockCommitContext(context.Background(), disk, base, top, bandwidth, flags)
+}
+
+// BlockCommitContext is like BlockCommit but gives up waiting for the reply once ctx is done.
+func (m *Domain) BlockCommitContext(ctx context.Context, disk string, base string, top string, bandwidth uint64, flags DomainBlockCommitFlags) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.BlockCommit", disk, base, top, bandwidth, flags).Store()
 	return
 }
 
 // BlockCopy See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainBlockCopy
-func (m *Domain) BlockCopy(disk string, destxml string, params map[string]interface{}, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.BlockCopy", 0, disk, destxml, params, flags).Store()
+func (m *Domain) BlockCopy(disk string, destxml string, params *DomainBlockCopyParameters, flags DomainBlockCopyFlags) (err error) {
+	return m.BlockCopyContext(context.Background(), disk, destxml, params, flags)
+}
+
+// BlockCopyContext is like BlockCopy but gives up waiting for the reply once ctx is done.
+func (m *Domain) BlockCopyContext(ctx context.Context, disk string, destxml string, params *DomainBlockCopyParameters, flags DomainBlockCopyFlags) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.BlockCopy", disk, destxml, encodeParams(params), flags).Store()
 	return
 }
 
 // BlockJobAbort See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainBlockJobAbort
-func (m *Domain) BlockJobAbort(disk string, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.BlockJobAbort", 0, disk, flags).Store()
+func (m *Domain) BlockJobAbort(disk string, flags DomainBlockJobAbortFlags) (err error) {
+	return m.BlockJobAbortContext(context.Background(), disk, flags)
+}
+
+// BlockJobAbortContext is like BlockJobAbort but gives up waiting for the reply once ctx is done.
+func (m *Domain) BlockJobAbortContext(ctx context.Context, disk string, flags DomainBlockJobAbortFlags) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.BlockJobAbort", disk, flags).Store()
 	return
 }
 
 // BlockPeek See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainBlockPeek
 func (m *Domain) BlockPeek(disk string, offset uint64, size uint64, flags uint32) (buffer []byte, err error) {
-	err = m.object.Call("org.libvirt.Domain.BlockPeek", 0, disk, offset, size, flags).Store(&buffer)
+	return m.BlockPeekContext(context.Background(), disk, offset, size, flags)
+}
+
+// BlockPeekContext is like BlockPeek but gives up waiting for the reply once ctx is done.
+func (m *Domain) BlockPeekContext(ctx context.Context, disk string, offset uint64, size uint64, flags uint32) (buffer []byte, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.BlockPeek", disk, offset, size, flags).Store(&buffer)
 	return
 }
 
 // BlockPull See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainBlockPull
-func (m *Domain) BlockPull(disk string, bandwidth uint64, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.BlockPull", 0, disk, bandwidth, flags).Store()
+func (m *Domain) BlockPull(disk string, bandwidth uint64, flags DomainBlockPullFlags) (err error) {
+	return m.BlockPullContext(context.Background(), disk, bandwidth, flags)
+}
+
+// BlockPullContext is like BlockPull but gives up waiting for the reply once ctx is done.
+func (m *Domain) BlockPullContext(ctx context.Context, disk string, bandwidth uint64, flags DomainBlockPullFlags) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.BlockPull", disk, bandwidth, flags).Store()
 	return
 }
 
 // BlockRebase See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainBlockRebase Empty string can be used to pass a NULL as @base argument.
-func (m *Domain) BlockRebase(disk string, base string, bandwidth uint64, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.BlockRebase", 0, disk, base, bandwidth, flags).Store()
+func (m *Domain) BlockRebase(disk string, base string, bandwidth uint64, flags DomainBlockRebaseFlags) (err error) {
+	return m.BlockRebaseContext(context.Background(), disk, base, bandwidth, flags)
+}
+
+// BlockRebaseContext is like BlockRebase but gives up waiting for the reply once ctx is done.
+func (m *Domain) BlockRebaseContext(ctx context.Context, disk string, base string, bandwidth uint64, flags DomainBlockRebaseFlags) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.BlockRebase", disk, base, bandwidth, flags).Store()
 	return
 }
 
 // BlockResize See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainBlockResize
-func (m *Domain) BlockResize(disk string, size uint64, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.BlockResize", 0, disk, size, flags).Store()
+func (m *Domain) BlockResize(disk string, size uint64, flags DomainBlockResizeFlags) (err error) {
+	return m.BlockResizeContext(context.Background(), disk, size, flags)
+}
+
+// BlockResizeContext is like BlockResize but gives up waiting for the reply once ctx is done.
+func (m *Domain) BlockResizeContext(ctx context.Context, disk string, size uint64, flags DomainBlockResizeFlags) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.BlockResize", disk, size, flags).Store()
 	return
 }
 
 // BlockJobSetSpeed See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainBlockJobSetSpeed
-func (m *Domain) BlockJobSetSpeed(disk string, bandwidth uint64, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.BlockJobSetSpeed", 0, disk, bandwidth, flags).Store()
+func (m *Domain) BlockJobSetSpeed(disk string, bandwidth uint64, flags DomainBlockJobSetSpeedFlags) (err error) {
+	return m.BlockJobSetSpeedContext(context.Background(), disk, bandwidth, flags)
+}
+
+// BlockJobSetSpeedContext is like BlockJobSetSpeed but gives up waiting for the reply once ctx is done.
+func (m *Domain) BlockJobSetSpeedContext(ctx context.Context, disk string, bandwidth uint64, flags DomainBlockJobSetSpeedFlags) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.BlockJobSetSpeed", disk, bandwidth, flags).Store()
 	return
 }
 
 // CoreDump See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainCoreDumpWithFormat
-func (m *Domain) CoreDump(to string, dumpformat uint32, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.CoreDump", 0, to, dumpformat, flags).Store()
+func (m *Domain) CoreDump(to string, dumpformat DomainCoreDumpFormat, flags DomainCoreDumpFlags) (err error) {
+	return m.CoreDumpContext(context.Background(), to, dumpformat, flags)
+}
+
+// CoreDumpContext is like CoreDump but gives up waiting for the reply once ctx is done.
+func (m *Domain) CoreDumpContext(ctx context.Context, to string, dumpformat DomainCoreDumpFormat, flags DomainCoreDumpFlags) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.CoreDump", to, dumpformat, flags).Store()
 	return
 }
 
 // Create See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainCreateWithFlags
-func (m *Domain) Create(flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.Create", 0, flags).Store()
+func (m *Domain) Create(flags DomainCreateFlags) (err error) {
+	return m.CreateContext(context.Background(), flags)
+}
+
+// CreateContext is like Create but gives up waiting for the reply once ctx is done.
+func (m *Domain) CreateContext(ctx context.Context, flags DomainCreateFlags) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.Create", flags).Store()
 	return
 }
 
 // CreateWithFiles See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainCreateWithFiles
-func (m *Domain) CreateWithFiles(files []uint32, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.CreateWithFiles", 0, files, flags).Store()
+func (m *Domain) CreateWithFiles(files []uint32, flags DomainCreateFlags) (err error) {
+	return m.CreateWithFilesContext(context.Background(), files, flags)
+}
+
+// CreateWithFilesContext is like CreateWithFiles but gives up waiting for the reply once ctx is done.
+func (m *Domain) CreateWithFilesContext(ctx context.Context, files []uint32, flags DomainCreateFlags) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.CreateWithFiles", files, flags).Store()
 	return
 }
 
 // DelIOThread See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainDelIOThread
-func (m *Domain) DelIOThread(iothreadId uint32, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.DelIOThread", 0, iothreadId, flags).Store()
+func (m *Domain) DelIOThread(iothreadId uint32, flags DomainModificationImpact) (err error) {
+	return m.DelIOThreadContext(context.Background(), iothreadId, flags)
+}
+
+// DelIOThreadContext is like DelIOThread but gives up waiting for the reply once ctx is done.
+func (m *Domain) DelIOThreadContext(ctx context.Context, iothreadId uint32, flags DomainModificationImpact) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.DelIOThread", iothreadId, flags).Store()
 	return
 }
 
 // Destroy See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainDestroyFlags
-func (m *Domain) Destroy(flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.Destroy", 0, flags).Store()
+func (m *Domain) Destroy(flags DomainDestroyFlags) (err error) {
+	return m.DestroyContext(context.Background(), flags)
+}
+
+// DestroyContext is like Destroy but gives up waiting for the reply once ctx is done.
+func (m *Domain) DestroyContext(ctx context.Context, flags DomainDestroyFlags) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.Destroy", flags).Store()
 	return
 }
 
 // DetachDevice See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainDetachDeviceFlags
-func (m *Domain) DetachDevice(xml string, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.DetachDevice", 0, xml, flags).Store()
+func (m *Domain) DetachDevice(xml string, flags DomainDeviceModifyFlags) (err error) {
+	return m.DetachDeviceContext(context.Background(), xml, flags)
+}
+
+// DetachDeviceContext is like DetachDevice but gives up waiting for the reply once ctx is done.
+func (m *Domain) DetachDeviceContext(ctx context.Context, xml string, flags DomainDeviceModifyFlags) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.DetachDevice", xml, flags).Store()
 	return
 }
 
 // FSFreeze See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainFSFreeze
 func (m *Domain) FSFreeze(mountpoints []string, flags uint32) (frozenFilesystems uint32, err error) {
-	err = m.object.Call("org.libvirt.Domain.FSFreeze", 0, mountpoints, flags).Store(&frozenFilesystems)
+	return m.FSFreezeContext(context.Background(), mountpoints, flags)
+}
+
+// FSFreezeContext is like FSFreeze but gives up waiting for the reply once ctx is done.
+func (m *Domain) FSFreezeContext(ctx context.Context, mountpoints []string, flags uint32) (frozenFilesystems uint32, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.FSFreeze", mountpoints, flags).Store(&frozenFilesystems)
 	return
 }
 
 // FSThaw See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainFSThaw
 func (m *Domain) FSThaw(mountpoints []string, flags uint32) (thawedFilesystems uint32, err error) {
-	err = m.object.Call("org.libvirt.Domain.FSThaw", 0, mountpoints, flags).Store(&thawedFilesystems)
+	return m.FSThawContext(context.Background(), mountpoints, flags)
+}
+
+// FSThawContext is like FSThaw but gives up waiting for the reply once ctx is done.
+func (m *Domain) FSThawContext(ctx context.Context, mountpoints []string, flags uint32) (thawedFilesystems uint32, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.FSThaw", mountpoints, flags).Store(&thawedFilesystems)
 	return
 }
 
 // FSTrim See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainFSTrim Empty string can be used to pass a NULL as @mountpoint argument.
 func (m *Domain) FSTrim(mountpoint string, minimum uint64, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.FSTrim", 0, mountpoint, minimum, flags).Store()
+	return m.FSTrimContext(context.Background(), mountpoint, minimum, flags)
+}
+
+// FSTrimContext is like FSTrim but gives up waiting for the reply once ctx is done.
+func (m *Domain) FSTrimContext(ctx context.Context, mountpoint string, minimum uint64, flags uint32) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.FSTrim", mountpoint, minimum, flags).Store()
 	return
 }
 
 // GetBlockIOParameters See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainGetBlkioParameters
-func (m *Domain) GetBlockIOParameters(flags uint32) (BlkioParameters map[string]interface{}, err error) {
-	err = m.object.Call("org.libvirt.Domain.GetBlockIOParameters", 0, flags).Store(&BlkioParameters)
+func (m *Domain) GetBlockIOParameters(flags DomainModificationImpact) (BlkioParameters *DomainBlockIOParameters, err error) {
+	return m.GetBlockIOParametersContext(context.Background(), flags)
+}
+
+// GetBlockIOParametersContext is like GetBlockIOParameters but gives up waiting for the reply once ctx is done.
+func (m *Domain) GetBlockIOParametersContext(ctx context.Context, flags DomainModificationImpact) (BlkioParameters *DomainBlockIOParameters, err error) {
+	var params map[string]dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.GetBlockIOParameters", flags).Store(&params); err != nil {
+		return
+	}
+	BlkioParameters = new(DomainBlockIOParameters)
+	err = decodeParams(params, BlkioParameters)
 	return
 }
 
 // GetBlockIOTune See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainGetBlockIoTune
-func (m *Domain) GetBlockIOTune(disk string, flags uint32) (blockIOTune map[string]interface{}, err error) {
-	err = m.object.Call("org.libvirt.Domain.GetBlockIOTune", 0, disk, flags).Store(&blockIOTune)
+func (m *Domain) GetBlockIOTune(disk string, flags DomainModificationImpact) (blockIOTune *DomainBlockIOTune, err error) {
+	return m.GetBlockIOTuneContext(context.Background(), disk, flags)
+}
+
+// GetBlockIOTuneContext is like GetBlockIOTune but gives up waiting for the reply once ctx is done.
+func (m *Domain) GetBlockIOTuneContext(ctx context.Context, disk string, flags DomainModificationImpact) (blockIOTune *DomainBlockIOTune, err error) {
+	var params map[string]dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.GetBlockIOTune", disk, flags).Store(&params); err != nil {
+		return
+	}
+	blockIOTune = new(DomainBlockIOTune)
+	err = decodeParams(params, blockIOTune)
 	return
 }
 
 // GetBlockJobInfo See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainGetBlockJobInfo
-func (m *Domain) GetBlockJobInfo(disk string, flags uint32) (blockJobInfo interface{}, err error) {
-	err = m.object.Call("org.libvirt.Domain.GetBlockJobInfo", 0, disk, flags).Store(&blockJobInfo)
+func (m *Domain) GetBlockJobInfo(disk string, flags DomainBlockJobInfoFlags) (blockJobInfo DomainBlockJobInfo, err error) {
+	return m.GetBlockJobInfoContext(context.Background(), disk, flags)
+}
+
+// GetBlockJobInfoContext is like GetBlockJobInfo but gives up waiting for the reply once ctx is done.
+func (m *Domain) GetBlockJobInfoContext(ctx context.Context, disk string, flags DomainBlockJobInfoFlags) (blockJobInfo DomainBlockJobInfo, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.GetBlockJobInfo", disk, flags).Store(&blockJobInfo)
 	return
 }
 
 // GetControlInfo See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainGetControlInfo
-func (m *Domain) GetControlInfo(flags uint32) (controlInfo interface{}, err error) {
-	err = m.object.Call("org.libvirt.Domain.GetControlInfo", 0, flags).Store(&controlInfo)
+func (m *Domain) GetControlInfo(flags uint32) (controlInfo DomainControlInfo, err error) {
+	return m.GetControlInfoContext(context.Background(), flags)
+}
+
+// GetControlInfoContext is like GetControlInfo but gives up waiting for the reply once ctx is done.
+func (m *Domain) GetControlInfoContext(ctx context.Context, flags uint32) (controlInfo DomainControlInfo, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.GetControlInfo", flags).Store(&controlInfo)
 	return
 }
 
 // GetDiskErrors See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainGetDiskErrors
-func (m *Domain) GetDiskErrors(flags uint32) (diskErrors []interface{}, err error) {
-	err = m.object.Call("org.libvirt.Domain.GetDiskErrors", 0, flags).Store(&diskErrors)
+func (m *Domain) GetDiskErrors(flags uint32) (diskErrors []DomainDiskError, err error) {
+	return m.GetDiskErrorsContext(context.Background(), flags)
+}
+
+// GetDiskErrorsContext is like GetDiskErrors but gives up waiting for the reply once ctx is done.
+func (m *Domain) GetDiskErrorsContext(ctx context.Context, flags uint32) (diskErrors []DomainDiskError, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.GetDiskErrors", flags).Store(&diskErrors)
 	return
 }
 
 // GetEmulatorPinInfo See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainGetEmulatorPinInfo
-func (m *Domain) GetEmulatorPinInfo(flags uint32) (cpumap []bool, err error) {
-	err = m.object.Call("org.libvirt.Domain.GetEmulatorPinInfo", 0, flags).Store(&cpumap)
+func (m *Domain) GetEmulatorPinInfo(flags DomainModificationImpact) (cpumap []bool, err error) {
+	return m.GetEmulatorPinInfoContext(context.Background(), flags)
+}
+
+// GetEmulatorPinInfoContext is like GetEmulatorPinInfo but gives up waiting for the reply once ctx is done.
+func (m *Domain) GetEmulatorPinInfoContext(ctx context.Context, flags DomainModificationImpact) (cpumap []bool, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.GetEmulatorPinInfo", flags).Store(&cpumap)
 	return
 }
 
 // GetFSInfo See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainGetFSInfo
-func (m *Domain) GetFSInfo(flags uint32) (fsInfo []interface{}, err error) {
-	err = m.object.Call("org.libvirt.Domain.GetFSInfo", 0, flags).Store(&fsInfo)
+func (m *Domain) GetFSInfo(flags uint32) (fsInfo []DomainFSInfo, err error) {
+	return m.GetFSInfoContext(context.Background(), flags)
+}
+
+// GetFSInfoContext is like GetFSInfo but gives up waiting for the reply once ctx is done.
+func (m *Domain) GetFSInfoContext(ctx context.Context, flags uint32) (fsInfo []DomainFSInfo, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.GetFSInfo", flags).Store(&fsInfo)
 	return
 }
 
 // GetGuestVcpus See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainGetGuestVcpus
 func (m *Domain) GetGuestVcpus(flags uint32) (vcpus map[string]interface{}, err error) {
-	err = m.object.Call("org.libvirt.Domain.GetGuestVcpus", 0, flags).Store(&vcpus)
+	return m.GetGuestVcpusContext(context.Background(), flags)
+}
+
+// GetGuestVcpusContext is like GetGuestVcpus but gives up waiting for the reply once ctx is done.
+func (m *Domain) GetGuestVcpusContext(ctx context.Context, flags uint32) (vcpus map[string]interface{}, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.GetGuestVcpus", flags).Store(&vcpus)
 	return
 }
 
 // GetHostname See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainGetHostname
-func (m *Domain) GetHostname(flags uint32) (hostname string, err error) {
-	err = m.object.Call("org.libvirt.Domain.GetHostname", 0, flags).Store(&hostname)
+func (m *Domain) GetHostname(flags DomainGetHostnameFlags) (hostname string, err error) {
+	return m.GetHostnameContext(context.Background(), flags)
+}
+
+// GetHostnameContext is like GetHostname but gives up waiting for the reply once ctx is done.
+func (m *Domain) GetHostnameContext(ctx context.Context, flags DomainGetHostnameFlags) (hostname string, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.GetHostname", flags).Store(&hostname)
 	return
 }
 
 // GetInterfaceParameters See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainGetInterfaceParameters
-func (m *Domain) GetInterfaceParameters(device string, flags uint32) (interfaceParameters map[string]interface{}, err error) {
-	err = m.object.Call("org.libvirt.Domain.GetInterfaceParameters", 0, device, flags).Store(&interfaceParameters)
+func (m *Domain) GetInterfaceParameters(device string, flags DomainModificationImpact) (interfaceParameters *DomainInterfaceParameters, err error) {
+	return m.GetInterfaceParametersContext(context.Background(), device, flags)
+}
+
+// GetInterfaceParametersContext is like GetInterfaceParameters but gives up waiting for the reply once ctx is done.
+func (m *Domain) GetInterfaceParametersContext(ctx context.Context, device string, flags DomainModificationImpact) (interfaceParameters *DomainInterfaceParameters, err error) {
+	var params map[string]dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.GetInterfaceParameters", device, flags).Store(&params); err != nil {
+		return
+	}
+	interfaceParameters = new(DomainInterfaceParameters)
+	err = decodeParams(params, interfaceParameters)
 	return
 }
 
 // GetIOThreadInfo See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainGetIOThreadInfo
-func (m *Domain) GetIOThreadInfo(flags uint32) (ioThreadInfo []interface{}, err error) {
-	err = m.object.Call("org.libvirt.Domain.GetIOThreadInfo", 0, flags).Store(&ioThreadInfo)
+func (m *Domain) GetIOThreadInfo(flags DomainModificationImpact) (ioThreadInfo []DomainIOThreadInfo, err error) {
+	return m.GetIOThreadInfoContext(context.Background(), flags)
+}
+
+// GetIOThreadInfoContext is like GetIOThreadInfo but gives up waiting for the reply once ctx is done.
+func (m *Domain) GetIOThreadInfoContext(ctx context.Context, flags DomainModificationImpact) (ioThreadInfo []DomainIOThreadInfo, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.GetIOThreadInfo", flags).Store(&ioThreadInfo)
 	return
 }
 
 // GetJobInfo See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainGetJobInfo
-func (m *Domain) GetJobInfo() (jobInfo interface{}, err error) {
-	err = m.object.Call("org.libvirt.Domain.GetJobInfo", 0).Store(&jobInfo)
+func (m *Domain) GetJobInfo() (jobInfo DomainJobInfo, err error) {
+	return m.GetJobInfoContext(context.Background())
+}
+
+// GetJobInfoContext is like GetJobInfo but gives up waiting for the reply once ctx is done.
+func (m *Domain) GetJobInfoContext(ctx context.Context) (jobInfo DomainJobInfo, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.GetJobInfo").Store(&jobInfo)
 	return
 }
 
 // GetJobStats See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainGetJobStats
-func (m *Domain) GetJobStats(flags uint32) (stats interface{}, err error) {
-	err = m.object.Call("org.libvirt.Domain.GetJobStats", 0, flags).Store(&stats)
+func (m *Domain) GetJobStats(flags DomainGetJobStatsFlags) (stats DomainJobStats, err error) {
+	return m.GetJobStatsContext(context.Background(), flags)
+}
+
+// GetJobStatsContext is like GetJobStats but gives up waiting for the reply once ctx is done.
+func (m *Domain) GetJobStatsContext(ctx context.Context, flags DomainGetJobStatsFlags) (stats DomainJobStats, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.GetJobStats", flags).Store(&stats)
 	return
 }
 
 // GetMemoryParameters See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainGetMemoryParameters
-func (m *Domain) GetMemoryParameters(flags uint32) (memoryParameters map[string]interface{}, err error) {
-	err = m.object.Call("org.libvirt.Domain.GetMemoryParameters", 0, flags).Store(&memoryParameters)
+func (m *Domain) GetMemoryParameters(flags DomainModificationImpact) (memoryParameters *DomainMemoryParameters, err error) {
+	return m.GetMemoryParametersContext(context.Background(), flags)
+}
+
+// GetMemoryParametersContext is like GetMemoryParameters but gives up waiting for the reply once ctx is done.
+func (m *Domain) GetMemoryParametersContext(ctx context.Context, flags DomainModificationImpact) (memoryParameters *DomainMemoryParameters, err error) {
+	var params map[string]dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.GetMemoryParameters", flags).Store(&params); err != nil {
+		return
+	}
+	memoryParameters = new(DomainMemoryParameters)
+	err = decodeParams(params, memoryParameters)
 	return
 }
 
 // GetMetadata See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainGetMetadata Empty string can be used to pass a NULL as @uri argument.
-func (m *Domain) GetMetadata(itype int32, uri string, flags uint32) (metadata string, err error) {
-	err = m.object.Call("org.libvirt.Domain.GetMetadata", 0, itype, uri, flags).Store(&metadata)
+func (m *Domain) GetMetadata(itype DomainMetadataType, uri string, flags DomainModificationImpact) (metadata string, err error) {
+	return m.GetMetadataContext(context.Background(), itype, uri, flags)
+}
+
+// GetMetadataContext is like GetMetadata but gives up waiting for the reply once ctx is done.
+func (m *Domain) GetMetadataContext(ctx context.Context, itype DomainMetadataType, uri string, flags DomainModificationImpact) (metadata string, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.GetMetadata", itype, uri, flags).Store(&metadata)
 	return
 }
 
 // GetNumaParameters See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainGetNumaParameters
-func (m *Domain) GetNumaParameters(flags uint32) (numaParameters map[string]interface{}, err error) {
-	err = m.object.Call("org.libvirt.Domain.GetNumaParameters", 0, flags).Store(&numaParameters)
+func (m *Domain) GetNumaParameters(flags DomainModificationImpact) (numaParameters *DomainNumaParameters, err error) {
+	return m.GetNumaParametersContext(context.Background(), flags)
+}
+
+// GetNumaParametersContext is like GetNumaParameters but gives up waiting for the reply once ctx is done.
+func (m *Domain) GetNumaParametersContext(ctx context.Context, flags DomainModificationImpact) (numaParameters *DomainNumaParameters, err error) {
+	var params map[string]dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.GetNumaParameters", flags).Store(&params); err != nil {
+		return
+	}
+	numaParameters = new(DomainNumaParameters)
+	err = decodeParams(params, numaParameters)
 	return
 }
 
 // GetPerfEvents See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainGetPerfEvents
-func (m *Domain) GetPerfEvents(flags uint32) (perfEvents map[string]interface{}, err error) {
-	err = m.object.Call("org.libvirt.Domain.GetPerfEvents", 0, flags).Store(&perfEvents)
+func (m *Domain) GetPerfEvents(flags DomainModificationImpact) (perfEvents *DomainPerfEvents, err error) {
+	return m.GetPerfEventsContext(context.Background(), flags)
+}
+
+// GetPerfEventsContext is like GetPerfEvents but gives up waiting for the reply once ctx is done.
+func (m *Domain) GetPerfEventsContext(ctx context.Context, flags DomainModificationImpact) (perfEvents *DomainPerfEvents, err error) {
+	var params map[string]dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.GetPerfEvents", flags).Store(&params); err != nil {
+		return
+	}
+	perfEvents = new(DomainPerfEvents)
+	err = decodeParams(params, perfEvents)
 	return
 }
 
 // GetSchedulerParameters See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainGetSchedulerParametersFlags
-func (m *Domain) GetSchedulerParameters(flags uint32) (SchedulerParameters map[string]interface{}, err error) {
-	err = m.object.Call("org.libvirt.Domain.GetSchedulerParameters", 0, flags).Store(&SchedulerParameters)
+func (m *Domain) GetSchedulerParameters(flags DomainModificationImpact) (SchedulerParameters *DomainSchedulerParameters, err error) {
+	return m.GetSchedulerParametersContext(context.Background(), flags)
+}
+
+// GetSchedulerParametersContext is like GetSchedulerParameters but gives up waiting for the reply once ctx is done.
+func (m *Domain) GetSchedulerParametersContext(ctx context.Context, flags DomainModificationImpact) (SchedulerParameters *DomainSchedulerParameters, err error) {
+	var params map[string]dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.GetSchedulerParameters", flags).Store(&params); err != nil {
+		return
+	}
+	SchedulerParameters = new(DomainSchedulerParameters)
+	err = decodeParams(params, SchedulerParameters)
 	return
 }
 
 // GetSecurityLabelList See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainGetSecurityLabelList
-func (m *Domain) GetSecurityLabelList() (securityLabels []interface{}, err error) {
-	err = m.object.Call("org.libvirt.Domain.GetSecurityLabelList", 0).Store(&securityLabels)
+func (m *Domain) GetSecurityLabelList() (securityLabels []DomainSecurityLabel, err error) {
+	return m.GetSecurityLabelListContext(context.Background())
+}
+
+// GetSecurityLabelListContext is like GetSecurityLabelList but gives up waiting for the reply once ctx is done.
+func (m *Domain) GetSecurityLabelListContext(ctx context.Context) (securityLabels []DomainSecurityLabel, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.GetSecurityLabelList").Store(&securityLabels)
 	return
 }
 
 // GetState See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainGetState
-func (m *Domain) GetState(flags uint32) (state interface{}, err error) {
-	err = m.object.Call("org.libvirt.Domain.GetState", 0, flags).Store(&state)
+func (m *Domain) GetState(flags uint32) (state DomainState, err error) {
+	return m.GetStateContext(context.Background(), flags)
+}
+
+// GetStateContext is like GetState but gives up waiting for the reply once ctx is done.
+func (m *Domain) GetStateContext(ctx context.Context, flags uint32) (state DomainState, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.GetState", flags).Store(&state)
 	return
 }
 
 // GetStats See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainListGetStats
-func (m *Domain) GetStats(stats uint32, flags uint32) (records map[string]interface{}, err error) {
-	err = m.object.Call("org.libvirt.Domain.GetStats", 0, stats, flags).Store(&records)
+func (m *Domain) GetStats(stats DomainStatsTypes, flags ConnectGetAllDomainStatsFlags) (records map[string]interface{}, err error) {
+	return m.GetStatsContext(context.Background(), stats, flags)
+}
+
+// GetStatsContext is like GetStats but gives up waiting for the reply once ctx is done.
+func (m *Domain) GetStatsContext(ctx context.Context, stats DomainStatsTypes, flags ConnectGetAllDomainStatsFlags) (records map[string]interface{}, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.GetStats", stats, flags).Store(&records)
 	return
 }
 
 // GetTime See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainGetTime
-func (m *Domain) GetTime(flags uint32) (time interface{}, err error) {
-	err = m.object.Call("org.libvirt.Domain.GetTime", 0, flags).Store(&time)
+func (m *Domain) GetTime(flags uint32) (time DomainTime, err error) {
+	return m.GetTimeContext(context.Background(), flags)
+}
+
+// GetTimeContext is like GetTime but gives up waiting for the reply once ctx is done.
+func (m *Domain) GetTimeContext(ctx context.Context, flags uint32) (time DomainTime, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.GetTime", flags).Store(&time)
 	return
 }
 
 // GetVcpuPinInfo See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainGetVcpuPinInfo
-func (m *Domain) GetVcpuPinInfo(flags uint32) (vcpuPinInfo [][]bool, err error) {
-	err = m.object.Call("org.libvirt.Domain.GetVcpuPinInfo", 0, flags).Store(&vcpuPinInfo)
+func (m *Domain) GetVcpuPinInfo(flags DomainModificationImpact) (vcpuPinInfo [][]bool, err error) {
+	return m.GetVcpuPinInfoContext(context.Background(), flags)
+}
+
+// GetVcpuPinInfoContext is like GetVcpuPinInfo but gives up waiting for the reply once ctx is done.
+func (m *Domain) GetVcpuPinInfoContext(ctx context.Context, flags DomainModificationImpact) (vcpuPinInfo [][]bool, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.GetVcpuPinInfo", flags).Store(&vcpuPinInfo)
 	return
 }
 
 // GetVcpus See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainGetVcpusFlags
-func (m *Domain) GetVcpus(flags uint32) (vcpus uint32, err error) {
-	err = m.object.Call("org.libvirt.Domain.GetVcpus", 0, flags).Store(&vcpus)
+func (m *Domain) GetVcpus(flags DomainVcpuFlags) (vcpus uint32, err error) {
+	return m.GetVcpusContext(context.Background(), flags)
+}
+
+// GetVcpusContext is like GetVcpus but gives up waiting for the reply once ctx is done.
+func (m *Domain) GetVcpusContext(ctx context.Context, flags DomainVcpuFlags) (vcpus uint32, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.GetVcpus", flags).Store(&vcpus)
 	return
 }
 
 // GetXMLDesc See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainGetXMLDesc
-func (m *Domain) GetXMLDesc(flags uint32) (xml string, err error) {
-	err = m.object.Call("org.libvirt.Domain.GetXMLDesc", 0, flags).Store(&xml)
+func (m *Domain) GetXMLDesc(flags DomainXMLFlags) (xml string, err error) {
+	return m.GetXMLDescContext(context.Background(), flags)
+}
+
+// GetXMLDescContext is like GetXMLDesc but gives up waiting for the reply once ctx is done.
+func (m *Domain) GetXMLDescContext(ctx context.Context, flags DomainXMLFlags) (xml string, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.GetXMLDesc", flags).Store(&xml)
 	return
 }
 
 // HasManagedSaveImage See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainHasManagedSaveImage
 func (m *Domain) HasManagedSaveImage(flags uint32) (managedSaveImage bool, err error) {
-	err = m.object.Call("org.libvirt.Domain.HasManagedSaveImage", 0, flags).Store(&managedSaveImage)
+	return m.HasManagedSaveImageContext(context.Background(), flags)
+}
+
+// HasManagedSaveImageContext is like HasManagedSaveImage but gives up waiting for the reply once ctx is done.
+func (m *Domain) HasManagedSaveImageContext(ctx context.Context, flags uint32) (managedSaveImage bool, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.HasManagedSaveImage", flags).Store(&managedSaveImage)
 	return
 }
 
 // InjectNMI See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainInjectNMI
 func (m *Domain) InjectNMI(flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.InjectNMI", 0, flags).Store()
+	return m.InjectNMIContext(context.Background(), flags)
+}
+
+// InjectNMIContext is like InjectNMI but gives up waiting for the reply once ctx is done.
+func (m *Domain) InjectNMIContext(ctx context.Context, flags uint32) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.InjectNMI", flags).Store()
 	return
 }
 
 // InterfaceAddresses See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainInterfaceAddresses
-func (m *Domain) InterfaceAddresses(source uint32, flags uint32) (ifaces []interface{}, err error) {
-	err = m.object.Call("org.libvirt.Domain.InterfaceAddresses", 0, source, flags).Store(&ifaces)
+func (m *Domain) InterfaceAddresses(source DomainInterfaceAddressesSource, flags uint32) (ifaces []DomainInterface, err error) {
+	return m.InterfaceAddressesContext(context.Background(), source, flags)
+}
+
+// InterfaceAddressesContext is like InterfaceAddresses but gives up waiting for the reply once ctx is done.
+func (m *Domain) InterfaceAddressesContext(ctx context.Context, source DomainInterfaceAddressesSource, flags uint32) (ifaces []DomainInterface, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.InterfaceAddresses", source, flags).Store(&ifaces)
 	return
 }
 
+// ListDomainSnapshots See https://libvirt.org/html/libvirt-libvirt-domain-snapshot.html#virDomainListAllSnapshots
+func (m *Domain) ListDomainSnapshots(flags DomainSnapshotListFlags) (snapshots []dbus.ObjectPath, err error) {
+	return m.ListDomainSnapshotsContext(context.Background(), flags)
+}
+
+// ListDomainSnapshotsContext is like ListDomainSnapshots but gives up waiting for the reply once ctx is done.
+func (m *Domain) ListDomainSnapshotsContext(ctx context.Context, flags DomainSnapshotListFlags) (snapshots []dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.ListDomainSnapshots", flags).Store(&snapshots)
+	return
+}
+
+// ListDomainSnapshotsObjects is like ListDomainSnapshots but returns []*DomainSnapshot instead of object paths.
+func (m *Domain) ListDomainSnapshotsObjects(flags DomainSnapshotListFlags) (snapshots []*DomainSnapshot, err error) {
+	return m.ListDomainSnapshotsObjectsContext(context.Background(), flags)
+}
+
+// ListDomainSnapshotsObjectsContext is like ListDomainSnapshotsObjects but gives up waiting for the reply once ctx is done.
+func (m *Domain) ListDomainSnapshotsObjectsContext(ctx context.Context, flags DomainSnapshotListFlags) (snapshots []*DomainSnapshot, err error) {
+	objPaths, err := m.ListDomainSnapshotsContext(ctx, flags)
+	if err != nil {
+		return nil, err
+	}
+	snapshots = make([]*DomainSnapshot, len(objPaths))
+	for i, objPath := range objPaths {
+		snapshots[i] = NewDomainSnapshot(m.conn, objPath)
+	}
+	return snapshots, nil
+}
+
 // ManagedSave See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainManagedSave
-func (m *Domain) ManagedSave(flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.ManagedSave", 0, flags).Store()
+func (m *Domain) ManagedSave(flags DomainSaveRestoreFlags) (err error) {
+	return m.ManagedSaveContext(context.Background(), flags)
+}
+
+// ManagedSaveContext is like ManagedSave but gives up waiting for the reply once ctx is done.
+func (m *Domain) ManagedSaveContext(ctx context.Context, flags DomainSaveRestoreFlags) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.ManagedSave", flags).Store()
 	return
 }
 
 // ManagedSaveRemove See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainManagedSaveRemove
 func (m *Domain) ManagedSaveRemove(flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.ManagedSaveRemove", 0, flags).Store()
+	return m.ManagedSaveRemoveContext(context.Background(), flags)
+}
+
+// ManagedSaveRemoveContext is like ManagedSaveRemove but gives up waiting for the reply once ctx is done.
+func (m *Domain) ManagedSaveRemoveContext(ctx context.Context, flags uint32) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.ManagedSaveRemove", flags).Store()
 	return
 }
 
 // MemoryPeek See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainMemoryPeek
-func (m *Domain) MemoryPeek(offset uint64, size uint64, flags uint32) (buffer []byte, err error) {
-	err = m.object.Call("org.libvirt.Domain.MemoryPeek", 0, offset, size, flags).Store(&buffer)
+func (m *Domain) MemoryPeek(offset uint64, size uint64, flags DomainMemoryFlags) (buffer []byte, err error) {
+	return m.MemoryPeekContext(context.Background(), offset, size, flags)
+}
+
+// MemoryPeekContext is like MemoryPeek but gives up waiting for the reply once ctx is done.
+func (m *Domain) MemoryPeekContext(ctx context.Context, offset uint64, size uint64, flags DomainMemoryFlags) (buffer []byte, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.MemoryPeek", offset, size, flags).Store(&buffer)
 	return
 }
 
 // MemoryStats See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainMemoryStats
 func (m *Domain) MemoryStats(flags uint32) (stats map[int32]uint64, err error) {
-	err = m.object.Call("org.libvirt.Domain.MemoryStats", 0, flags).Store(&stats)
+	return m.MemoryStatsContext(context.Background(), flags)
+}
+
+// MemoryStatsContext is like MemoryStats but gives up waiting for the reply once ctx is done.
+func (m *Domain) MemoryStatsContext(ctx context.Context, flags uint32) (stats map[int32]uint64, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.MemoryStats", flags).Store(&stats)
 	return
 }
 
 // MigrateGetCompressionCache See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainMigrateGetCompressionCache
 func (m *Domain) MigrateGetCompressionCache(flags uint32) (cacheSize uint64, err error) {
-	err = m.object.Call("org.libvirt.Domain.MigrateGetCompressionCache", 0, flags).Store(&cacheSize)
+	return m.MigrateGetCompressionCacheContext(context.Background(), flags)
+}
+
+// MigrateGetCompressionCacheContext is like MigrateGetCompressionCache but gives up waiting for the reply once ctx is done.
+func (m *Domain) MigrateGetCompressionCacheContext(ctx context.Context, flags uint32) (cacheSize uint64, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.MigrateGetCompressionCache", flags).Store(&cacheSize)
 	return
 }
 
 // MigrateGetMaxSpeed See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainMigrateGetMaxSpeed
-func (m *Domain) MigrateGetMaxSpeed(flags uint32) (bandwidth uint64, err error) {
-	err = m.object.Call("org.libvirt.Domain.MigrateGetMaxSpeed", 0, flags).Store(&bandwidth)
+func (m *Domain) MigrateGetMaxSpeed(flags DomainMigrateMaxSpeedFlags) (bandwidth uint64, err error) {
+	return m.MigrateGetMaxSpeedContext(context.Background(), flags)
+}
+
+// MigrateGetMaxSpeedContext is like MigrateGetMaxSpeed but gives up waiting for the reply once ctx is done.
+func (m *Domain) MigrateGetMaxSpeedContext(ctx context.Context, flags DomainMigrateMaxSpeedFlags) (bandwidth uint64, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.MigrateGetMaxSpeed", flags).Store(&bandwidth)
 	return
 }
 
 // MigrateSetCompressionCache See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainMigrateSetCompressionCache
 func (m *Domain) MigrateSetCompressionCache(cacheSize uint64, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.MigrateSetCompressionCache", 0, cacheSize, flags).Store()
+	return m.MigrateSetCompressionCacheContext(context.Background(), cacheSize, flags)
+}
+
+// MigrateSetCompressionCacheContext is like MigrateSetCompressionCache but gives up waiting for the reply once ctx is done.
+func (m *Domain) MigrateSetCompressionCacheContext(ctx context.Context, cacheSize uint64, flags uint32) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.MigrateSetCompressionCache", cacheSize, flags).Store()
 	return
 }
 
 // MigrateSetMaxDowntime See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainMigrateSetMaxDowntime
 func (m *Domain) MigrateSetMaxDowntime(downtime uint64, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.MigrateSetMaxDowntime", 0, downtime, flags).Store()
+	return m.MigrateSetMaxDowntimeContext(context.Background(), downtime, flags)
+}
+
+// MigrateSetMaxDowntimeContext is like MigrateSetMaxDowntime but gives up waiting for the reply once ctx is done.
+func (m *Domain) MigrateSetMaxDowntimeContext(ctx context.Context, downtime uint64, flags uint32) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.MigrateSetMaxDowntime", downtime, flags).Store()
 	return
 }
 
 // MigrateSetMaxSpeed See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainMigrateSetMaxSpeed
-func (m *Domain) MigrateSetMaxSpeed(bandwidth uint64, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.MigrateSetMaxSpeed", 0, bandwidth, flags).Store()
+func (m *Domain) MigrateSetMaxSpeed(bandwidth uint64, flags DomainMigrateMaxSpeedFlags) (err error) {
+	return m.MigrateSetMaxSpeedContext(context.Background(), bandwidth, flags)
+}
+
+// MigrateSetMaxSpeedContext is like MigrateSetMaxSpeed but gives up waiting for the reply once ctx is done.
+func (m *Domain) MigrateSetMaxSpeedContext(ctx context.Context, bandwidth uint64, flags DomainMigrateMaxSpeedFlags) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.MigrateSetMaxSpeed", bandwidth, flags).Store()
 	return
 }
 
 // MigrateStartPostCopy See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainMigrateStartPostCopy
 func (m *Domain) MigrateStartPostCopy(flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.MigrateStartPostCopy", 0, flags).Store()
+	return m.MigrateStartPostCopyContext(context.Background(), flags)
+}
+
+// MigrateStartPostCopyContext is like MigrateStartPostCopy but gives up waiting for the reply once ctx is done.
+func (m *Domain) MigrateStartPostCopyContext(ctx context.Context, flags uint32) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.MigrateStartPostCopy", flags).Store()
 	return
 }
 
 // MigrateToURI3 See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainMigrateToURI3
-func (m *Domain) MigrateToURI3(dconuri string, params map[string]interface{}, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.MigrateToURI3", 0, dconuri, params, flags).Store()
+func (m *Domain) MigrateToURI3(dconuri string, params *DomainMigrateParameters, flags DomainMigrateFlags) (err error) {
+	return m.MigrateToURI3Context(context.Background(), dconuri, params, flags)
+}
+
+// MigrateToURI3Context is like MigrateToURI3 but gives up waiting for the reply once ctx is done.
+func (m *Domain) MigrateToURI3Context(ctx context.Context, dconuri string, params *DomainMigrateParameters, flags DomainMigrateFlags) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.MigrateToURI3", dconuri, encodeParams(params), flags).Store()
 	return
 }
 
 // OpenGraphicsFD See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainOpenGraphicsFD
-func (m *Domain) OpenGraphicsFD(idx uint32, flags uint32) (fd uint32, err error) {
-	err = m.object.Call("org.libvirt.Domain.OpenGraphicsFD", 0, idx, flags).Store(&fd)
+func (m *Domain) OpenGraphicsFD(idx uint32, flags DomainOpenGraphicsFlags) (fd uint32, err error) {
+	return m.OpenGraphicsFDContext(context.Background(), idx, flags)
+}
+
+// OpenGraphicsFDContext is like OpenGraphicsFD but gives up waiting for the reply once ctx is done.
+func (m *Domain) OpenGraphicsFDContext(ctx context.Context, idx uint32, flags DomainOpenGraphicsFlags) (fd uint32, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.OpenGraphicsFD", idx, flags).Store(&fd)
 	return
 }
 
 // PinEmulator See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainPinEmulator
-func (m *Domain) PinEmulator(cpumap []bool, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.PinEmulator", 0, cpumap, flags).Store()
+func (m *Domain) PinEmulator(cpumap []bool, flags DomainModificationImpact) (err error) {
+	return m.PinEmulatorContext(context.Background(), cpumap, flags)
+}
+
+// PinEmulatorContext is like PinEmulator but gives up waiting for the reply once ctx is done.
+func (m *Domain) PinEmulatorContext(ctx context.Context, cpumap []bool, flags DomainModificationImpact) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.PinEmulator", cpumap, flags).Store()
 	return
 }
 
 // PinIOThread See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainPinIOThread
-func (m *Domain) PinIOThread(iothreadId uint32, cpumap []bool, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.PinIOThread", 0, iothreadId, cpumap, flags).Store()
+func (m *Domain) PinIOThread(iothreadId uint32, cpumap []bool, flags DomainModificationImpact) (err error) {
+	return m.PinIOThreadContext(context.Background(), iothreadId, cpumap, flags)
+}
+
+// PinIOThreadContext is like PinIOThread but gives up waiting for the reply once ctx is done.
+func (m *Domain) PinIOThreadContext(ctx context.Context, iothreadId uint32, cpumap []bool, flags DomainModificationImpact) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.PinIOThread", iothreadId, cpumap, flags).Store()
 	return
 }
 
 // PinVcpu See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainPinVcpuFlags
-func (m *Domain) PinVcpu(vcpu uint32, cpumap []bool, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.PinVcpu", 0, vcpu, cpumap, flags).Store()
+func (m *Domain) PinVcpu(vcpu uint32, cpumap []bool, flags DomainModificationImpact) (err error) {
+	return m.PinVcpuContext(context.Background(), vcpu, cpumap, flags)
+}
+
+// PinVcpuContext is like PinVcpu but gives up waiting for the reply once ctx is done.
+func (m *Domain) PinVcpuContext(ctx context.Context, vcpu uint32, cpumap []bool, flags DomainModificationImpact) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.PinVcpu", vcpu, cpumap, flags).Store()
 	return
 }
 
 // PMWakeup See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainPMWakeup
 func (m *Domain) PMWakeup(flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.PMWakeup", 0, flags).Store()
+	return m.PMWakeupContext(context.Background(), flags)
+}
+
+// PMWakeupContext is like PMWakeup but gives up waiting for the reply once ctx is done.
+func (m *Domain) PMWakeupContext(ctx context.Context, flags uint32) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.PMWakeup", flags).Store()
 	return
 }
 
 // Reboot See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainReboot
-func (m *Domain) Reboot(flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.Reboot", 0, flags).Store()
+func (m *Domain) Reboot(flags DomainRebootFlags) (err error) {
+	return m.RebootContext(context.Background(), flags)
+}
+
+// RebootContext is like Reboot but gives up waiting for the reply once ctx is done.
+func (m *Domain) RebootContext(ctx context.Context, flags DomainRebootFlags) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.Reboot", flags).Store()
 	return
 }
 
 // Rename See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainRename
 func (m *Domain) Rename(name string, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.Rename", 0, name, flags).Store()
+	return m.RenameContext(context.Background(), name, flags)
+}
+
+// RenameContext is like Rename but gives up waiting for the reply once ctx is done.
+func (m *Domain) RenameContext(ctx context.Context, name string, flags uint32) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.Rename", name, flags).Store()
 	return
 }
 
 // Reset See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainReset
 func (m *Domain) Reset(flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.Reset", 0, flags).Store()
+	return m.ResetContext(context.Background(), flags)
+}
+
+// ResetContext is like Reset but gives up waiting for the reply once ctx is done.
+func (m *Domain) ResetContext(ctx context.Context, flags uint32) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.Reset", flags).Store()
 	return
 }
 
 // Resume See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainResume
 func (m *Domain) Resume() (err error) {
-	err = m.object.Call("org.libvirt.Domain.Resume", 0).Store()
+	return m.ResumeContext(context.Background())
+}
+
+// ResumeContext is like Resume but gives up waiting for the reply once ctx is done.
+func (m *Domain) ResumeContext(ctx context.Context) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.Resume").Store()
 	return
 }
 
 // Save See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainSaveFlags Empty string can be used to pass a NULL as @xml argument.
-func (m *Domain) Save(to string, xml string, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.Save", 0, to, xml, flags).Store()
+func (m *Domain) Save(to string, xml string, flags DomainSaveRestoreFlags) (err error) {
+	return m.SaveContext(context.Background(), to, xml, flags)
+}
+
+// SaveContext is like Save but gives up waiting for the reply once ctx is done.
+func (m *Domain) SaveContext(ctx context.Context, to string, xml string, flags DomainSaveRestoreFlags) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.Save", to, xml, flags).Store()
 	return
 }
 
 // SendKey See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainSendKey
-func (m *Domain) SendKey(codeset uint32, holdtime uint32, keycodes []uint32, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.SendKey", 0, codeset, holdtime, keycodes, flags).Store()
+func (m *Domain) SendKey(codeset KeycodeSet, holdtime uint32, keycodes []uint32, flags uint32) (err error) {
+	return m.SendKeyContext(context.Background(), codeset, holdtime, keycodes, flags)
+}
+
+// SendKeyContext is like SendKey but gives up waiting for the reply once ctx is done.
+func (m *Domain) SendKeyContext(ctx context.Context, codeset KeycodeSet, holdtime uint32, keycodes []uint32, flags uint32) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.SendKey", codeset, holdtime, keycodes, flags).Store()
 	return
 }
 
 // SendProcessSignal See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainSendProcessSignal
-func (m *Domain) SendProcessSignal(pidValue int64, sigNum uint32, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.SendProcessSignal", 0, pidValue, sigNum, flags).Store()
+func (m *Domain) SendProcessSignal(pidValue int64, sigNum DomainProcessSignal, flags uint32) (err error) {
+	return m.SendProcessSignalContext(context.Background(), pidValue, sigNum, flags)
+}
+
+// SendProcessSignalContext is like SendProcessSignal but gives up waiting for the reply once ctx is done.
+func (m *Domain) SendProcessSignalContext(ctx context.Context, pidValue int64, sigNum DomainProcessSignal, flags uint32) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.SendProcessSignal", pidValue, sigNum, flags).Store()
 	return
 }
 
 // SetBlockIOParameters See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainSetBlkioParameters
-func (m *Domain) SetBlockIOParameters(params map[string]interface{}, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.SetBlockIOParameters", 0, params, flags).Store()
+func (m *Domain) SetBlockIOParameters(params *DomainBlockIOParameters, flags DomainModificationImpact) (err error) {
+	return m.SetBlockIOParametersContext(context.Background(), params, flags)
+}
+
+// SetBlockIOParametersContext is like SetBlockIOParameters but gives up waiting for the reply once ctx is done.
+func (m *Domain) SetBlockIOParametersContext(ctx context.Context, params *DomainBlockIOParameters, flags DomainModificationImpact) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.SetBlockIOParameters", encodeParams(params), flags).Store()
 	return
 }
 
 // SetBlockIOTune See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainSetBlockIoTune
-func (m *Domain) SetBlockIOTune(disk string, params map[string]interface{}, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.SetBlockIOTune", 0, disk, params, flags).Store()
+func (m *Domain) SetBlockIOTune(disk string, params *DomainBlockIOTune, flags DomainModificationImpact) (err error) {
+	return m.SetBlockIOTuneContext(context.Background(), disk, params, flags)
+}
+
+// SetBlockIOTuneContext is like SetBlockIOTune but gives up waiting for the reply once ctx is done.
+func (m *Domain) SetBlockIOTuneContext(ctx context.Context, disk string, params *DomainBlockIOTune, flags DomainModificationImpact) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.SetBlockIOTune", disk, encodeParams(params), flags).Store()
 	return
 }
 
 // SetGuestVcpus See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainSetGuestVcpus
 func (m *Domain) SetGuestVcpus(vcpumap []bool, state int32, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.SetGuestVcpus", 0, vcpumap, state, flags).Store()
+	return m.SetGuestVcpusContext(context.Background(), vcpumap, state, flags)
+}
+
+// SetGuestVcpusContext is like SetGuestVcpus but gives up waiting for the reply once ctx is done.
+func (m *Domain) SetGuestVcpusContext(ctx context.Context, vcpumap []bool, state int32, flags uint32) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.SetGuestVcpus", vcpumap, state, flags).Store()
 	return
 }
 
 // SetInterfaceParameters See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainSetInterfaceParameters
-func (m *Domain) SetInterfaceParameters(device string, params map[string]interface{}, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.SetInterfaceParameters", 0, device, params, flags).Store()
+func (m *Domain) SetInterfaceParameters(device string, params *DomainInterfaceParameters, flags DomainModificationImpact) (err error) {
+	return m.SetInterfaceParametersContext(context.Background(), device, params, flags)
+}
+
+// SetInterfaceParametersContext is like SetInterfaceParameters but gives up waiting for the reply once ctx is done.
+func (m *Domain) SetInterfaceParametersContext(ctx context.Context, device string, params *DomainInterfaceParameters, flags DomainModificationImpact) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.SetInterfaceParameters", device, encodeParams(params), flags).Store()
 	return
 }
 
 // SetMemory See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainSetMemoryFlags
-func (m *Domain) SetMemory(memory uint64, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.SetMemory", 0, memory, flags).Store()
+func (m *Domain) SetMemory(memory uint64, flags DomainMemoryModFlags) (err error) {
+	return m.SetMemoryContext(context.Background(), memory, flags)
+}
+
+// SetMemoryContext is like SetMemory but gives up waiting for the reply once ctx is done.
+func (m *Domain) SetMemoryContext(ctx context.Context, memory uint64, flags DomainMemoryModFlags) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.SetMemory", memory, flags).Store()
 	return
 }
 
 // SetMemoryParameters See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainSetMemoryParameters
-func (m *Domain) SetMemoryParameters(params map[string]interface{}, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.SetMemoryParameters", 0, params, flags).Store()
+func (m *Domain) SetMemoryParameters(params *DomainMemoryParameters, flags DomainModificationImpact) (err error) {
+	return m.SetMemoryParametersContext(context.Background(), params, flags)
+}
+
+// SetMemoryParametersContext is like SetMemoryParameters but gives up waiting for the reply once ctx is done.
+func (m *Domain) SetMemoryParametersContext(ctx context.Context, params *DomainMemoryParameters, flags DomainModificationImpact) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.SetMemoryParameters", encodeParams(params), flags).Store()
 	return
 }
 
 // SetMemoryStatsPeriod See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainSetMemoryStatsPeriod
-func (m *Domain) SetMemoryStatsPeriod(period int32, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.SetMemoryStatsPeriod", 0, period, flags).Store()
+func (m *Domain) SetMemoryStatsPeriod(period int32, flags DomainMemoryModFlags) (err error) {
+	return m.SetMemoryStatsPeriodContext(context.Background(), period, flags)
+}
+
+// SetMemoryStatsPeriodContext is like SetMemoryStatsPeriod but gives up waiting for the reply once ctx is done.
+func (m *Domain) SetMemoryStatsPeriodContext(ctx context.Context, period int32, flags DomainMemoryModFlags) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.SetMemoryStatsPeriod", period, flags).Store()
 	return
 }
 
 // SetMetadata See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainSetMetadata Empty string can be used to pass a NULL as @key or @uri argument.
-func (m *Domain) SetMetadata(itype int32, metadata string, key string, uri string, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.SetMetadata", 0, itype, metadata, key, uri, flags).Store()
+func (m *Domain) SetMetadata(itype DomainMetadataType, metadata string, key string, uri string, flags DomainModificationImpact) (err error) {
+	return m.SetMetadataContext(context.Background(), itype, metadata, key, uri, flags)
+}
+
+// SetMetadataContext is like SetMetadata but gives up waiting for the reply once ctx is done.
+func (m *Domain) SetMetadataContext(ctx context.Context, itype DomainMetadataType, metadata string, key string, uri string, flags DomainModificationImpact) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.SetMetadata", itype, metadata, key, uri, flags).Store()
 	return
 }
 
 // SetNumaParameters See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainSetNumaParameters
-func (m *Domain) SetNumaParameters(params map[string]interface{}, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.SetNumaParameters", 0, params, flags).Store()
+func (m *Domain) SetNumaParameters(params *DomainNumaParameters, flags DomainModificationImpact) (err error) {
+	return m.SetNumaParametersContext(context.Background(), params, flags)
+}
+
+// SetNumaParametersContext is like SetNumaParameters but gives up waiting for the reply once ctx is done.
+func (m *Domain) SetNumaParametersContext(ctx context.Context, params *DomainNumaParameters, flags DomainModificationImpact) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.SetNumaParameters", encodeParams(params), flags).Store()
 	return
 }
 
 // SetPerfEvents See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainSetPerfEvents
-func (m *Domain) SetPerfEvents(params map[string]interface{}, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.SetPerfEvents", 0, params, flags).Store()
+func (m *Domain) SetPerfEvents(params *DomainPerfEvents, flags DomainModificationImpact) (err error) {
+	return m.SetPerfEventsContext(context.Background(), params, flags)
+}
+
+// SetPerfEventsContext is like SetPerfEvents but gives up waiting for the reply once ctx is done.
+func (m *Domain) SetPerfEventsContext(ctx context.Context, params *DomainPerfEvents, flags DomainModificationImpact) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.SetPerfEvents", encodeParams(params), flags).Store()
 	return
 }
 
 // SetSchedulerParameters See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainSetSchedulerParametersFlags
-func (m *Domain) SetSchedulerParameters(params map[string]interface{}, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.SetSchedulerParameters", 0, params, flags).Store()
+func (m *Domain) SetSchedulerParameters(params *DomainSchedulerParameters, flags DomainModificationImpact) (err error) {
+	return m.SetSchedulerParametersContext(context.Background(), params, flags)
+}
+
+// SetSchedulerParametersContext is like SetSchedulerParameters but gives up waiting for the reply once ctx is done.
+func (m *Domain) SetSchedulerParametersContext(ctx context.Context, params *DomainSchedulerParameters, flags DomainModificationImpact) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.SetSchedulerParameters", encodeParams(params), flags).Store()
 	return
 }
 
 // SetUserPassword See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainSetUserPassword
-func (m *Domain) SetUserPassword(user string, password string, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.SetUserPassword", 0, user, password, flags).Store()
+func (m *Domain) SetUserPassword(user string, password string, flags DomainSetUserPasswordFlags) (err error) {
+	return m.SetUserPasswordContext(context.Background(), user, password, flags)
+}
+
+// SetUserPasswordContext is like SetUserPassword but gives up waiting for the reply once ctx is done.
+func (m *Domain) SetUserPasswordContext(ctx context.Context, user string, password string, flags DomainSetUserPasswordFlags) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.SetUserPassword", user, password, flags).Store()
 	return
 }
 
 // SetTime See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainSetTime
-func (m *Domain) SetTime(seconds uint64, nseconds uint32, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.SetTime", 0, seconds, nseconds, flags).Store()
+func (m *Domain) SetTime(seconds uint64, nseconds uint32, flags DomainSetTimeFlags) (err error) {
+	return m.SetTimeContext(context.Background(), seconds, nseconds, flags)
+}
+
+// SetTimeContext is like SetTime but gives up waiting for the reply once ctx is done.
+func (m *Domain) SetTimeContext(ctx context.Context, seconds uint64, nseconds uint32, flags DomainSetTimeFlags) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.SetTime", seconds, nseconds, flags).Store()
 	return
 }
 
 // SetVcpus See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainSetVcpusFlags
-func (m *Domain) SetVcpus(vcpus uint32, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.SetVcpus", 0, vcpus, flags).Store()
+func (m *Domain) SetVcpus(vcpus uint32, flags DomainVcpuFlags) (err error) {
+	return m.SetVcpusContext(context.Background(), vcpus, flags)
+}
+
+// SetVcpusContext is like SetVcpus but gives up waiting for the reply once ctx is done.
+func (m *Domain) SetVcpusContext(ctx context.Context, vcpus uint32, flags DomainVcpuFlags) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.SetVcpus", vcpus, flags).Store()
 	return
 }
 
 // Shutdown See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainShutdownFlags
-func (m *Domain) Shutdown(flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.Shutdown", 0, flags).Store()
+func (m *Domain) Shutdown(flags DomainShutdownFlags) (err error) {
+	return m.ShutdownContext(context.Background(), flags)
+}
+
+// ShutdownContext is like Shutdown but gives up waiting for the reply once ctx is done.
+func (m *Domain) ShutdownContext(ctx context.Context, flags DomainShutdownFlags) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.Shutdown", flags).Store()
+	return
+}
+
+// SnapshotCreateXML See https://libvirt.org/html/libvirt-libvirt-domain-snapshot.html#virDomainSnapshotCreateXML
+func (m *Domain) SnapshotCreateXML(xml string, flags DomainSnapshotCreateFlags) (snapshot dbus.ObjectPath, err error) {
+	return m.SnapshotCreateXMLContext(context.Background(), xml, flags)
+}
+
+// SnapshotCreateXMLContext is like SnapshotCreateXML but gives up waiting for the reply once ctx is done.
+func (m *Domain) SnapshotCreateXMLContext(ctx context.Context, xml string, flags DomainSnapshotCreateFlags) (snapshot dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.SnapshotCreateXML", xml, flags).Store(&snapshot)
+	return
+}
+
+// SnapshotCreateXMLObject is like SnapshotCreateXML but returns *DomainSnapshot instead of an object path.
+func (m *Domain) SnapshotCreateXMLObject(xml string, flags DomainSnapshotCreateFlags) (snapshot *DomainSnapshot, err error) {
+	return m.SnapshotCreateXMLObjectContext(context.Background(), xml, flags)
+}
+
+// SnapshotCreateXMLObjectContext is like SnapshotCreateXMLObject but gives up waiting for the reply once ctx is done.
+func (m *Domain) SnapshotCreateXMLObjectContext(ctx context.Context, xml string, flags DomainSnapshotCreateFlags) (snapshot *DomainSnapshot, err error) {
+	objPath, err := m.SnapshotCreateXMLContext(ctx, xml, flags)
+	if err != nil {
+		return nil, err
+	}
+	return NewDomainSnapshot(m.conn, objPath), nil
+}
+
+// SnapshotCurrent See https://libvirt.org/html/libvirt-libvirt-domain-snapshot.html#virDomainSnapshotCurrent
+func (m *Domain) SnapshotCurrent(flags uint32) (snapshot dbus.ObjectPath, err error) {
+	return m.SnapshotCurrentContext(context.Background(), flags)
+}
+
+// SnapshotCurrentContext is like SnapshotCurrent but gives up waiting for the reply once ctx is done.
+func (m *Domain) SnapshotCurrentContext(ctx context.Context, flags uint32) (snapshot dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.SnapshotCurrent", flags).Store(&snapshot)
 	return
 }
 
+// SnapshotCurrentObject is like SnapshotCurrent but returns *DomainSnapshot instead of an object path.
+func (m *Domain) SnapshotCurrentObject(flags uint32) (snapshot *DomainSnapshot, err error) {
+	return m.SnapshotCurrentObjectContext(context.Background(), flags)
+}
+
+// SnapshotCurrentObjectContext is like SnapshotCurrentObject but gives up waiting for the reply once ctx is done.
+func (m *Domain) SnapshotCurrentObjectContext(ctx context.Context, flags uint32) (snapshot *DomainSnapshot, err error) {
+	objPath, err := m.SnapshotCurrentContext(ctx, flags)
+	if err != nil {
+		return nil, err
+	}
+	return NewDomainSnapshot(m.conn, objPath), nil
+}
+
+// SnapshotLookupByName See https://libvirt.org/html/libvirt-libvirt-domain-snapshot.html#virDomainSnapshotLookupByName
+func (m *Domain) SnapshotLookupByName(name string, flags uint32) (snapshot dbus.ObjectPath, err error) {
+	return m.SnapshotLookupByNameContext(context.Background(), name, flags)
+}
+
+// SnapshotLookupByNameContext is like SnapshotLookupByName but gives up waiting for the reply once ctx is done.
+func (m *Domain) SnapshotLookupByNameContext(ctx context.Context, name string, flags uint32) (snapshot dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.SnapshotLookupByName", name, flags).Store(&snapshot)
+	return
+}
+
+// SnapshotLookupByNameObject is like SnapshotLookupByName but returns *DomainSnapshot instead of an object path.
+func (m *Domain) SnapshotLookupByNameObject(name string, flags uint32) (snapshot *DomainSnapshot, err error) {
+	return m.SnapshotLookupByNameObjectContext(context.Background(), name, flags)
+}
+
+// SnapshotLookupByNameObjectContext is like SnapshotLookupByNameObject but gives up waiting for the reply once ctx is done.
+func (m *Domain) SnapshotLookupByNameObjectContext(ctx context.Context, name string, flags uint32) (snapshot *DomainSnapshot, err error) {
+	objPath, err := m.SnapshotLookupByNameContext(ctx, name, flags)
+	if err != nil {
+		return nil, err
+	}
+	return NewDomainSnapshot(m.conn, objPath), nil
+}
+
 // Suspend See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainSuspend
 func (m *Domain) Suspend() (err error) {
-	err = m.object.Call("org.libvirt.Domain.Suspend", 0).Store()
+	return m.SuspendContext(context.Background())
+}
+
+// SuspendContext is like Suspend but gives up waiting for the reply once ctx is done.
+func (m *Domain) SuspendContext(ctx context.Context) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.Suspend").Store()
 	return
 }
 
 // Undefine See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainUndefineFlags
-func (m *Domain) Undefine(flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.Undefine", 0, flags).Store()
+func (m *Domain) Undefine(flags DomainUndefineFlags) (err error) {
+	return m.UndefineContext(context.Background(), flags)
+}
+
+// UndefineContext is like Undefine but gives up waiting for the reply once ctx is done.
+func (m *Domain) UndefineContext(ctx context.Context, flags DomainUndefineFlags) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.Undefine", flags).Store()
 	return
 }
 
 // UpdateDevice See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainUpdateDeviceFlags
-func (m *Domain) UpdateDevice(xml string, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Domain.UpdateDevice", 0, xml, flags).Store()
+func (m *Domain) UpdateDevice(xml string, flags DomainDeviceModifyFlags) (err error) {
+	return m.UpdateDeviceContext(context.Background(), xml, flags)
+}
+
+// UpdateDeviceContext is like UpdateDevice but gives up waiting for the reply once ctx is done.
+func (m *Domain) UpdateDeviceContext(ctx context.Context, xml string, flags DomainDeviceModifyFlags) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Domain.UpdateDevice", xml, flags).Store()
 	return
 }
 
 // GetActive See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainIsActive
 func (m *Domain) GetActive() (v bool, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.Domain", "Active").Store(&v)
+	return m.GetActiveContext(context.Background())
+}
+
+// GetActiveContext is like GetActive but gives up waiting for the reply once ctx is done.
+func (m *Domain) GetActiveContext(ctx context.Context) (v bool, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.Domain", "Active").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
 
 // SetAutostart See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainGetAutostart and https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainSetAutostart
 func (m *Domain) SetAutostart(v bool) (err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Set", 0, "org.libvirt.Domain", "Autostart", dbus.MakeVariant(v)).Store()
+	return m.SetAutostartContext(context.Background(), v)
+}
+
+// SetAutostartContext is like SetAutostart but gives up waiting for the reply once ctx is done.
+func (m *Domain) SetAutostartContext(ctx context.Context, v bool) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Set", "org.libvirt.Domain", "Autostart", dbus.MakeVariant(v)).Store()
 	return
 }
 
 // GetAutostart See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainGetAutostart and https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainSetAutostart
 func (m *Domain) GetAutostart() (v bool, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.Domain", "Autostart").Store(&v)
+	return m.GetAutostartContext(context.Background())
+}
+
+// GetAutostartContext is like GetAutostart but gives up waiting for the reply once ctx is done.
+func (m *Domain) GetAutostartContext(ctx context.Context) (v bool, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.Domain", "Autostart").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
 
 // GetId See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainGetID
 func (m *Domain) GetId() (v uint32, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.Domain", "Id").Store(&v)
+	return m.GetIdContext(context.Background())
+}
+
+// GetIdContext is like GetId but gives up waiting for the reply once ctx is done.
+func (m *Domain) GetIdContext(ctx context.Context) (v uint32, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.Domain", "Id").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
 
 // GetName See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainGetName
 func (m *Domain) GetName() (v string, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.Domain", "Name").Store(&v)
+	return m.GetNameContext(context.Background())
+}
+
+// GetNameContext is like GetName but gives up waiting for the reply once ctx is done.
+func (m *Domain) GetNameContext(ctx context.Context) (v string, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.Domain", "Name").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
 
 // GetOSType See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainGetOSType
 func (m *Domain) GetOSType() (v string, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.Domain", "OSType").Store(&v)
+	return m.GetOSTypeContext(context.Background())
+}
+
+// GetOSTypeContext is like GetOSType but gives up waiting for the reply once ctx is done.
+func (m *Domain) GetOSTypeContext(ctx context.Context) (v string, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.Domain", "OSType").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
 
 // GetPersistent See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainIsPersistent
 func (m *Domain) GetPersistent() (v bool, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.Domain", "Persistent").Store(&v)
+	return m.GetPersistentContext(context.Background())
+}
+
+// GetPersistentContext is like GetPersistent but gives up waiting for the reply once ctx is done.
+func (m *Domain) GetPersistentContext(ctx context.Context) (v bool, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.Domain", "Persistent").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
 
 // GetSchedulerType See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainGetSchedulerType
-func (m *Domain) GetSchedulerType() (v interface{}, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.Domain", "SchedulerType").Store(&v)
+func (m *Domain) GetSchedulerType() (v DomainSchedulerType, err error) {
+	return m.GetSchedulerTypeContext(context.Background())
+}
+
+// GetSchedulerTypeContext is like GetSchedulerType but gives up waiting for the reply once ctx is done.
+func (m *Domain) GetSchedulerTypeContext(ctx context.Context) (v DomainSchedulerType, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.Domain", "SchedulerType").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
 
 // GetUpdated See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainIsUpdated
 func (m *Domain) GetUpdated() (v bool, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.Domain", "Updated").Store(&v)
+	return m.GetUpdatedContext(context.Background())
+}
+
+// GetUpdatedContext is like GetUpdated but gives up waiting for the reply once ctx is done.
+func (m *Domain) GetUpdatedContext(ctx context.Context) (v bool, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.Domain", "Updated").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
 
 // GetUUID See https://libvirt.org/html/libvirt-libvirt-domain.html#virDomainGetUUIDString
 func (m *Domain) GetUUID() (v string, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.Domain", "UUID").Store(&v)
+	return m.GetUUIDContext(context.Background())
+}
+
+// GetUUIDContext is like GetUUID but gives up waiting for the reply once ctx is done.
+func (m *Domain) GetUUIDContext(ctx context.Context) (v string, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.Domain", "UUID").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
@@ -1,46 +1,290 @@
 package libvirt
 
 import (
-	"fmt"
+	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/godbus/dbus"
 )
 
-func DomainEventCb(domain dbus.ObjectPath, event int32, detail int32) {
-	fmt.Printf("%s %d %d\n", domain, event, detail)
+func TestListDomains(t *testing.T) {
+	c, _ := newTestConn(t)
+
+	conn := NewConnect(c, "")
+	domains, err := conn.ListDomainsObjects(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(domains) != 1 {
+		t.Fatalf("got %d domains, want 1", len(domains))
+	}
+	if name, err := domains[0].GetName(); err != nil || name != "test" {
+		t.Fatalf("got name %q, %v", name, err)
+	}
+
+	domain, err := conn.DomainLookupByNameObject("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	def, err := domain.Definition()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if def.UUID != fakeDomainUUID {
+		t.Errorf("got uuid %q", def.UUID)
+	}
+	if def.ID != nil {
+		t.Errorf("got the live definition with id %d", *def.ID)
+	}
+
+	records, err := conn.GetAllDomainStats(0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].Domain != "test" {
+		t.Errorf("got stats %+v", records)
+	}
+
+	if err = domain.SetAutostart(true); err != nil {
+		t.Fatal(err)
+	}
+	if st, err := domain.GetAutostart(); err != nil || !st {
+		t.Errorf("got autostart %v, %v", st, err)
+	}
+
+	_, err = conn.DomainLookupByName("winxp")
+	if !errors.Is(err, ErrNoDomain) {
+		t.Errorf("got %v, want ErrNoDomain", err)
+	}
 }
 
-func TestListDomains(t *testing.T) {
-	c, err := NewConn(DriverQEMU)
+func TestDomainLifecycle(t *testing.T) {
+	c, _ := newTestConn(t)
+	conn := NewConnect(c, "")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := conn.DomainEvents(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	next := func() string {
+		select {
+		case ev := <-events:
+			return ev.String()
+		case <-time.After(5 * time.Second):
+			t.Fatal("no event")
+			return ""
+		}
+	}
+
+	domain, err := conn.DomainDefineXMLObject("<domain type='test'><name>guest</name></domain>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ev := next(); ev != "Defined Added" {
+		t.Errorf("got %q", ev)
+	}
+
+	steps := []struct {
+		op    func() error
+		event string
+		state int32
+	}{
+		{func() error { return domain.Create(0) }, "Started Booted", fakeStateRunning},
+		{domain.Suspend, "Suspended Paused", fakeStatePaused},
+		{domain.Resume, "Resumed Unpaused", fakeStateRunning},
+		{func() error { return domain.Destroy(0) }, "Stopped Destroyed", fakeStateShutoff},
+	}
+	for _, step := range steps {
+		if err = step.op(); err != nil {
+			t.Fatal(err)
+		}
+		if ev := next(); ev != step.event {
+			t.Errorf("got %q, want %q", ev, step.event)
+		}
+		if st, err := domain.GetState(0); err != nil || st.State != step.state {
+			t.Errorf("after %s got state %+v, %v", step.event, st, err)
+		}
+	}
+
+	err = domain.Destroy(0)
+	if !errors.Is(err, ErrOperationInvalid) {
+		t.Errorf("got %v, want ErrOperationInvalid", err)
+	}
+	if err = domain.Undefine(0); err != nil {
+		t.Fatal(err)
+	}
+	if ev := next(); ev != "Undefined Removed" {
+		t.Errorf("got %q", ev)
+	}
+	if _, err = conn.DomainLookupByName("guest"); !errors.Is(err, ErrNoDomain) {
+		t.Errorf("got %v, want ErrNoDomain", err)
+	}
+}
+
+func TestStoreInterfaceAddresses(t *testing.T) {
+	body := []interface{}{[][]interface{}{
+		{"vnet0", "52:54:00:aa:bb:cc", [][]interface{}{{int32(0), "192.168.122.10", uint32(24)}}},
+	}}
+	var ifaces []DomainInterface
+	if err := dbus.Store(body, &ifaces); err != nil {
+		t.Fatal(err)
+	}
+	if len(ifaces) != 1 || ifaces[0].HwAddr != "52:54:00:aa:bb:cc" ||
+		len(ifaces[0].Addrs) != 1 || ifaces[0].Addrs[0].Addr != "192.168.122.10" || ifaces[0].Addrs[0].Prefix != 24 {
+		t.Fatalf("unexpected result %+v", ifaces)
+	}
+}
+
+func TestDomainMemoryParameters(t *testing.T) {
+	c, _ := newTestConn(t)
+	domain, err := NewConnect(c, "").DomainLookupByNameObject("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	limit := uint64(2097152)
+	if err = domain.SetMemoryParameters(&DomainMemoryParameters{HardLimit: &limit}, DomainAffectLive); err != nil {
+		t.Fatal(err)
+	}
+	params, err := domain.GetMemoryParameters(DomainAffectLive)
 	if err != nil {
 		t.Fatal(err)
 	}
-	defer c.Close()
+	if params.HardLimit == nil || *params.HardLimit != limit {
+		t.Errorf("got hard limit %v, want %d", params.HardLimit, limit)
+	}
+	if params.SoftLimit == nil || *params.SoftLimit != DomainMemoryParamUnlimited {
+		t.Errorf("got soft limit %v, want unlimited", params.SoftLimit)
+	}
+	if params.MinGuarantee != nil {
+		t.Errorf("got min guarantee %d, want none", *params.MinGuarantee)
+	}
+
+	err = decodeParams(map[string]dbus.Variant{"hard_limit": dbus.MakeVariant(int32(1))}, params)
+	if err == nil {
+		t.Error("decoded an int as hard_limit")
+	}
+}
 
+func TestDomainStats(t *testing.T) {
+	c, _ := newTestConn(t)
 	conn := NewConnect(c, "")
-	dpath, err := conn.DomainLookupByName("winxp")
+
+	all, err := conn.AllDomainStats(DomainStatsState|DomainStatsVCPU|DomainStatsInterface|DomainStatsBlock, 0)
 	if err != nil {
 		t.Fatal(err)
 	}
-	domain := NewDomain(c, dpath)
-	_, err = domain.GetXMLDesc(0)
+	if len(all) != 1 {
+		t.Fatalf("got %d records, want 1", len(all))
+	}
+	s := all[0]
+	if s.Domain != "test" || s.State == nil || s.State.State != fakeStateRunning {
+		t.Errorf("got %+v", s)
+	}
+	if s.CPU != nil || s.Balloon != nil {
+		t.Errorf("got unrequested cpu %+v, balloon %+v", s.CPU, s.Balloon)
+	}
+	if s.VCPUCurrent != 2 || len(s.VCPU) != 2 || s.VCPU[1].Time != 1000000000 {
+		t.Errorf("got vcpus %d, %+v", s.VCPUCurrent, s.VCPU)
+	}
+	if len(s.Net) != 1 || s.Net[0].Name != "testnet0" || s.Net[0].RxBytes != 4096 {
+		t.Errorf("got net %+v", s.Net)
+	}
+	if len(s.Block) != 1 || s.Block[0].Name != "vda" || s.Block[0].WrBytes != 2048 {
+		t.Errorf("got block %+v", s.Block)
+	}
+	if s.Perf != nil || s.IOThread != nil || s.MemoryBandwidth != nil {
+		t.Errorf("got perf %+v, iothreads %+v, memory bandwidth %+v", s.Perf, s.IOThread, s.MemoryBandwidth)
+	}
+
+	domain, err := conn.DomainLookupByNameObject("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	one, err := domain.Stats(0, 0)
 	if err != nil {
 		t.Fatal(err)
 	}
+	if one.CPU == nil || one.CPU.Time != 1000000000 {
+		t.Errorf("got cpu %+v", one.CPU)
+	}
+	if one.Balloon == nil || one.Balloon.Current != 8388608 {
+		t.Errorf("got balloon %+v", one.Balloon)
+	}
+	if len(one.MemoryBandwidth) != 1 || one.MemoryBandwidth[0].VCPUs != "0" ||
+		len(one.MemoryBandwidth[0].Nodes) != 1 || one.MemoryBandwidth[0].Nodes[0].BytesTotal != 8192 {
+		t.Errorf("got memory bandwidth %+v", one.MemoryBandwidth)
+	}
 
-	_, err = conn.GetAllDomainStats(0, 1)
+	// With vCPU 1 unplugged the vCPUs keep their numbers.
+	sparse, err := conn.DomainCreateXMLObject("<domain type='test'><name>sparse</name>"+
+		"<vcpu current='2'>3</vcpu><vcpus><vcpu id='0' enabled='yes'/><vcpu id='1' enabled='no'/>"+
+		"<vcpu id='2' enabled='yes'/></vcpus></domain>", 0)
 	if err != nil {
-		panic(err)
+		t.Fatal(err)
 	}
+	if s, err = sparse.Stats(DomainStatsVCPU, 0); err != nil {
+		t.Fatal(err)
+	}
+	if s.VCPUCurrent != 2 || s.VCPUMaximum != 3 || len(s.VCPU) != 2 || s.VCPU[0].Number != 0 || s.VCPU[1].Number != 2 {
+		t.Errorf("got vcpus %d/%d, %+v", s.VCPUCurrent, s.VCPUMaximum, s.VCPU)
+	}
+}
 
-	ch := conn.SubscribeDomainEvent(DomainEventCb)
-	defer conn.UnSubscribeDomainEvent(ch)
+func TestDomainSignalsArePerObject(t *testing.T) {
+	c, f := newTestConn(t)
+	conn := NewConnect(c, "")
 
-	st, err := domain.GetAutostart()
+	domain, err := conn.DomainLookupByUUIDObject(fakeDomainUUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := conn.DomainCreateXMLObject("<domain type='test'><name>other</name></domain>", 0)
 	if err != nil {
-		panic(err)
+		t.Fatal(err)
+	}
+
+	// Both signals reach the connection, so the dispatcher has to tell
+	// them apart by path.
+	rebooted := make(chan struct{}, 2)
+	sub, err := domain.SubscribeReboot(func() { rebooted <- struct{}{} })
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Close()
+	otherRebooted := make(chan struct{}, 2)
+	otherSub, err := other.SubscribeReboot(func() { otherRebooted <- struct{}{} })
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer otherSub.Close()
+	rule := "type='signal',interface='org.libvirt.Domain',member='Reboot',path='" + string(domain.path) + "'"
+	if n := f.matchRules()[rule]; n != 1 {
+		t.Fatalf("rule %s installed %d times", rule, n)
+	}
+
+	if err = other.Reboot(0); err != nil {
+		t.Fatal(err)
+	}
+	if err = domain.Reboot(0); err != nil {
+		t.Fatal(err)
+	}
+	for _, ch := range []chan struct{}{rebooted, otherRebooted} {
+		select {
+		case <-ch:
+		case <-time.After(5 * time.Second):
+			t.Fatal("no Reboot signal")
+		}
+	}
+	select {
+	case <-rebooted:
+		t.Error("a subscription got the Reboot signal of another domain")
+	case <-otherRebooted:
+		t.Error("a subscription got the Reboot signal of another domain")
+	case <-time.After(50 * time.Millisecond):
 	}
-	fmt.Printf("autostart %#+v\n", st)
-	select {}
 }
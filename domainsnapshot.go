@@ -0,0 +1,120 @@
+package libvirt
+
+import (
+	"context"
+
+	"github.com/godbus/dbus"
+)
+
+type DomainSnapshot struct {
+	conn *Conn
+	path dbus.ObjectPath
+}
+
+// NewDomainSnapshot() TODO
+func NewDomainSnapshot(c *Conn, path dbus.ObjectPath) *DomainSnapshot {
+	m := &DomainSnapshot{conn: c, path: path}
+	if path == "" {
+		m.path = c.path
+	}
+
+	return m
+}
+
+// Delete See https://libvirt.org/html/libvirt-libvirt-domain-snapshot.html#virDomainSnapshotDelete
+func (m *DomainSnapshot) Delete(flags DomainSnapshotDeleteFlags) (err error) {
+	return m.DeleteContext(context.Background(), flags)
+}
+
+// DeleteContext is like Delete but gives up waiting for the reply once ctx is done.
+func (m *DomainSnapshot) DeleteContext(ctx context.Context, flags DomainSnapshotDeleteFlags) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.DomainSnapshot.Delete", flags).Store()
+	return
+}
+
+// GetParent See https://libvirt.org/html/libvirt-libvirt-domain-snapshot.html#virDomainSnapshotGetParent
+func (m *DomainSnapshot) GetParent(flags uint32) (parent dbus.ObjectPath, err error) {
+	return m.GetParentContext(context.Background(), flags)
+}
+
+// GetParentContext is like GetParent but gives up waiting for the reply once ctx is done.
+func (m *DomainSnapshot) GetParentContext(ctx context.Context, flags uint32) (parent dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.DomainSnapshot.GetParent", flags).Store(&parent)
+	return
+}
+
+// GetParentObject is like GetParent but returns *DomainSnapshot instead of an object path.
+func (m *DomainSnapshot) GetParentObject(flags uint32) (parent *DomainSnapshot, err error) {
+	return m.GetParentObjectContext(context.Background(), flags)
+}
+
+// GetParentObjectContext is like GetParentObject but gives up waiting for the reply once ctx is done.
+func (m *DomainSnapshot) GetParentObjectContext(ctx context.Context, flags uint32) (parent *DomainSnapshot, err error) {
+	objPath, err := m.GetParentContext(ctx, flags)
+	if err != nil {
+		return nil, err
+	}
+	return NewDomainSnapshot(m.conn, objPath), nil
+}
+
+// GetXMLDesc See https://libvirt.org/html/libvirt-libvirt-domain-snapshot.html#virDomainSnapshotGetXMLDesc
+func (m *DomainSnapshot) GetXMLDesc(flags DomainSnapshotXMLFlags) (xml string, err error) {
+	return m.GetXMLDescContext(context.Background(), flags)
+}
+
+// GetXMLDescContext is like GetXMLDesc but gives up waiting for the reply once ctx is done.
+func (m *DomainSnapshot) GetXMLDescContext(ctx context.Context, flags DomainSnapshotXMLFlags) (xml string, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.DomainSnapshot.GetXMLDesc", flags).Store(&xml)
+	return
+}
+
+// IsCurrent See https://libvirt.org/html/libvirt-libvirt-domain-snapshot.html#virDomainSnapshotIsCurrent
+func (m *DomainSnapshot) IsCurrent(flags uint32) (current bool, err error) {
+	return m.IsCurrentContext(context.Background(), flags)
+}
+
+// IsCurrentContext is like IsCurrent but gives up waiting for the reply once ctx is done.
+func (m *DomainSnapshot) IsCurrentContext(ctx context.Context, flags uint32) (current bool, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.DomainSnapshot.IsCurrent", flags).Store(&current)
+	return
+}
+
+// ListChildren See https://libvirt.org/html/libvirt-libvirt-domain-snapshot.html#virDomainSnapshotListAllChildren
+func (m *DomainSnapshot) ListChildren(flags DomainSnapshotListFlags) (snapshots []dbus.ObjectPath, err error) {
+	return m.ListChildrenContext(context.Background(), flags)
+}
+
+// ListChildrenContext is like ListChildren but gives up waiting for the reply once ctx is done.
+func (m *DomainSnapshot) ListChildrenContext(ctx context.Context, flags DomainSnapshotListFlags) (snapshots []dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.DomainSnapshot.ListChildren", flags).Store(&snapshots)
+	return
+}
+
+// ListChildrenObjects is like ListChildren but returns []*DomainSnapshot instead of object paths.
+func (m *DomainSnapshot) ListChildrenObjects(flags DomainSnapshotListFlags) (snapshots []*DomainSnapshot, err error) {
+	return m.ListChildrenObjectsContext(context.Background(), flags)
+}
+
+// ListChildrenObjectsContext is like ListChildrenObjects but gives up waiting for the reply once ctx is done.
+func (m *DomainSnapshot) ListChildrenObjectsContext(ctx context.Context, flags DomainSnapshotListFlags) (snapshots []*DomainSnapshot, err error) {
+	objPaths, err := m.ListChildrenContext(ctx, flags)
+	if err != nil {
+		return nil, err
+	}
+	snapshots = make([]*DomainSnapshot, len(objPaths))
+	for i, objPath := range objPaths {
+		snapshots[i] = NewDomainSnapshot(m.conn, objPath)
+	}
+	return snapshots, nil
+}
+
+// Revert See https://libvirt.org/html/libvirt-libvirt-domain-snapshot.html#virDomainRevertToSnapshot
+func (m *DomainSnapshot) Revert(flags DomainSnapshotRevertFlags) (err error) {
+	return m.RevertContext(context.Background(), flags)
+}
+
+// RevertContext is like Revert but gives up waiting for the reply once ctx is done.
+func (m *DomainSnapshot) RevertContext(ctx context.Context, flags DomainSnapshotRevertFlags) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.DomainSnapshot.Revert", flags).Store()
+	return
+}
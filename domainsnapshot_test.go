@@ -0,0 +1,53 @@
+package libvirt
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDomainSnapshots(t *testing.T) {
+	c, _ := newTestConn(t)
+	conn := NewConnect(c, "")
+
+	domain, err := conn.DomainLookupByUUIDObject(fakeDomainUUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = domain.SnapshotCurrent(0); !errors.Is(err, ErrNoDomainSnapshot) {
+		t.Errorf("got %v without snapshots, want ErrNoDomainSnapshot", err)
+	}
+
+	base, err := domain.SnapshotCreateXMLObject("<domainsnapshot><name>base</name></domainsnapshot>", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	top, err := domain.SnapshotCreateXMLObject("<domainsnapshot><name>top</name></domainsnapshot>", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if current, err := domain.SnapshotCurrentObject(0); err != nil || current.path != top.path {
+		t.Errorf("got current %v, %v", current, err)
+	}
+	if parent, err := top.GetParentObject(0); err != nil || parent.path != base.path {
+		t.Errorf("got parent %v, %v", parent, err)
+	}
+	if children, err := base.ListChildrenObjects(0); err != nil || len(children) != 1 || children[0].path != top.path {
+		t.Errorf("got children %v, %v", children, err)
+	}
+
+	if err = base.Revert(0); err != nil {
+		t.Fatal(err)
+	}
+	if current, err := base.IsCurrent(0); err != nil || !current {
+		t.Errorf("got current %v, %v after revert", current, err)
+	}
+	if err = top.Delete(0); err != nil {
+		t.Fatal(err)
+	}
+	if snapshots, err := domain.ListDomainSnapshots(0); err != nil || len(snapshots) != 1 {
+		t.Errorf("got snapshots %v, %v", snapshots, err)
+	}
+	if _, err = domain.SnapshotLookupByName("top", 0); !errors.Is(err, ErrNoDomainSnapshot) {
+		t.Errorf("got %v, want ErrNoDomainSnapshot", err)
+	}
+}
@@ -0,0 +1,308 @@
+package libvirt
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DomainStats are the statistics of one domain, parsed from the flat
+// "group.index.field" keys libvirt reports them with. A section is nil, or
+// a list empty, when its stat group was not requested or not reported;
+// fields libvirt leaves out of a reported group are zero.
+type DomainStats struct {
+	// Domain is the domain name. Domain.Stats leaves it empty.
+	Domain      string
+	State       *DomainState
+	CPU         *DomainCPUStats
+	Balloon     *DomainBalloonStats
+	VCPUCurrent uint32
+	VCPUMaximum uint32
+	VCPU        []DomainVCPUStats
+	Net         []DomainNetStats
+	Block       []DomainBlockStats
+	Perf        *DomainPerfStats
+	IOThread    []DomainIOThreadStats
+	// MemoryBandwidth are the resctrl monitors of the domain.
+	MemoryBandwidth []DomainMemoryBandwidthMonitor
+}
+
+// DomainCPUStats are the "cpu.*" statistics, in nanoseconds.
+type DomainCPUStats struct {
+	Time   uint64 `stat:"time"`
+	User   uint64 `stat:"user"`
+	System uint64 `stat:"system"`
+}
+
+// DomainBalloonStats are the "balloon.*" statistics, in KiB except for
+// the fault counters and LastUpdate, a timestamp in seconds.
+type DomainBalloonStats struct {
+	Current        uint64 `stat:"current"`
+	Maximum        uint64 `stat:"maximum"`
+	SwapIn         uint64 `stat:"swap_in"`
+	SwapOut        uint64 `stat:"swap_out"`
+	MajorFault     uint64 `stat:"major_fault"`
+	MinorFault     uint64 `stat:"minor_fault"`
+	Unused         uint64 `stat:"unused"`
+	Available      uint64 `stat:"available"`
+	Usable         uint64 `stat:"usable"`
+	RSS            uint64 `stat:"rss"`
+	LastUpdate     uint64 `stat:"last-update"`
+	DiskCaches     uint64 `stat:"disk_caches"`
+	HugetlbPgalloc uint64 `stat:"hugetlb_pgalloc"`
+	HugetlbPgfail  uint64 `stat:"hugetlb_pgfail"`
+}
+
+// DomainVCPUStats are the "vcpu.<n>.*" statistics of a virtual CPU; Time
+// and Wait are in nanoseconds. Number is n, which need not match the
+// position in DomainStats.VCPU since offline vCPUs are left out.
+type DomainVCPUStats struct {
+	Number uint32
+	State  int32  `stat:"state"`
+	Time   uint64 `stat:"time"`
+	Wait   uint64 `stat:"wait"`
+	Halted bool   `stat:"halted"`
+}
+
+// DomainNetStats are the "net.<n>.*" statistics of an interface.
+type DomainNetStats struct {
+	Name    string `stat:"name"`
+	RxBytes uint64 `stat:"rx.bytes"`
+	RxPkts  uint64 `stat:"rx.pkts"`
+	RxErrs  uint64 `stat:"rx.errs"`
+	RxDrop  uint64 `stat:"rx.drop"`
+	TxBytes uint64 `stat:"tx.bytes"`
+	TxPkts  uint64 `stat:"tx.pkts"`
+	TxErrs  uint64 `stat:"tx.errs"`
+	TxDrop  uint64 `stat:"tx.drop"`
+}
+
+// DomainBlockStats are the "block.<n>.*" statistics of a disk or, with
+// ConnectGetAllDomainsStatsBacking, one of its backing images. The times
+// are in nanoseconds.
+type DomainBlockStats struct {
+	Name         string `stat:"name"`
+	BackingIndex uint32 `stat:"backingIndex"`
+	Path         string `stat:"path"`
+	RdReqs       uint64 `stat:"rd.reqs"`
+	RdBytes      uint64 `stat:"rd.bytes"`
+	RdTimes      uint64 `stat:"rd.times"`
+	WrReqs       uint64 `stat:"wr.reqs"`
+	WrBytes      uint64 `stat:"wr.bytes"`
+	WrTimes      uint64 `stat:"wr.times"`
+	FlReqs       uint64 `stat:"fl.reqs"`
+	FlTimes      uint64 `stat:"fl.times"`
+	Errors       uint64 `stat:"errors"`
+	Allocation   uint64 `stat:"allocation"`
+	Capacity     uint64 `stat:"capacity"`
+	Physical     uint64 `stat:"physical"`
+	Threshold    uint64 `stat:"threshold"`
+}
+
+// DomainPerfStats are the "perf.*" counters of the events enabled with
+// Domain.SetPerfEvents.
+type DomainPerfStats struct {
+	CMT                   uint64 `stat:"cmt"`
+	MBMT                  uint64 `stat:"mbmt"`
+	MBML                  uint64 `stat:"mbml"`
+	CPUCycles             uint64 `stat:"cpu_cycles"`
+	Instructions          uint64 `stat:"instructions"`
+	CacheReferences       uint64 `stat:"cache_references"`
+	CacheMisses           uint64 `stat:"cache_misses"`
+	BranchInstructions    uint64 `stat:"branch_instructions"`
+	BranchMisses          uint64 `stat:"branch_misses"`
+	BusCycles             uint64 `stat:"bus_cycles"`
+	StalledCyclesFrontend uint64 `stat:"stalled_cycles_frontend"`
+	StalledCyclesBackend  uint64 `stat:"stalled_cycles_backend"`
+	RefCPUCycles          uint64 `stat:"ref_cpu_cycles"`
+	CPUClock              uint64 `stat:"cpu_clock"`
+	TaskClock             uint64 `stat:"task_clock"`
+	PageFaults            uint64 `stat:"page_faults"`
+	ContextSwitches       uint64 `stat:"context_switches"`
+	CPUMigrations         uint64 `stat:"cpu_migrations"`
+	PageFaultsMin         uint64 `stat:"page_faults_min"`
+	PageFaultsMaj         uint64 `stat:"page_faults_maj"`
+	AlignmentFaults       uint64 `stat:"alignment_faults"`
+	EmulationFaults       uint64 `stat:"emulation_faults"`
+}
+
+// DomainIOThreadStats are the "iothread.<id>.*" polling settings of an
+// I/O thread.
+type DomainIOThreadStats struct {
+	ID         uint32
+	PollMaxNs  uint64 `stat:"poll-max-ns"`
+	PollGrow   uint32 `stat:"poll-grow"`
+	PollShrink uint32 `stat:"poll-shrink"`
+}
+
+// DomainMemoryBandwidthMonitor are the "memory.bandwidth.monitor.<n>.*"
+// statistics of a memory bandwidth monitor. VCPUs is the list of vCPUs it
+// covers, such as "0-3".
+type DomainMemoryBandwidthMonitor struct {
+	Name  string `stat:"name"`
+	VCPUs string `stat:"vcpus"`
+	Nodes []DomainMemoryBandwidthNode
+}
+
+// DomainMemoryBandwidthNode are the "node.<i>.*" counters of a monitor for
+// one cache node, in bytes.
+type DomainMemoryBandwidthNode struct {
+	ID         uint32 `stat:"id"`
+	BytesLocal uint64 `stat:"bytes.local"`
+	BytesTotal uint64 `stat:"bytes.total"`
+}
+
+// AllDomainStats is like GetAllDomainStats but parses the statistics of
+// each domain into DomainStats.
+func (m *Connect) AllDomainStats(stats DomainStatsTypes, flags ConnectGetAllDomainStatsFlags) ([]*DomainStats, error) {
+	return m.AllDomainStatsContext(context.Background(), stats, flags)
+}
+
+// AllDomainStatsContext is like AllDomainStats but gives up waiting for the reply once ctx is done.
+func (m *Connect) AllDomainStatsContext(ctx context.Context, stats DomainStatsTypes, flags ConnectGetAllDomainStatsFlags) ([]*DomainStats, error) {
+	records, err := m.GetAllDomainStatsContext(ctx, stats, flags)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*DomainStats, len(records))
+	for i, r := range records {
+		ret[i] = parseDomainStats(r.Stats)
+		ret[i].Domain = r.Domain
+	}
+	return ret, nil
+}
+
+// Stats is like GetStats but parses the statistics into DomainStats.
+func (m *Domain) Stats(stats DomainStatsTypes, flags ConnectGetAllDomainStatsFlags) (*DomainStats, error) {
+	return m.StatsContext(context.Background(), stats, flags)
+}
+
+// StatsContext is like Stats but gives up waiting for the reply once ctx is done.
+func (m *Domain) StatsContext(ctx context.Context, stats DomainStatsTypes, flags ConnectGetAllDomainStatsFlags) (*DomainStats, error) {
+	records, err := m.GetStatsContext(ctx, stats, flags)
+	if err != nil {
+		return nil, err
+	}
+	return parseDomainStats(records), nil
+}
+
+func parseDomainStats(stats map[string]interface{}) *DomainStats {
+	s := new(DomainStats)
+	if state, ok := stats["state.state"]; ok {
+		s.State = new(DomainState)
+		setStat(reflect.ValueOf(&s.State.State).Elem(), state)
+		setStat(reflect.ValueOf(&s.State.Reason).Elem(), stats["state.reason"])
+	}
+	setStat(reflect.ValueOf(&s.VCPUCurrent).Elem(), stats["vcpu.current"])
+	setStat(reflect.ValueOf(&s.VCPUMaximum).Elem(), stats["vcpu.maximum"])
+
+	if cpu := new(DomainCPUStats); decodeStats(stats, "cpu.", cpu) {
+		s.CPU = cpu
+	}
+	if balloon := new(DomainBalloonStats); decodeStats(stats, "balloon.", balloon) {
+		s.Balloon = balloon
+	}
+	if perf := new(DomainPerfStats); decodeStats(stats, "perf.", perf) {
+		s.Perf = perf
+	}
+	for _, n := range statIndexes(stats, "vcpu.") {
+		vcpu := DomainVCPUStats{Number: uint32(n)}
+		decodeStats(stats, "vcpu."+strconv.Itoa(n)+".", &vcpu)
+		s.VCPU = append(s.VCPU, vcpu)
+	}
+	for _, n := range statIndexes(stats, "net.") {
+		var net DomainNetStats
+		decodeStats(stats, "net."+strconv.Itoa(n)+".", &net)
+		s.Net = append(s.Net, net)
+	}
+	for _, n := range statIndexes(stats, "block.") {
+		var block DomainBlockStats
+		decodeStats(stats, "block."+strconv.Itoa(n)+".", &block)
+		s.Block = append(s.Block, block)
+	}
+	for _, n := range statIndexes(stats, "iothread.") {
+		iothread := DomainIOThreadStats{ID: uint32(n)}
+		decodeStats(stats, "iothread."+strconv.Itoa(n)+".", &iothread)
+		s.IOThread = append(s.IOThread, iothread)
+	}
+	for _, n := range statIndexes(stats, "memory.bandwidth.monitor.") {
+		prefix := "memory.bandwidth.monitor." + strconv.Itoa(n) + "."
+		var mon DomainMemoryBandwidthMonitor
+		decodeStats(stats, prefix, &mon)
+		for _, i := range statIndexes(stats, prefix+"node.") {
+			var node DomainMemoryBandwidthNode
+			decodeStats(stats, prefix+"node."+strconv.Itoa(i)+".", &node)
+			mon.Nodes = append(mon.Nodes, node)
+		}
+		s.MemoryBandwidth = append(s.MemoryBandwidth, mon)
+	}
+	return s
+}
+
+// statIndexes returns the numbers following prefix in the keys of stats,
+// in ascending order. Interfaces, disks and monitors are numbered from 0,
+// vCPUs likewise but with gaps for the offline ones, and I/O threads by
+// their IDs.
+func statIndexes(stats map[string]interface{}, prefix string) []int {
+	seen := make(map[int]bool)
+	var ret []int
+	for key := range stats {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		rest := key[len(prefix):]
+		dot := strings.IndexByte(rest, '.')
+		if dot < 0 {
+			continue
+		}
+		n, err := strconv.Atoi(rest[:dot])
+		if err != nil || seen[n] {
+			continue
+		}
+		seen[n] = true
+		ret = append(ret, n)
+	}
+	sort.Ints(ret)
+	return ret
+}
+
+// decodeStats sets the fields of v, a pointer to one of the structs above,
+// from the statistics named prefix followed by their stat tag, and reports
+// whether any of them was present.
+func decodeStats(stats map[string]interface{}, prefix string, v interface{}) bool {
+	found := false
+	rv := reflect.ValueOf(v).Elem()
+	for i := 0; i < rv.NumField(); i++ {
+		tag := rv.Type().Field(i).Tag.Get("stat")
+		if tag == "" {
+			continue
+		}
+		if val, ok := stats[prefix+tag]; ok {
+			setStat(rv.Field(i), val)
+			found = true
+		}
+	}
+	return found
+}
+
+// setStat stores val in f if it has the same kind of type, converting
+// between integer types since libvirt has changed the types of some
+// statistics over time.
+func setStat(f reflect.Value, val interface{}) {
+	if val == nil {
+		return
+	}
+	rv := reflect.ValueOf(val)
+	switch {
+	case isInteger(f.Kind()) && isInteger(rv.Kind()),
+		f.Kind() == reflect.String && rv.Kind() == reflect.String,
+		f.Kind() == reflect.Bool && rv.Kind() == reflect.Bool:
+		f.Set(rv.Convert(f.Type()))
+	}
+}
+
+func isInteger(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Uint64
+}
@@ -0,0 +1,88 @@
+package libvirt
+
+import (
+	"encoding/xml"
+
+	"github.com/godbus/dbus"
+)
+
+// Driver names a libvirt driver exported by libvirt-dbus as
+// /org/libvirt/<Driver>. Besides the constants below any name returned by
+// ListDrivers can be used, e.g. Driver("CH").
+type Driver string
+
+const (
+	DriverVBox   Driver = "VBox"
+	DriverVZ     Driver = "VZ"
+	DriverQEMU   Driver = "QEMU"
+	DriverOpenVZ Driver = "OpenVZ"
+	DriverBHyve  Driver = "BHyve"
+	DriverLXC    Driver = "LXC"
+	DriverTest   Driver = "Test"
+	DriverXen    Driver = "Xen"
+	DriverUML    Driver = "UML"
+)
+
+const driverRoot = "/org/libvirt"
+
+// valid reports whether d can be used as an object path element.
+func (d Driver) valid() bool {
+	if d == "" {
+		return false
+	}
+	for _, c := range d {
+		if !(c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+func (d Driver) path() dbus.ObjectPath {
+	return dbus.ObjectPath(driverRoot + "/" + string(d))
+}
+
+// ListDrivers connects to the bus selected by opts and returns the drivers
+// libvirt-dbus currently exports, found by introspecting /org/libvirt.
+func ListDrivers(opts ...ConnOption) ([]Driver, error) {
+	o := connOptions{dial: dbus.SystemBusPrivate}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	bus := o.conn
+	if bus == nil {
+		var err error
+		if bus, err = o.open(); err != nil {
+			return nil, err
+		}
+		defer bus.Close()
+	}
+
+	var data string
+	err := bus.Object("org.libvirt", driverRoot).Call("org.freedesktop.DBus.Introspectable.Introspect", 0).Store(&data)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return parseDrivers(data)
+}
+
+// parseDrivers returns the child nodes of an introspection document.
+func parseDrivers(data string) ([]Driver, error) {
+	var node struct {
+		Children []struct {
+			Name string `xml:"name,attr"`
+		} `xml:"node"`
+	}
+	if err := xml.Unmarshal([]byte(data), &node); err != nil {
+		return nil, err
+	}
+
+	var drivers []Driver
+	for _, child := range node.Children {
+		if d := Driver(child.Name); d.valid() {
+			drivers = append(drivers, d)
+		}
+	}
+	return drivers, nil
+}
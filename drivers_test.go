@@ -0,0 +1,24 @@
+package libvirt
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDrivers(t *testing.T) {
+	data := `<!DOCTYPE node PUBLIC "-//freedesktop//DTD D-BUS Object Introspection 1.0//EN"
+ "http://www.freedesktop.org/standards/dbus/1.0/introspect.dtd">
+<node>
+  <interface name="org.freedesktop.DBus.Introspectable"/>
+  <node name="QEMU"/>
+  <node name="CH"/>
+  <node name="bad-name"/>
+</node>`
+	drivers, err := parseDrivers(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []Driver{DriverQEMU, "CH"}; !reflect.DeepEqual(drivers, want) {
+		t.Fatalf("got %v, want %v", drivers, want)
+	}
+}
@@ -0,0 +1,312 @@
+package libvirt
+
+import (
+	"strings"
+
+	"github.com/godbus/dbus"
+)
+
+// libvirtErrorName is the D-Bus error name libvirt-dbus uses for every
+// failure reported by libvirt itself.
+const libvirtErrorName = "org.libvirt.Error"
+
+// ErrorCode mirrors libvirt's virErrorNumber.
+type ErrorCode int32
+
+const (
+	CodeOK ErrorCode = iota
+	CodeInternalError
+	CodeNoMemory
+	CodeNoSupport
+	CodeUnknownHost
+	CodeNoConnect
+	CodeInvalidConn
+	CodeInvalidDomain
+	CodeInvalidArg
+	CodeOperationFailed
+	CodeGetFailed
+	CodePostFailed
+	CodeHTTPError
+	CodeSexprSerial
+	CodeNoXen
+	CodeXenCall
+	CodeOSType
+	CodeNoKernel
+	CodeNoRoot
+	CodeNoSource
+	CodeNoTarget
+	CodeNoName
+	CodeNoOS
+	CodeNoDevice
+	CodeNoXenstore
+	CodeDriverFull
+	CodeCallFailed
+	CodeXMLError
+	CodeDomExist
+	CodeOperationDenied
+	CodeOpenFailed
+	CodeReadFailed
+	CodeParseFailed
+	CodeConfSyntax
+	CodeWriteFailed
+	CodeXMLDetail
+	CodeInvalidNetwork
+	CodeNetworkExist
+	CodeSystemError
+	CodeRPC
+	CodeGNUTLSError
+	CodeWarnNoNetwork
+	CodeNoDomain
+	CodeNoNetwork
+	CodeInvalidMAC
+	CodeAuthFailed
+	CodeInvalidStoragePool
+	CodeInvalidStorageVol
+	CodeWarnNoStorage
+	CodeNoStoragePool
+	CodeNoStorageVol
+	CodeWarnNoNode
+	CodeInvalidNodeDevice
+	CodeNoNodeDevice
+	CodeNoSecurityModel
+	CodeOperationInvalid
+	CodeWarnNoInterface
+	CodeNoInterface
+	CodeInvalidInterface
+	CodeMultipleInterfaces
+	CodeWarnNoNWFilter
+	CodeInvalidNWFilter
+	CodeNoNWFilter
+	CodeBuildFirewall
+	CodeWarnNoSecret
+	CodeInvalidSecret
+	CodeNoSecret
+	CodeConfigUnsupported
+	CodeOperationTimeout
+	CodeMigratePersistFailed
+	CodeHookScriptFailed
+	CodeInvalidDomainSnapshot
+	CodeNoDomainSnapshot
+	CodeInvalidStream
+	CodeArgumentUnsupported
+	CodeStorageProbeFailed
+	CodeStoragePoolBuilt
+	CodeSnapshotRevertRisky
+	CodeOperationAborted
+	CodeAuthCancelled
+	CodeNoDomainMetadata
+	CodeMigrateUnsafe
+	CodeOverflow
+	CodeBlockCopyActive
+	CodeOperationUnsupported
+	CodeSSH
+	CodeAgentUnresponsive
+	CodeResourceBusy
+	CodeAccessDenied
+	CodeDBusService
+	CodeStorageVolExist
+	CodeCPUIncompatible
+	CodeXMLInvalidSchema
+	CodeMigrateFinishOK
+	CodeAuthUnavailable
+	CodeNoServer
+	CodeNoClient
+	CodeAgentUnsynced
+	CodeLibSSH
+	CodeDeviceMissing
+	CodeInvalidNWFilterBinding
+	CodeNoNWFilterBinding
+	CodeInvalidDomainCheckpoint
+	CodeNoDomainCheckpoint
+	CodeNoDomainBackup
+	CodeInvalidNetworkPort
+	CodeNetworkPortExist
+	CodeNoNetworkPort
+	CodeNoHostname
+)
+
+// ErrorDomain mirrors libvirt's virErrorDomain.
+type ErrorDomain int32
+
+const (
+	FromNone ErrorDomain = iota
+	FromXen
+	FromXend
+	FromXenstore
+	FromSexpr
+	FromXML
+	FromDom
+	FromRPC
+	FromProxy
+	FromConf
+	FromQEMU
+	FromNet
+	FromTest
+	FromRemote
+	FromOpenVZ
+	FromXenXM
+	FromStatsLinux
+	FromLXC
+	FromStorage
+	FromNetwork
+	FromDomain
+	FromUML
+	FromNodeDev
+	FromXenInotify
+	FromSecurity
+	FromVBox
+	FromInterface
+	FromONE
+	FromESX
+	FromPHYP
+	FromSecret
+	FromCPU
+	FromXenAPI
+	FromNWFilter
+	FromHook
+	FromDomainSnapshot
+	FromAudit
+	FromSysinfo
+	FromStreams
+	FromVMware
+	FromEvent
+	FromLibxl
+	FromLocking
+	FromHyperV
+	FromCapabilities
+	FromURI
+	FromAuth
+	FromDBus
+	FromParallels
+	FromDevice
+	FromSSH
+	FromLockspace
+	FromInitctl
+	FromIdentity
+	FromCgroup
+	FromAccess
+	FromSystemd
+	FromBhyve
+	FromCrypto
+	FromFirewall
+	FromPolkit
+	FromThread
+	FromAdmin
+	FromLogging
+	FromXenXL
+	FromPerf
+	FromLibSSH
+	FromResctrl
+	FromFirewalld
+	FromDomainCheckpoint
+	FromTPM
+	FromBPF
+)
+
+// Error is a failure reported by libvirt through libvirt-dbus.
+//
+// libvirt-dbus only forwards the formatted libvirt message, so Code is
+// recovered from the well known message prefixes libvirt uses for each
+// error number and left at CodeOK (and only the message is meaningful)
+// when the message is not recognised. The message does not tell which
+// subsystem raised the error, so Domain is always FromNone.
+type Error struct {
+	Code    ErrorCode
+	Domain  ErrorDomain
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is reports whether target is an *Error with the same code, so the
+// sentinel errors below can be used with errors.Is.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	if t.Code != e.Code {
+		return false
+	}
+	return t.Domain == FromNone || t.Domain == e.Domain
+}
+
+var (
+	ErrInternal              = &Error{Code: CodeInternalError, Message: "internal error"}
+	ErrNoSupport             = &Error{Code: CodeNoSupport, Message: "this function is not supported by the connection driver"}
+	ErrInvalidArg            = &Error{Code: CodeInvalidArg, Message: "invalid argument"}
+	ErrOperationFailed       = &Error{Code: CodeOperationFailed, Message: "operation failed"}
+	ErrOperationDenied       = &Error{Code: CodeOperationDenied, Message: "operation forbidden"}
+	ErrXMLDetail             = &Error{Code: CodeXMLDetail, Message: "XML error"}
+	ErrNoDomain              = &Error{Code: CodeNoDomain, Message: "Domain not found"}
+	ErrNoNetwork             = &Error{Code: CodeNoNetwork, Message: "Network not found"}
+	ErrAuthFailed            = &Error{Code: CodeAuthFailed, Message: "authentication failed"}
+	ErrNoStoragePool         = &Error{Code: CodeNoStoragePool, Message: "Storage pool not found"}
+	ErrNoStorageVol          = &Error{Code: CodeNoStorageVol, Message: "Storage volume not found"}
+	ErrNoNodeDevice          = &Error{Code: CodeNoNodeDevice, Message: "Node device not found"}
+	ErrNoSecurityModel       = &Error{Code: CodeNoSecurityModel, Message: "Security model not found"}
+	ErrOperationInvalid      = &Error{Code: CodeOperationInvalid, Message: "Requested operation is not valid"}
+	ErrNoInterface           = &Error{Code: CodeNoInterface, Message: "Interface not found"}
+	ErrNoNWFilter            = &Error{Code: CodeNoNWFilter, Message: "Network filter not found"}
+	ErrNoSecret              = &Error{Code: CodeNoSecret, Message: "Secret not found"}
+	ErrConfigUnsupported     = &Error{Code: CodeConfigUnsupported, Message: "unsupported configuration"}
+	ErrOperationTimeout      = &Error{Code: CodeOperationTimeout, Message: "Timed out during operation"}
+	ErrNoDomainSnapshot      = &Error{Code: CodeNoDomainSnapshot, Message: "Domain snapshot not found"}
+	ErrArgumentUnsupported   = &Error{Code: CodeArgumentUnsupported, Message: "argument unsupported"}
+	ErrStoragePoolBuilt      = &Error{Code: CodeStoragePoolBuilt, Message: "storage pool already built"}
+	ErrOperationAborted      = &Error{Code: CodeOperationAborted, Message: "operation aborted"}
+	ErrNoDomainMetadata      = &Error{Code: CodeNoDomainMetadata, Message: "metadata not found"}
+	ErrMigrateUnsafe         = &Error{Code: CodeMigrateUnsafe, Message: "Unsafe migration"}
+	ErrBlockCopyActive       = &Error{Code: CodeBlockCopyActive, Message: "block copy still active"}
+	ErrOperationUnsupported  = &Error{Code: CodeOperationUnsupported, Message: "Operation not supported"}
+	ErrAgentUnresponsive     = &Error{Code: CodeAgentUnresponsive, Message: "Guest agent is not responding"}
+	ErrResourceBusy          = &Error{Code: CodeResourceBusy, Message: "resource busy"}
+	ErrAccessDenied          = &Error{Code: CodeAccessDenied, Message: "access denied"}
+	ErrCPUIncompatible       = &Error{Code: CodeCPUIncompatible, Message: "the CPU is incompatible with host CPU"}
+	ErrDeviceMissing         = &Error{Code: CodeDeviceMissing, Message: "device not found"}
+	ErrNoDomainCheckpoint    = &Error{Code: CodeNoDomainCheckpoint, Message: "Domain checkpoint not found"}
+	ErrNoNetworkPort         = &Error{Code: CodeNoNetworkPort, Message: "network port not found"}
+	ErrNetworkPortExist      = &Error{Code: CodeNetworkPortExist, Message: "network port already exists"}
+	ErrNoHostname            = &Error{Code: CodeNoHostname, Message: "no hostname found"}
+	ErrMigratePersistFailed  = &Error{Code: CodeMigratePersistFailed, Message: "Failed to make domain persistent after migration"}
+	ErrSnapshotRevertRisky   = &Error{Code: CodeSnapshotRevertRisky, Message: "revert requires force"}
+	ErrInvalidDomainSnapshot = &Error{Code: CodeInvalidDomainSnapshot, Message: "Invalid snapshot"}
+)
+
+// knownErrors lists the sentinels in the order their messages are matched.
+var knownErrors = []*Error{
+	ErrInternal, ErrNoSupport, ErrInvalidArg, ErrOperationFailed,
+	ErrOperationDenied, ErrXMLDetail, ErrNoDomain, ErrNoNetwork,
+	ErrAuthFailed, ErrNoStoragePool, ErrNoStorageVol, ErrNoNodeDevice,
+	ErrNoSecurityModel, ErrOperationInvalid, ErrNoInterface, ErrNoNWFilter,
+	ErrNoSecret, ErrConfigUnsupported, ErrOperationTimeout,
+	ErrNoDomainSnapshot, ErrArgumentUnsupported, ErrStoragePoolBuilt,
+	ErrOperationAborted, ErrNoDomainMetadata, ErrMigrateUnsafe,
+	ErrBlockCopyActive, ErrOperationUnsupported, ErrAgentUnresponsive,
+	ErrResourceBusy, ErrAccessDenied, ErrCPUIncompatible, ErrDeviceMissing,
+	ErrNoDomainCheckpoint, ErrNoNetworkPort, ErrNetworkPortExist,
+	ErrNoHostname, ErrMigratePersistFailed, ErrSnapshotRevertRisky,
+	ErrInvalidDomainSnapshot,
+}
+
+// parseError decodes a libvirt message into an *Error.
+func parseError(msg string) *Error {
+	for _, known := range knownErrors {
+		if msg == known.Message || strings.HasPrefix(msg, known.Message+": ") {
+			return &Error{Code: known.Code, Message: msg}
+		}
+	}
+	return &Error{Message: msg}
+}
+
+// wrapError turns an org.libvirt.Error reply into an *Error and returns any
+// other error unchanged.
+func wrapError(err error) error {
+	e, ok := err.(dbus.Error)
+	if !ok || e.Name != libvirtErrorName {
+		return err
+	}
+	return parseError(e.Error())
+}
@@ -0,0 +1,39 @@
+package libvirt
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/godbus/dbus"
+)
+
+func TestWrapError(t *testing.T) {
+	tests := []struct {
+		msg    string
+		target error
+		code   ErrorCode
+	}{
+		{"Domain not found: no domain with matching name 'winxp'", ErrNoDomain, CodeNoDomain},
+		{"Requested operation is not valid: domain is not running", ErrOperationInvalid, CodeOperationInvalid},
+		{"Network not found", ErrNoNetwork, CodeNoNetwork},
+		{"something unexpected", nil, CodeOK},
+	}
+	for _, tt := range tests {
+		err := wrapError(dbus.Error{Name: libvirtErrorName, Body: []interface{}{tt.msg}})
+		var lerr *Error
+		if !errors.As(err, &lerr) {
+			t.Fatalf("%q: got %T, want *Error", tt.msg, err)
+		}
+		if lerr.Code != tt.code || lerr.Domain != FromNone || lerr.Message != tt.msg {
+			t.Errorf("%q: got %+v", tt.msg, lerr)
+		}
+		if tt.target != nil && !errors.Is(err, tt.target) {
+			t.Errorf("%q: errors.Is(%v) = false", tt.msg, tt.target)
+		}
+	}
+
+	other := dbus.Error{Name: "org.freedesktop.DBus.Error.UnknownObject"}
+	if err, ok := wrapError(other).(dbus.Error); !ok || err.Name != other.Name {
+		t.Errorf("non libvirt error was rewritten: %v", err)
+	}
+}
@@ -0,0 +1,405 @@
+package libvirt
+
+import (
+	"strconv"
+
+	"github.com/godbus/dbus"
+)
+
+// enumString returns names[v], or a numeric fallback for values newer than
+// this package.
+func enumString(v int32, names []string) string {
+	if v >= 0 && int(v) < len(names) {
+		return names[v]
+	}
+	return "Unknown(" + strconv.Itoa(int(v)) + ")"
+}
+
+// DomainEventType mirrors libvirt's virDomainEventType.
+type DomainEventType int32
+
+const (
+	DomainEventDefined DomainEventType = iota
+	DomainEventUndefined
+	DomainEventStarted
+	DomainEventSuspended
+	DomainEventResumed
+	DomainEventStopped
+	DomainEventShutdown
+	DomainEventPMSuspended
+	DomainEventCrashed
+)
+
+var domainEventTypeNames = []string{"Defined", "Undefined", "Started", "Suspended",
+	"Resumed", "Stopped", "Shutdown", "PMSuspended", "Crashed"}
+
+func (t DomainEventType) String() string {
+	return enumString(int32(t), domainEventTypeNames)
+}
+
+// DomainEventDetail is the detail of a DomainLifecycleEvent. Its dynamic
+// type is the detail enum matching the event type, e.g.
+// DomainEventStartedDetail for DomainEventStarted.
+type DomainEventDetail interface {
+	String() string
+}
+
+// DomainEventDefinedDetail mirrors virDomainEventDefinedDetailType.
+type DomainEventDefinedDetail int32
+
+const (
+	DomainEventDefinedAdded DomainEventDefinedDetail = iota
+	DomainEventDefinedUpdated
+	DomainEventDefinedRenamed
+	DomainEventDefinedFromSnapshot
+)
+
+var domainEventDefinedNames = []string{"Added", "Updated", "Renamed", "FromSnapshot"}
+
+func (d DomainEventDefinedDetail) String() string {
+	return enumString(int32(d), domainEventDefinedNames)
+}
+
+// DomainEventUndefinedDetail mirrors virDomainEventUndefinedDetailType.
+type DomainEventUndefinedDetail int32
+
+const (
+	DomainEventUndefinedRemoved DomainEventUndefinedDetail = iota
+	DomainEventUndefinedRenamed
+)
+
+var domainEventUndefinedNames = []string{"Removed", "Renamed"}
+
+func (d DomainEventUndefinedDetail) String() string {
+	return enumString(int32(d), domainEventUndefinedNames)
+}
+
+// DomainEventStartedDetail mirrors virDomainEventStartedDetailType.
+type DomainEventStartedDetail int32
+
+const (
+	DomainEventStartedBooted DomainEventStartedDetail = iota
+	DomainEventStartedMigrated
+	DomainEventStartedRestored
+	DomainEventStartedFromSnapshot
+	DomainEventStartedWakeup
+)
+
+var domainEventStartedNames = []string{"Booted", "Migrated", "Restored", "FromSnapshot", "Wakeup"}
+
+func (d DomainEventStartedDetail) String() string {
+	return enumString(int32(d), domainEventStartedNames)
+}
+
+// DomainEventSuspendedDetail mirrors virDomainEventSuspendedDetailType.
+type DomainEventSuspendedDetail int32
+
+const (
+	DomainEventSuspendedPaused DomainEventSuspendedDetail = iota
+	DomainEventSuspendedMigrated
+	DomainEventSuspendedIOError
+	DomainEventSuspendedWatchdog
+	DomainEventSuspendedRestored
+	DomainEventSuspendedFromSnapshot
+	DomainEventSuspendedAPIError
+	DomainEventSuspendedPostcopy
+	DomainEventSuspendedPostcopyFailed
+)
+
+var domainEventSuspendedNames = []string{"Paused", "Migrated", "IOError", "Watchdog",
+	"Restored", "FromSnapshot", "APIError", "Postcopy", "PostcopyFailed"}
+
+func (d DomainEventSuspendedDetail) String() string {
+	return enumString(int32(d), domainEventSuspendedNames)
+}
+
+// DomainEventResumedDetail mirrors virDomainEventResumedDetailType.
+type DomainEventResumedDetail int32
+
+const (
+	DomainEventResumedUnpaused DomainEventResumedDetail = iota
+	DomainEventResumedMigrated
+	DomainEventResumedFromSnapshot
+	DomainEventResumedPostcopy
+)
+
+var domainEventResumedNames = []string{"Unpaused", "Migrated", "FromSnapshot", "Postcopy"}
+
+func (d DomainEventResumedDetail) String() string {
+	return enumString(int32(d), domainEventResumedNames)
+}
+
+// DomainEventStoppedDetail mirrors virDomainEventStoppedDetailType.
+type DomainEventStoppedDetail int32
+
+const (
+	DomainEventStoppedShutdown DomainEventStoppedDetail = iota
+	DomainEventStoppedDestroyed
+	DomainEventStoppedCrashed
+	DomainEventStoppedMigrated
+	DomainEventStoppedSaved
+	DomainEventStoppedFailed
+	DomainEventStoppedFromSnapshot
+)
+
+var domainEventStoppedNames = []string{"Shutdown", "Destroyed", "Crashed", "Migrated",
+	"Saved", "Failed", "FromSnapshot"}
+
+func (d DomainEventStoppedDetail) String() string {
+	return enumString(int32(d), domainEventStoppedNames)
+}
+
+// DomainEventShutdownDetail mirrors virDomainEventShutdownDetailType.
+type DomainEventShutdownDetail int32
+
+const (
+	DomainEventShutdownFinished DomainEventShutdownDetail = iota
+	DomainEventShutdownGuest
+	DomainEventShutdownHost
+)
+
+var domainEventShutdownNames = []string{"Finished", "Guest", "Host"}
+
+func (d DomainEventShutdownDetail) String() string {
+	return enumString(int32(d), domainEventShutdownNames)
+}
+
+// DomainEventPMSuspendedDetail mirrors virDomainEventPMSuspendedDetailType.
+type DomainEventPMSuspendedDetail int32
+
+const (
+	DomainEventPMSuspendedMemory DomainEventPMSuspendedDetail = iota
+	DomainEventPMSuspendedDisk
+)
+
+var domainEventPMSuspendedNames = []string{"Memory", "Disk"}
+
+func (d DomainEventPMSuspendedDetail) String() string {
+	return enumString(int32(d), domainEventPMSuspendedNames)
+}
+
+// DomainEventCrashedDetail mirrors virDomainEventCrashedDetailType.
+type DomainEventCrashedDetail int32
+
+const (
+	DomainEventCrashedPanicked DomainEventCrashedDetail = iota
+	DomainEventCrashedCrashloaded
+)
+
+var domainEventCrashedNames = []string{"Panicked", "Crashloaded"}
+
+func (d DomainEventCrashedDetail) String() string {
+	return enumString(int32(d), domainEventCrashedNames)
+}
+
+// unknownEventDetail is the detail of an event type this package does not
+// know about.
+type unknownEventDetail int32
+
+func (d unknownEventDetail) String() string {
+	return enumString(int32(d), nil)
+}
+
+func newDomainEventDetail(event DomainEventType, detail int32) DomainEventDetail {
+	switch event {
+	case DomainEventDefined:
+		return DomainEventDefinedDetail(detail)
+	case DomainEventUndefined:
+		return DomainEventUndefinedDetail(detail)
+	case DomainEventStarted:
+		return DomainEventStartedDetail(detail)
+	case DomainEventSuspended:
+		return DomainEventSuspendedDetail(detail)
+	case DomainEventResumed:
+		return DomainEventResumedDetail(detail)
+	case DomainEventStopped:
+		return DomainEventStoppedDetail(detail)
+	case DomainEventShutdown:
+		return DomainEventShutdownDetail(detail)
+	case DomainEventPMSuspended:
+		return DomainEventPMSuspendedDetail(detail)
+	case DomainEventCrashed:
+		return DomainEventCrashedDetail(detail)
+	}
+	return unknownEventDetail(detail)
+}
+
+// DomainLifecycleEvent is a decoded Connect.DomainEvent signal.
+type DomainLifecycleEvent struct {
+	Domain *Domain
+	Event  DomainEventType
+	Detail DomainEventDetail
+}
+
+func (e DomainLifecycleEvent) String() string {
+	return e.Event.String() + " " + e.Detail.String()
+}
+
+// SubscribeDomainLifecycle is like SubscribeDomainEvent but hands the
+// callback a decoded DomainLifecycleEvent.
+func (m *Connect) SubscribeDomainLifecycle(callback func(DomainLifecycleEvent), opts ...SubscribeOption) (*Subscription, error) {
+	if callback == nil {
+		return nil, errNilCallback
+	}
+	return m.SubscribeDomainEvent(func(domain dbus.ObjectPath, event int32, detail int32) {
+		callback(DomainLifecycleEvent{
+			Domain: NewDomain(m.conn, domain),
+			Event:  DomainEventType(event),
+			Detail: newDomainEventDetail(DomainEventType(event), detail),
+		})
+	}, opts...)
+}
+
+// NetworkEventType mirrors libvirt's virNetworkEventLifecycleType.
+type NetworkEventType int32
+
+const (
+	NetworkEventDefined NetworkEventType = iota
+	NetworkEventUndefined
+	NetworkEventStarted
+	NetworkEventStopped
+)
+
+var networkEventTypeNames = []string{"Defined", "Undefined", "Started", "Stopped"}
+
+func (t NetworkEventType) String() string {
+	return enumString(int32(t), networkEventTypeNames)
+}
+
+// NetworkLifecycleEvent is a decoded Connect.NetworkEvent signal.
+type NetworkLifecycleEvent struct {
+	Network *Network
+	Event   NetworkEventType
+}
+
+// SubscribeNetworkLifecycle is like SubscribeNetworkEvent but hands the
+// callback a decoded NetworkLifecycleEvent.
+func (m *Connect) SubscribeNetworkLifecycle(callback func(NetworkLifecycleEvent), opts ...SubscribeOption) (*Subscription, error) {
+	if callback == nil {
+		return nil, errNilCallback
+	}
+	return m.SubscribeNetworkEvent(func(network dbus.ObjectPath, event int32) {
+		callback(NetworkLifecycleEvent{
+			Network: NewNetwork(m.conn, network),
+			Event:   NetworkEventType(event),
+		})
+	}, opts...)
+}
+
+// StoragePoolEventType mirrors libvirt's virStoragePoolEventLifecycleType.
+type StoragePoolEventType int32
+
+const (
+	StoragePoolEventDefined StoragePoolEventType = iota
+	StoragePoolEventUndefined
+	StoragePoolEventStarted
+	StoragePoolEventStopped
+	StoragePoolEventCreated
+	StoragePoolEventDeleted
+)
+
+var storagePoolEventTypeNames = []string{"Defined", "Undefined", "Started", "Stopped",
+	"Created", "Deleted"}
+
+func (t StoragePoolEventType) String() string {
+	return enumString(int32(t), storagePoolEventTypeNames)
+}
+
+// StoragePoolLifecycleEvent is a decoded Connect.StoragePoolEvent signal.
+// libvirt defines no detail values for storage pool events yet.
+type StoragePoolLifecycleEvent struct {
+	StoragePool *StoragePool
+	Event       StoragePoolEventType
+	Detail      int32
+}
+
+// SubscribeStoragePoolLifecycle is like SubscribeStoragePoolEvent but hands
+// the callback a decoded StoragePoolLifecycleEvent.
+func (m *Connect) SubscribeStoragePoolLifecycle(callback func(StoragePoolLifecycleEvent), opts ...SubscribeOption) (*Subscription, error) {
+	if callback == nil {
+		return nil, errNilCallback
+	}
+	return m.SubscribeStoragePoolEvent(func(storagePool dbus.ObjectPath, event int32, detail int32) {
+		callback(StoragePoolLifecycleEvent{
+			StoragePool: NewStoragePool(m.conn, storagePool),
+			Event:       StoragePoolEventType(event),
+			Detail:      detail,
+		})
+	}, opts...)
+}
+
+// NodeDeviceEventType mirrors libvirt's virNodeDeviceEventLifecycleType.
+type NodeDeviceEventType int32
+
+const (
+	NodeDeviceEventCreated NodeDeviceEventType = iota
+	NodeDeviceEventDeleted
+	NodeDeviceEventDefined
+	NodeDeviceEventUndefined
+)
+
+var nodeDeviceEventTypeNames = []string{"Created", "Deleted", "Defined", "Undefined"}
+
+func (t NodeDeviceEventType) String() string {
+	return enumString(int32(t), nodeDeviceEventTypeNames)
+}
+
+// NodeDeviceLifecycleEvent is a decoded Connect.NodeDeviceEvent signal.
+// libvirt defines no detail values for node device events yet.
+type NodeDeviceLifecycleEvent struct {
+	NodeDevice *NodeDevice
+	Event      NodeDeviceEventType
+	Detail     int32
+}
+
+// SubscribeNodeDeviceLifecycle is like SubscribeNodeDeviceEvent but hands
+// the callback a decoded NodeDeviceLifecycleEvent.
+func (m *Connect) SubscribeNodeDeviceLifecycle(callback func(NodeDeviceLifecycleEvent), opts ...SubscribeOption) (*Subscription, error) {
+	if callback == nil {
+		return nil, errNilCallback
+	}
+	return m.SubscribeNodeDeviceEvent(func(dev dbus.ObjectPath, event int32, detail int32) {
+		callback(NodeDeviceLifecycleEvent{
+			NodeDevice: NewNodeDevice(m.conn, dev),
+			Event:      NodeDeviceEventType(event),
+			Detail:     detail,
+		})
+	}, opts...)
+}
+
+// SecretEventType mirrors libvirt's virSecretEventLifecycleType.
+type SecretEventType int32
+
+const (
+	SecretEventDefined SecretEventType = iota
+	SecretEventUndefined
+)
+
+var secretEventTypeNames = []string{"Defined", "Undefined"}
+
+func (t SecretEventType) String() string {
+	return enumString(int32(t), secretEventTypeNames)
+}
+
+// SecretLifecycleEvent is a decoded Connect.SecretEvent signal. libvirt
+// defines no detail values for secret events yet.
+type SecretLifecycleEvent struct {
+	Secret *Secret
+	Event  SecretEventType
+	Detail int32
+}
+
+// SubscribeSecretLifecycle is like SubscribeSecretEvent but hands the
+// callback a decoded SecretLifecycleEvent.
+func (m *Connect) SubscribeSecretLifecycle(callback func(SecretLifecycleEvent), opts ...SubscribeOption) (*Subscription, error) {
+	if callback == nil {
+		return nil, errNilCallback
+	}
+	return m.SubscribeSecretEvent(func(secret dbus.ObjectPath, event int32, detail int32) {
+		callback(SecretLifecycleEvent{
+			Secret: NewSecret(m.conn, secret),
+			Event:  SecretEventType(event),
+			Detail: detail,
+		})
+	}, opts...)
+}
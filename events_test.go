@@ -0,0 +1,17 @@
+package libvirt
+
+import "testing"
+
+func TestDomainEventDetail(t *testing.T) {
+	d := newDomainEventDetail(DomainEventStopped, int32(DomainEventStoppedCrashed))
+	if d != DomainEventStoppedCrashed {
+		t.Fatalf("got %#v, want DomainEventStoppedCrashed", d)
+	}
+	e := DomainLifecycleEvent{Event: DomainEventStopped, Detail: d}
+	if s := e.String(); s != "Stopped Crashed" {
+		t.Fatalf("got %q", s)
+	}
+	if s := newDomainEventDetail(DomainEventType(42), 3).String(); s != "Unknown(3)" {
+		t.Fatalf("got %q", s)
+	}
+}
@@ -0,0 +1,336 @@
+// Package exporter exposes libvirt metrics in the Prometheus text format.
+//
+// On every scrape an Exporter reads the statistics of all domains, the
+// CPU and memory counters of the host, the size of the storage pools and
+// the DHCP leases of the active networks. Domain metrics are labelled with
+// the domain name and UUID. Domain lifecycle events are counted from the
+// moment the Exporter is created; those lost in a burst too large to queue
+// are counted by libvirt_exporter_events_dropped_total.
+package exporter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	libvirt "sdstack.com/sdstack/go-libvirt"
+)
+
+const namespace = "libvirt_"
+
+// eventQueueDepth is how many lifecycle events may wait to be counted
+// before they are dropped.
+const eventQueueDepth = 4096
+
+// Exporter is an http.Handler serving the metrics of a libvirt connection.
+type Exporter struct {
+	src source
+	sub subscription
+
+	mu sync.Mutex
+	// uuids maps the names of the domains in the last scrape to their
+	// UUIDs. It is replaced, never changed, and epoch counts the times it
+	// was dropped.
+	uuids  map[string]string
+	epoch  uint64
+	events map[[2]string]uint64
+	// dropped counts the events that were lost before being counted.
+	dropped uint64
+}
+
+// New returns an Exporter for conn and starts counting its domain
+// lifecycle events.
+func New(conn *libvirt.Connect) (*Exporter, error) {
+	return newExporter(connSource{conn})
+}
+
+func newExporter(src source) (*Exporter, error) {
+	e := &Exporter{
+		src:    src,
+		uuids:  make(map[string]string),
+		events: make(map[[2]string]uint64),
+	}
+	sub, err := src.SubscribeDomainLifecycle(e.countEvent,
+		libvirt.WithQueueDepth(eventQueueDepth), libvirt.WithOverflowPolicy(libvirt.OverflowReport))
+	if err != nil {
+		return nil, err
+	}
+	e.sub = sub
+	go e.countDropped()
+	return e, nil
+}
+
+// Close stops counting lifecycle events.
+func (e *Exporter) Close() error {
+	return e.sub.Close()
+}
+
+func (e *Exporter) countEvent(ev libvirt.DomainLifecycleEvent) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.events[[2]string{strings.ToLower(ev.Event.String()), strings.ToLower(ev.Detail.String())}]++
+	// A name may now belong to a domain with another UUID: persistent
+	// domains are defined and undefined, transient ones only started and
+	// stopped.
+	switch ev.Event {
+	case libvirt.DomainEventDefined, libvirt.DomainEventUndefined,
+		libvirt.DomainEventStarted, libvirt.DomainEventStopped:
+		e.uuids = make(map[string]string)
+		e.epoch++
+	}
+}
+
+// countDropped counts the events the subscription reports it could not
+// hand to countEvent, until it stops.
+func (e *Exporter) countDropped() {
+	for {
+		select {
+		case <-e.sub.Errors():
+			e.mu.Lock()
+			e.dropped++
+			e.mu.Unlock()
+		case <-e.sub.Done():
+			return
+		}
+	}
+}
+
+// ServeHTTP collects the metrics and writes them in the text format.
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m := e.collect(r.Context())
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.writeTo(w)
+}
+
+// collect reads the current metrics. A collector that fails is left out
+// and reported by libvirt_exporter_collector_success.
+func (e *Exporter) collect(ctx context.Context) *metrics {
+	m := newMetrics()
+	for _, c := range []struct {
+		name    string
+		collect func(context.Context, *metrics) error
+	}{
+		{"domain", e.collectDomains},
+		{"node", e.collectNode},
+		{"storage_pool", e.collectStoragePools},
+		{"network", e.collectNetworks},
+	} {
+		ok := 1.0
+		if err := c.collect(ctx, m); err != nil {
+			ok = 0
+		}
+		m.gauge(namespace+"exporter_collector_success", "Whether a collector succeeded.", ok,
+			"collector", c.name)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	keys := make([][2]string, 0, len(e.events))
+	for k := range e.events {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i][0] < keys[j][0] || keys[i][0] == keys[j][0] && keys[i][1] < keys[j][1]
+	})
+	for _, k := range keys {
+		m.counter(namespace+"domain_lifecycle_events_total", "Domain lifecycle events seen.", float64(e.events[k]),
+			"event", k[0], "detail", k[1])
+	}
+	m.counter(namespace+"exporter_events_dropped_total", "Domain lifecycle events lost before they were counted.", float64(e.dropped))
+	return m
+}
+
+func (e *Exporter) collectDomains(ctx context.Context, m *metrics) error {
+	stats, err := e.src.AllDomainStatsContext(ctx, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	known, epoch := e.uuids, e.epoch
+	e.mu.Unlock()
+	// Only the domains of this scrape are remembered, so the names of
+	// domains that are gone are forgotten.
+	uuids := make(map[string]string, len(stats))
+	for _, s := range stats {
+		uuid, ok := known[s.Domain]
+		if !ok {
+			uuid = e.domainUUID(ctx, s.Domain)
+		}
+		if uuid != "" {
+			uuids[s.Domain] = uuid
+		}
+		writeDomainStats(m, s, uuid)
+	}
+	e.mu.Lock()
+	if e.epoch == epoch {
+		e.uuids = uuids
+	}
+	e.mu.Unlock()
+	return nil
+}
+
+// domainUUID returns the UUID of the domain called name, or "" if it
+// cannot be found out.
+func (e *Exporter) domainUUID(ctx context.Context, name string) string {
+	uuid, err := e.src.DomainUUID(ctx, name)
+	if err != nil {
+		return ""
+	}
+	return uuid
+}
+
+func writeDomainStats(m *metrics, s *libvirt.DomainStats, uuid string) {
+	dl := []string{"domain", s.Domain, "uuid", uuid}
+	with := func(labels ...string) []string {
+		return append(append([]string(nil), dl...), labels...)
+	}
+
+	if s.State != nil {
+		m.gauge(namespace+"domain_state", "Domain state as a virDomainState value.", float64(s.State.State), dl...)
+	}
+	if s.CPU != nil {
+		m.counter(namespace+"domain_cpu_time_seconds_total", "CPU time used by the domain.", nsec(s.CPU.Time), dl...)
+	}
+	if s.Balloon != nil {
+		m.gauge(namespace+"domain_memory_current_bytes", "Memory currently assigned to the domain.", kib(s.Balloon.Current), dl...)
+		m.gauge(namespace+"domain_memory_maximum_bytes", "Maximum memory of the domain.", kib(s.Balloon.Maximum), dl...)
+		m.gauge(namespace+"domain_memory_unused_bytes", "Memory left unused by the guest.", kib(s.Balloon.Unused), dl...)
+		m.gauge(namespace+"domain_memory_usable_bytes", "Memory the guest can use without swapping.", kib(s.Balloon.Usable), dl...)
+		m.gauge(namespace+"domain_memory_rss_bytes", "Resident set size of the domain process.", kib(s.Balloon.RSS), dl...)
+	}
+	if s.VCPUMaximum > 0 {
+		m.gauge(namespace+"domain_vcpus", "Virtual CPUs currently online.", float64(s.VCPUCurrent), dl...)
+		m.gauge(namespace+"domain_vcpus_maximum", "Maximum number of virtual CPUs.", float64(s.VCPUMaximum), dl...)
+	}
+	for _, v := range s.VCPU {
+		m.counter(namespace+"domain_vcpu_time_seconds_total", "CPU time used by a virtual CPU.", nsec(v.Time),
+			with("vcpu", strconv.FormatUint(uint64(v.Number), 10))...)
+	}
+	for _, b := range s.Block {
+		l := with("device", b.Name)
+		m.counter(namespace+"domain_block_read_bytes_total", "Bytes read from a disk.", float64(b.RdBytes), l...)
+		m.counter(namespace+"domain_block_write_bytes_total", "Bytes written to a disk.", float64(b.WrBytes), l...)
+		m.counter(namespace+"domain_block_read_requests_total", "Read requests to a disk.", float64(b.RdReqs), l...)
+		m.counter(namespace+"domain_block_write_requests_total", "Write requests to a disk.", float64(b.WrReqs), l...)
+		m.counter(namespace+"domain_block_flush_requests_total", "Flush requests to a disk.", float64(b.FlReqs), l...)
+		m.counter(namespace+"domain_block_read_time_seconds_total", "Time spent reading from a disk.", nsec(b.RdTimes), l...)
+		m.counter(namespace+"domain_block_write_time_seconds_total", "Time spent writing to a disk.", nsec(b.WrTimes), l...)
+		m.gauge(namespace+"domain_block_capacity_bytes", "Logical size of a disk.", float64(b.Capacity), l...)
+		m.gauge(namespace+"domain_block_allocation_bytes", "Host storage in use by a disk.", float64(b.Allocation), l...)
+	}
+	for _, n := range s.Net {
+		l := with("device", n.Name)
+		m.counter(namespace+"domain_interface_receive_bytes_total", "Bytes received on an interface.", float64(n.RxBytes), l...)
+		m.counter(namespace+"domain_interface_transmit_bytes_total", "Bytes sent on an interface.", float64(n.TxBytes), l...)
+		m.counter(namespace+"domain_interface_receive_packets_total", "Packets received on an interface.", float64(n.RxPkts), l...)
+		m.counter(namespace+"domain_interface_transmit_packets_total", "Packets sent on an interface.", float64(n.TxPkts), l...)
+		m.counter(namespace+"domain_interface_receive_errors_total", "Receive errors on an interface.", float64(n.RxErrs), l...)
+		m.counter(namespace+"domain_interface_transmit_errors_total", "Transmit errors on an interface.", float64(n.TxErrs), l...)
+		m.counter(namespace+"domain_interface_receive_drops_total", "Received packets dropped on an interface.", float64(n.RxDrop), l...)
+		m.counter(namespace+"domain_interface_transmit_drops_total", "Sent packets dropped on an interface.", float64(n.TxDrop), l...)
+	}
+}
+
+func (e *Exporter) collectNode(ctx context.Context, m *metrics) error {
+	cpu, err := e.src.NodeGetCPUStatsContext(ctx, libvirt.NodeCPUStatsAllCPUs, 0)
+	if err != nil {
+		return err
+	}
+	mem, err := e.src.NodeGetMemoryStatsContext(ctx, libvirt.NodeMemoryStatsAllCells, 0)
+	if err != nil {
+		return err
+	}
+	for _, mode := range []string{"user", "kernel", "idle", "iowait"} {
+		if v, ok := cpu[mode]; ok {
+			m.counter(namespace+"node_cpu_seconds_total", "CPU time of the host by mode.", nsec(v), "mode", mode)
+		}
+	}
+	for _, kind := range []string{"total", "free", "buffers", "cached"} {
+		if v, ok := mem[kind]; ok {
+			m.gauge(namespace+"node_memory_"+kind+"_bytes", "Host memory "+kind+".", kib(v))
+		}
+	}
+	return nil
+}
+
+// collectStoragePools writes the size of every storage pool. A pool that
+// is removed while it is read is left out.
+func (e *Exporter) collectStoragePools(ctx context.Context, m *metrics) error {
+	pools, err := e.src.StoragePools(ctx)
+	if err != nil {
+		return err
+	}
+	for _, p := range pools {
+		if err := writeStoragePool(ctx, m, p); err != nil && !errors.Is(err, libvirt.ErrNoStoragePool) {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeStoragePool(ctx context.Context, m *metrics, p storagePool) error {
+	name, err := p.GetNameContext(ctx)
+	if err != nil {
+		return err
+	}
+	uuid, err := p.GetUUIDContext(ctx)
+	if err != nil {
+		return err
+	}
+	info, err := p.GetInfoContext(ctx)
+	if err != nil {
+		return err
+	}
+	l := []string{"pool", name, "uuid", uuid}
+	m.gauge(namespace+"storage_pool_state", "Storage pool state as a virStoragePoolState value.", float64(info.State), l...)
+	m.gauge(namespace+"storage_pool_capacity_bytes", "Size of a storage pool.", float64(info.Capacity), l...)
+	m.gauge(namespace+"storage_pool_allocation_bytes", "Space allocated in a storage pool.", float64(info.Allocation), l...)
+	m.gauge(namespace+"storage_pool_available_bytes", "Free space in a storage pool.", float64(info.Available), l...)
+	return nil
+}
+
+// collectNetworks writes the number of DHCP leases of every active
+// network. A network that is removed while it is read is left out.
+func (e *Exporter) collectNetworks(ctx context.Context, m *metrics) error {
+	networks, err := e.src.ActiveNetworks(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range networks {
+		if err := writeNetwork(ctx, m, n); err != nil && !errors.Is(err, libvirt.ErrNoNetwork) {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeNetwork(ctx context.Context, m *metrics, n network) error {
+	name, err := n.GetNameContext(ctx)
+	if err != nil {
+		return err
+	}
+	uuid, err := n.GetUUIDContext(ctx)
+	if err != nil {
+		return err
+	}
+	leases, err := n.GetDHCPLeasesContext(ctx, "", 0)
+	if err != nil {
+		return err
+	}
+	m.gauge(namespace+"network_dhcp_leases", "DHCP leases handed out on a network.", float64(len(leases)),
+		"network", name, "uuid", uuid)
+	return nil
+}
+
+func nsec(v uint64) float64 {
+	return float64(v) / 1e9
+}
+
+func kib(v uint64) float64 {
+	return float64(v) * 1024
+}
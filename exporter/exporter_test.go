@@ -0,0 +1,319 @@
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	libvirt "sdstack.com/sdstack/go-libvirt"
+)
+
+func TestWriteDomainStats(t *testing.T) {
+	m := newMetrics()
+	writeDomainStats(m, &libvirt.DomainStats{
+		Domain:  `web "1"`,
+		State:   &libvirt.DomainState{State: 1, Reason: 1},
+		CPU:     &libvirt.DomainCPUStats{Time: 2500000000},
+		Balloon: &libvirt.DomainBalloonStats{Current: 1048576},
+		VCPU:    []libvirt.DomainVCPUStats{{Number: 0, Time: 1e9}, {Number: 2, Time: 3e9}},
+		Block:   []libvirt.DomainBlockStats{{Name: "vda", RdBytes: 4096}},
+		Net:     []libvirt.DomainNetStats{{Name: "vnet0", TxPkts: 7}},
+	}, "6695eb01-f6a4-8304-79aa-97f2502e193f")
+	m.counter(namespace+"domain_lifecycle_events_total", "Domain lifecycle\nevents.", 3,
+		"event", "stopped", "detail", "destroyed")
+
+	var buf bytes.Buffer
+	if err := m.writeTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	labels := `{domain="web \"1\"",uuid="6695eb01-f6a4-8304-79aa-97f2502e193f"`
+	for _, want := range []string{
+		"# TYPE libvirt_domain_state gauge\nlibvirt_domain_state" + labels + "} 1\n",
+		"# TYPE libvirt_domain_cpu_time_seconds_total counter\n",
+		"libvirt_domain_cpu_time_seconds_total" + labels + "} 2.5\n",
+		"libvirt_domain_memory_current_bytes" + labels + "} 1073741824\n",
+		"libvirt_domain_vcpu_time_seconds_total" + labels + `,vcpu="2"} 3` + "\n",
+		"libvirt_domain_block_read_bytes_total" + labels + `,device="vda"} 4096` + "\n",
+		"libvirt_domain_interface_transmit_packets_total" + labels + `,device="vnet0"} 7` + "\n",
+		"# HELP libvirt_domain_lifecycle_events_total Domain lifecycle\\nevents.\n",
+		`libvirt_domain_lifecycle_events_total{event="stopped",detail="destroyed"} 3` + "\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("%q missing from\n%s", want, out)
+		}
+	}
+	if n := strings.Count(out, "# TYPE libvirt_domain_memory_current_bytes "); n != 1 {
+		t.Errorf("got %d TYPE lines for one metric", n)
+	}
+}
+
+// fakeSource is a source with one running domain, two storage pools and
+// two networks, one of each vanishing while it is read.
+type fakeSource struct {
+	mu       sync.Mutex
+	names    []string // of the domains AllDomainStatsContext returns
+	lookups  int
+	uuids    map[string]string
+	pools    []storagePool
+	networks []network
+	callback func(libvirt.DomainLifecycleEvent)
+	errs     chan error
+	done     chan struct{}
+}
+
+func newFakeSource() *fakeSource {
+	return &fakeSource{
+		names: []string{"web"},
+		uuids: map[string]string{"web": "6695eb01-f6a4-8304-79aa-97f2502e193f"},
+		pools: []storagePool{
+			&fakePool{name: "default", uuid: "dfe224cb-28fb-8dd0-c4b2-64eb3f0f4566",
+				info: libvirt.StoragePoolInfo{State: 2, Capacity: 100 << 30, Allocation: 10 << 30, Available: 90 << 30}},
+			&fakePool{name: "gone", err: &libvirt.Error{Code: libvirt.CodeNoStoragePool, Domain: libvirt.FromStorage,
+				Message: "Storage pool not found: no storage pool with matching uuid"}},
+		},
+		networks: []network{
+			&fakeNetwork{name: "default", uuid: "004b96e1-2d78-c30f-5aa5-f03c87d21e69", leases: 2},
+			&fakeNetwork{name: "gone", err: &libvirt.Error{Code: libvirt.CodeNoNetwork, Domain: libvirt.FromNetwork,
+				Message: "Network not found: no network with matching uuid"}},
+		},
+	}
+}
+
+func (f *fakeSource) AllDomainStatsContext(ctx context.Context, stats libvirt.DomainStatsTypes, flags libvirt.ConnectGetAllDomainStatsFlags) ([]*libvirt.DomainStats, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var s []*libvirt.DomainStats
+	for _, name := range f.names {
+		s = append(s, &libvirt.DomainStats{
+			Domain: name,
+			State:  &libvirt.DomainState{State: 1, Reason: 1},
+			VCPU:   []libvirt.DomainVCPUStats{{Number: 1, Time: 5e8}},
+		})
+	}
+	return s, nil
+}
+
+func (f *fakeSource) NodeGetCPUStatsContext(ctx context.Context, cpuNum int32, flags uint32) (map[string]uint64, error) {
+	return map[string]uint64{"user": 2e9, "kernel": 1e9, "idle": 7e9}, nil
+}
+
+func (f *fakeSource) NodeGetMemoryStatsContext(ctx context.Context, cellNum int32, flags uint32) (map[string]uint64, error) {
+	return map[string]uint64{"total": 8 << 20, "free": 4 << 20}, nil
+}
+
+func (f *fakeSource) DomainUUID(ctx context.Context, name string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.lookups++
+	uuid, ok := f.uuids[name]
+	if !ok {
+		return "", libvirt.ErrNoDomain
+	}
+	return uuid, nil
+}
+
+func (f *fakeSource) StoragePools(ctx context.Context) ([]storagePool, error) {
+	return f.pools, nil
+}
+
+func (f *fakeSource) ActiveNetworks(ctx context.Context) ([]network, error) {
+	return f.networks, nil
+}
+
+func (f *fakeSource) SubscribeDomainLifecycle(callback func(libvirt.DomainLifecycleEvent), opts ...libvirt.SubscribeOption) (subscription, error) {
+	f.callback = callback
+	f.errs = make(chan error)
+	f.done = make(chan struct{})
+	return f, nil
+}
+
+func (f *fakeSource) Close() error {
+	close(f.done)
+	return nil
+}
+
+func (f *fakeSource) Errors() <-chan error  { return f.errs }
+func (f *fakeSource) Done() <-chan struct{} { return f.done }
+
+type fakePool struct {
+	name, uuid string
+	info       libvirt.StoragePoolInfo
+	err        error // returned by GetInfoContext
+}
+
+func (p *fakePool) GetNameContext(ctx context.Context) (string, error) { return p.name, nil }
+func (p *fakePool) GetUUIDContext(ctx context.Context) (string, error) { return p.uuid, nil }
+
+func (p *fakePool) GetInfoContext(ctx context.Context) (libvirt.StoragePoolInfo, error) {
+	return p.info, p.err
+}
+
+type fakeNetwork struct {
+	name, uuid string
+	leases     int
+	err        error // returned by GetDHCPLeasesContext
+}
+
+func (n *fakeNetwork) GetNameContext(ctx context.Context) (string, error) { return n.name, nil }
+func (n *fakeNetwork) GetUUIDContext(ctx context.Context) (string, error) { return n.uuid, nil }
+
+func (n *fakeNetwork) GetDHCPLeasesContext(ctx context.Context, mac string, flags uint32) ([]libvirt.NetworkDHCPLease, error) {
+	return make([]libvirt.NetworkDHCPLease, n.leases), n.err
+}
+
+func scrape(t *testing.T, e *Exporter) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); ct != "text/plain; version=0.0.4; charset=utf-8" {
+		t.Errorf("got Content-Type %q", ct)
+	}
+	return rec.Body.String()
+}
+
+func TestServeHTTP(t *testing.T) {
+	src := newFakeSource()
+	e, err := newExporter(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := scrape(t, e)
+	for _, want := range []string{
+		`libvirt_domain_state{domain="web",uuid="6695eb01-f6a4-8304-79aa-97f2502e193f"} 1` + "\n",
+		`libvirt_domain_vcpu_time_seconds_total{domain="web",uuid="6695eb01-f6a4-8304-79aa-97f2502e193f",vcpu="1"} 0.5` + "\n",
+		`libvirt_node_cpu_seconds_total{mode="kernel"} 1` + "\n",
+		`libvirt_node_memory_free_bytes 4294967296` + "\n",
+		`libvirt_storage_pool_capacity_bytes{pool="default",uuid="dfe224cb-28fb-8dd0-c4b2-64eb3f0f4566"} 107374182400` + "\n",
+		`libvirt_network_dhcp_leases{network="default",uuid="004b96e1-2d78-c30f-5aa5-f03c87d21e69"} 2` + "\n",
+		`libvirt_exporter_collector_success{collector="domain"} 1` + "\n",
+		`libvirt_exporter_collector_success{collector="node"} 1` + "\n",
+		`libvirt_exporter_collector_success{collector="storage_pool"} 1` + "\n",
+		`libvirt_exporter_collector_success{collector="network"} 1` + "\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("%q missing from\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, `"gone"`) {
+		t.Errorf("removed pool or network in\n%s", out)
+	}
+
+	if err := e.Close(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-src.done:
+	default:
+		t.Error("Close did not close the subscription")
+	}
+}
+
+func TestCollectorFailure(t *testing.T) {
+	src := newFakeSource()
+	src.pools[1].(*fakePool).err = errors.New("connection reset")
+	src.networks[1].(*fakeNetwork).err = libvirt.ErrOperationInvalid
+	e, err := newExporter(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := e.collect(context.Background())
+	var buf bytes.Buffer
+	if err := out.writeTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`libvirt_exporter_collector_success{collector="domain"} 1` + "\n",
+		`libvirt_exporter_collector_success{collector="storage_pool"} 0` + "\n",
+		`libvirt_exporter_collector_success{collector="network"} 0` + "\n",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("%q missing from\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestCountEvent(t *testing.T) {
+	src := newFakeSource()
+	e, err := newExporter(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src.callback(libvirt.DomainLifecycleEvent{Event: libvirt.DomainEventSuspended, Detail: libvirt.DomainEventSuspendedPaused})
+	src.callback(libvirt.DomainLifecycleEvent{Event: libvirt.DomainEventSuspended, Detail: libvirt.DomainEventSuspendedPaused})
+	src.errs <- &libvirt.SignalError{Err: libvirt.ErrQueueFull}
+	var out string
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		if out = scrape(t, e); !strings.Contains(out, "libvirt_exporter_events_dropped_total 0\n") {
+			break
+		}
+	}
+	for _, want := range []string{
+		`libvirt_domain_lifecycle_events_total{event="suspended",detail="paused"} 2` + "\n",
+		"libvirt_exporter_events_dropped_total 1\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("%q missing from\n%s", want, out)
+		}
+	}
+}
+
+func TestDomainUUIDs(t *testing.T) {
+	src := newFakeSource()
+	e, err := newExporter(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lookups := func() int {
+		src.mu.Lock()
+		defer src.mu.Unlock()
+		return src.lookups
+	}
+
+	scrape(t, e)
+	src.callback(libvirt.DomainLifecycleEvent{Event: libvirt.DomainEventSuspended, Detail: libvirt.DomainEventSuspendedPaused})
+	scrape(t, e)
+	if n := lookups(); n != 1 {
+		t.Fatalf("looked up the UUID %d times, want 1", n)
+	}
+
+	for _, ev := range []libvirt.DomainLifecycleEvent{
+		{Event: libvirt.DomainEventUndefined, Detail: libvirt.DomainEventUndefinedRemoved},
+		{Event: libvirt.DomainEventDefined, Detail: libvirt.DomainEventDefinedAdded},
+		// A transient domain is destroyed and created again.
+		{Event: libvirt.DomainEventStopped, Detail: libvirt.DomainEventStoppedDestroyed},
+		{Event: libvirt.DomainEventStarted, Detail: libvirt.DomainEventStartedBooted},
+	} {
+		n := lookups()
+		uuid := fmt.Sprintf("a2e23ad5-fd5c-4ba6-8ed0-a4ed7a3c9d%02d", n)
+		src.mu.Lock()
+		src.uuids["web"] = uuid
+		src.mu.Unlock()
+		src.callback(ev)
+		out := scrape(t, e)
+		if lookups() != n+1 {
+			t.Errorf("%v: UUID not looked up again", ev)
+		}
+		if want := `libvirt_domain_state{domain="web",uuid="` + uuid + `"} 1`; !strings.Contains(out, want) {
+			t.Errorf("%v: %q missing from\n%s", ev, want, out)
+		}
+	}
+
+	// Domains that are gone are forgotten.
+	src.mu.Lock()
+	src.names = []string{"db"}
+	src.uuids["db"] = "9d0e4b5e-1c6f-4f0a-8a3c-2f3c1f1c8e11"
+	src.mu.Unlock()
+	scrape(t, e)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if _, ok := e.uuids["web"]; ok || len(e.uuids) != 1 {
+		t.Errorf("remembered %v after web went away", e.uuids)
+	}
+}
@@ -0,0 +1,105 @@
+package exporter
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// metricFamily is a metric and its samples in the Prometheus text format.
+type metricFamily struct {
+	name    string
+	help    string
+	typ     string
+	samples []sample
+}
+
+type sample struct {
+	labels []string // name, value, name, value, ...
+	value  float64
+}
+
+// metrics collects the samples of one scrape, grouped by metric in the
+// order the metrics are first seen.
+type metrics struct {
+	families []*metricFamily
+	byName   map[string]*metricFamily
+}
+
+func newMetrics() *metrics {
+	return &metrics{byName: make(map[string]*metricFamily)}
+}
+
+func (m *metrics) add(name, typ, help string, value float64, labels ...string) {
+	f, ok := m.byName[name]
+	if !ok {
+		f = &metricFamily{name: name, help: help, typ: typ}
+		m.byName[name] = f
+		m.families = append(m.families, f)
+	}
+	f.samples = append(f.samples, sample{labels, value})
+}
+
+func (m *metrics) gauge(name, help string, value float64, labels ...string) {
+	m.add(name, "gauge", help, value, labels...)
+}
+
+func (m *metrics) counter(name, help string, value float64, labels ...string) {
+	m.add(name, "counter", help, value, labels...)
+}
+
+// writeTo writes the metrics to w in the Prometheus text format, version
+// 0.0.4.
+func (m *metrics) writeTo(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	for _, f := range m.families {
+		bw.WriteString("# HELP " + f.name + " " + escapeHelp(f.help) + "\n")
+		bw.WriteString("# TYPE " + f.name + " " + f.typ + "\n")
+		for _, s := range f.samples {
+			bw.WriteString(f.name)
+			for i := 0; i+1 < len(s.labels); i += 2 {
+				if i == 0 {
+					bw.WriteByte('{')
+				} else {
+					bw.WriteByte(',')
+				}
+				bw.WriteString(s.labels[i] + `="` + escapeLabel(s.labels[i+1]) + `"`)
+			}
+			if len(s.labels) > 1 {
+				bw.WriteByte('}')
+			}
+			bw.WriteString(" " + formatValue(s.value) + "\n")
+		}
+	}
+	return bw.Flush()
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
+
+func escapeLabel(s string) string {
+	return labelEscaper.Replace(s)
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	case v == math.Trunc(v) && math.Abs(v) < 1e21:
+		// Keep byte and packet counts readable.
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,85 @@
+package exporter
+
+import (
+	"context"
+
+	libvirt "sdstack.com/sdstack/go-libvirt"
+)
+
+// source is the part of libvirt an Exporter reads. connSource reads it
+// from a *libvirt.Connect.
+type source interface {
+	AllDomainStatsContext(ctx context.Context, stats libvirt.DomainStatsTypes, flags libvirt.ConnectGetAllDomainStatsFlags) ([]*libvirt.DomainStats, error)
+	NodeGetCPUStatsContext(ctx context.Context, cpuNum int32, flags uint32) (map[string]uint64, error)
+	NodeGetMemoryStatsContext(ctx context.Context, cellNum int32, flags uint32) (map[string]uint64, error)
+	// DomainUUID returns the UUID of the domain called name.
+	DomainUUID(ctx context.Context, name string) (string, error)
+	StoragePools(ctx context.Context) ([]storagePool, error)
+	ActiveNetworks(ctx context.Context) ([]network, error)
+	SubscribeDomainLifecycle(callback func(libvirt.DomainLifecycleEvent), opts ...libvirt.SubscribeOption) (subscription, error)
+}
+
+// subscription is the part of *libvirt.Subscription an Exporter uses.
+type subscription interface {
+	Close() error
+	Errors() <-chan error
+	Done() <-chan struct{}
+}
+
+// storagePool is the part of *libvirt.StoragePool an Exporter reads.
+type storagePool interface {
+	GetNameContext(ctx context.Context) (string, error)
+	GetUUIDContext(ctx context.Context) (string, error)
+	GetInfoContext(ctx context.Context) (libvirt.StoragePoolInfo, error)
+}
+
+// network is the part of *libvirt.Network an Exporter reads.
+type network interface {
+	GetNameContext(ctx context.Context) (string, error)
+	GetUUIDContext(ctx context.Context) (string, error)
+	GetDHCPLeasesContext(ctx context.Context, mac string, flags uint32) ([]libvirt.NetworkDHCPLease, error)
+}
+
+type connSource struct {
+	*libvirt.Connect
+}
+
+func (c connSource) DomainUUID(ctx context.Context, name string) (string, error) {
+	d, err := c.DomainLookupByNameObjectContext(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	return d.GetUUIDContext(ctx)
+}
+
+func (c connSource) StoragePools(ctx context.Context) ([]storagePool, error) {
+	pools, err := c.ListStoragePoolsObjectsContext(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	s := make([]storagePool, len(pools))
+	for i, p := range pools {
+		s[i] = p
+	}
+	return s, nil
+}
+
+func (c connSource) ActiveNetworks(ctx context.Context) ([]network, error) {
+	networks, err := c.ListNetworksObjectsContext(ctx, libvirt.ConnectListNetworksActive)
+	if err != nil {
+		return nil, err
+	}
+	s := make([]network, len(networks))
+	for i, n := range networks {
+		s[i] = n
+	}
+	return s, nil
+}
+
+func (c connSource) SubscribeDomainLifecycle(callback func(libvirt.DomainLifecycleEvent), opts ...libvirt.SubscribeOption) (subscription, error) {
+	sub, err := c.Connect.SubscribeDomainLifecycle(callback, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
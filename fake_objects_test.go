@@ -0,0 +1,702 @@
+package libvirt
+
+import (
+	"encoding/xml"
+	"strings"
+
+	"github.com/godbus/dbus"
+	"sdstack.com/sdstack/go-libvirt/libvirtxml"
+)
+
+// This file holds the objects of fakeLibvirt besides domains and networks:
+// snapshots, storage pools and volumes, secrets, host devices and the
+// host statistics.
+
+// virStoragePoolState values.
+const (
+	fakePoolInactive = 0
+	fakePoolRunning  = 2
+)
+
+type fakeSnapshot struct {
+	path   dbus.ObjectPath
+	name   string
+	parent *fakeSnapshot
+	doc    string
+}
+
+type fakePool struct {
+	path       dbus.ObjectPath
+	def        *libvirtxml.StoragePool
+	active     bool
+	persistent bool
+	autostart  bool
+	vols       []*fakeVol
+}
+
+type fakeVol struct {
+	path dbus.ObjectPath
+	def  *libvirtxml.StorageVolume
+	pool *fakePool
+}
+
+type fakeSecret struct {
+	path  dbus.ObjectPath
+	def   *libvirtxml.Secret
+	value []byte
+}
+
+type fakeNodeDevice struct {
+	path dbus.ObjectPath
+	def  *libvirtxml.NodeDevice
+}
+
+// fakeSnapshotXML picks the name out of a snapshot document, which
+// libvirtxml has no type for.
+type fakeSnapshotXML struct {
+	XMLName xml.Name `xml:"domainsnapshot"`
+	Name    string   `xml:"name"`
+}
+
+// addDefaults adds the storage pool and host devices the fake starts
+// out with.
+func (f *fakeLibvirt) addDefaults() error {
+	capacity := &libvirtxml.StorageSize{Value: 107374182400}
+	p, err := f.addPool(&libvirtxml.StoragePool{Type: "dir", Name: "default-pool", UUID: fakePoolUUID,
+		Capacity: capacity, Available: capacity, Allocation: &libvirtxml.StorageSize{},
+		Target: &libvirtxml.StoragePoolTarget{Path: "/default-pool"}})
+	if err != nil {
+		return err
+	}
+	p.active = true
+
+	host := uint(1)
+	for _, def := range []*libvirtxml.NodeDevice{
+		{Name: "computer", Capabilities: []libvirtxml.NodeDeviceCapability{{Type: "system"}}},
+		{Name: "scsi_host1", Parent: "computer",
+			Capabilities: []libvirtxml.NodeDeviceCapability{{Type: "scsi_host", Host: &host}}},
+	} {
+		if err = f.addNodeDevice(def); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// snapshotMethods returns the snapshot methods of the Domain interface
+// of d.
+func (f *fakeLibvirt) snapshotMethods(d *fakeDomain) map[string]interface{} {
+	lookup := func(name string) *fakeSnapshot {
+		for _, s := range d.snapshots {
+			if s.name == name {
+				return s
+			}
+		}
+		return nil
+	}
+	return map[string]interface{}{
+		"ListDomainSnapshots": func(flags uint32) ([]dbus.ObjectPath, *dbus.Error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			paths := make([]dbus.ObjectPath, 0, len(d.snapshots))
+			for _, s := range d.snapshots {
+				paths = append(paths, s.path)
+			}
+			return paths, nil
+		},
+		"SnapshotCreateXML": func(doc string, flags uint32) (dbus.ObjectPath, *dbus.Error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			var v fakeSnapshotXML
+			if err := xml.Unmarshal([]byte(doc), &v); err != nil {
+				return "", fakeError("XML error: %v", err)
+			}
+			if v.Name == "" {
+				v.Name = strings.Replace(f.newUUID(), "-", "", -1)
+			}
+			if lookup(v.Name) != nil {
+				return "", fakeError("operation failed: domain snapshot '%s' already exists", v.Name)
+			}
+			s := &fakeSnapshot{path: d.path + dbus.ObjectPath("/snapshot/"+v.Name), name: v.Name,
+				parent: d.current, doc: doc}
+			if err := f.addSnapshot(d, s); err != nil {
+				return "", fakeError("internal error: %v", err)
+			}
+			d.snapshots = append(d.snapshots, s)
+			d.current = s
+			return s.path, nil
+		},
+		"SnapshotCurrent": func(flags uint32) (dbus.ObjectPath, *dbus.Error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			if d.current == nil {
+				return "", fakeError("Domain snapshot not found: the domain does not have a current snapshot")
+			}
+			return d.current.path, nil
+		},
+		"SnapshotLookupByName": func(name string, flags uint32) (dbus.ObjectPath, *dbus.Error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			if s := lookup(name); s != nil {
+				return s.path, nil
+			}
+			return "", fakeError("Domain snapshot not found: no domain snapshot with matching name '%s'", name)
+		},
+	}
+}
+
+func (f *fakeLibvirt) addSnapshot(d *fakeDomain, s *fakeSnapshot) error {
+	return f.exportTable(s.path, "org.libvirt.DomainSnapshot", map[string]interface{}{
+		"Delete": func(flags uint32) *dbus.Error {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			for i, other := range d.snapshots {
+				if other == s {
+					d.snapshots = append(d.snapshots[:i], d.snapshots[i+1:]...)
+					break
+				}
+			}
+			for _, other := range d.snapshots {
+				if other.parent == s {
+					other.parent = s.parent
+				}
+			}
+			if d.current == s {
+				d.current = nil
+			}
+			f.unexport(s.path)
+			return nil
+		},
+		"GetParent": func(flags uint32) (dbus.ObjectPath, *dbus.Error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			if s.parent == nil {
+				return "", fakeError("Domain snapshot not found: snapshot '%s' does not have a parent", s.name)
+			}
+			return s.parent.path, nil
+		},
+		"GetXMLDesc": func(flags uint32) (string, *dbus.Error) {
+			return s.doc, nil
+		},
+		"IsCurrent": func(flags uint32) (bool, *dbus.Error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			return d.current == s, nil
+		},
+		"ListChildren": func(flags uint32) ([]dbus.ObjectPath, *dbus.Error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			paths := []dbus.ObjectPath{}
+			for _, other := range d.snapshots {
+				if other.parent == s {
+					paths = append(paths, other.path)
+				}
+			}
+			return paths, nil
+		},
+		"Revert": func(flags uint32) *dbus.Error {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			d.current = s
+			return nil
+		},
+	})
+}
+
+func (f *fakeLibvirt) addPool(def *libvirtxml.StoragePool) (*fakePool, error) {
+	p := &fakePool{path: fakePath("storagepool", def.UUID), def: def, persistent: true}
+
+	err := f.exportTable(p.path, "org.libvirt.StoragePool", map[string]interface{}{
+		"Create": func(flags uint32) *dbus.Error {
+			return f.setPoolActive(p, true, StoragePoolEventStarted)
+		},
+		"Destroy": func() *dbus.Error {
+			return f.setPoolActive(p, false, StoragePoolEventStopped)
+		},
+		"Undefine": func() *dbus.Error {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			if p.active {
+				return fakeError("Requested operation is not valid: storage pool '%s' is still active", p.def.Name)
+			}
+			for i, other := range f.pools {
+				if other == p {
+					f.pools = append(f.pools[:i], f.pools[i+1:]...)
+					break
+				}
+			}
+			f.unexport(p.path)
+			f.emit("StoragePoolEvent", p.path, int32(StoragePoolEventUndefined), int32(0))
+			return nil
+		},
+		"GetInfo": func() (StoragePoolInfo, *dbus.Error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			info := StoragePoolInfo{State: fakePoolInactive}
+			if p.active {
+				info = StoragePoolInfo{fakePoolRunning, p.def.Capacity.Value, p.def.Allocation.Value, p.def.Available.Value}
+			}
+			return info, nil
+		},
+		"GetXMLDesc": func(flags uint32) (string, *dbus.Error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			doc, err := p.def.Marshal()
+			if err != nil {
+				return "", fakeError("internal error: %v", err)
+			}
+			return doc, nil
+		},
+		"ListStorageVolumes": func(flags uint32) ([]dbus.ObjectPath, *dbus.Error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			paths := make([]dbus.ObjectPath, 0, len(p.vols))
+			for _, v := range p.vols {
+				paths = append(paths, v.path)
+			}
+			return paths, nil
+		},
+		"Refresh": func(flags uint32) *dbus.Error {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			if !p.active {
+				return fakeError("Requested operation is not valid: storage pool '%s' is not active", p.def.Name)
+			}
+			f.emitFrom(p.path, "org.libvirt.StoragePool", "Refresh")
+			return nil
+		},
+		"StorageVolCreateXML": func(doc string, flags uint32) (dbus.ObjectPath, *dbus.Error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			def := new(libvirtxml.StorageVolume)
+			if err := def.Unmarshal(doc); err != nil {
+				return "", fakeError("XML error: %v", err)
+			}
+			if def.Name == "" {
+				return "", fakeError("XML error: missing volume name")
+			}
+			if f.findVol(func(v *fakeVol) bool { return v.pool == p && v.def.Name == def.Name }) != nil {
+				return "", fakeError("storage volume '%s' exists already", def.Name)
+			}
+			if def.Capacity == nil {
+				def.Capacity = &libvirtxml.StorageSize{}
+			}
+			def.Allocation = &libvirtxml.StorageSize{Value: def.Capacity.Value}
+			def.Target = &libvirtxml.StorageVolumeTarget{Path: p.def.Target.Path + "/" + def.Name}
+			def.Key = def.Target.Path
+			v, err := f.addVol(p, def)
+			if err != nil {
+				return "", fakeError("internal error: %v", err)
+			}
+			p.def.Allocation.Value += def.Capacity.Value
+			p.def.Available.Value -= def.Capacity.Value
+			return v.path, nil
+		},
+		"StorageVolLookupByName": func(name string) (dbus.ObjectPath, *dbus.Error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			if v := f.findVol(func(v *fakeVol) bool { return v.pool == p && v.def.Name == name }); v != nil {
+				return v.path, nil
+			}
+			return "", fakeError("Storage volume not found: no storage vol with matching name '%s'", name)
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = f.exportProperties(p.path, "org.libvirt.StoragePool", map[string]func() interface{}{
+		"Active":     func() interface{} { return p.active },
+		"Autostart":  func() interface{} { return p.autostart },
+		"Name":       func() interface{} { return p.def.Name },
+		"Persistent": func() interface{} { return p.persistent },
+		"UUID":       func() interface{} { return p.def.UUID },
+	}, map[string]func(dbus.Variant) *dbus.Error{
+		"Autostart": func(v dbus.Variant) *dbus.Error { return dbusStore(v, &p.autostart) },
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	f.pools = append(f.pools, p)
+	return p, nil
+}
+
+func (f *fakeLibvirt) setPoolActive(p *fakePool, active bool, event StoragePoolEventType) *dbus.Error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if p.active == active {
+		return fakeError("Requested operation is not valid: storage pool '%s' is already in the requested state", p.def.Name)
+	}
+	p.active = active
+	f.emit("StoragePoolEvent", p.path, int32(event), int32(0))
+	return nil
+}
+
+func (f *fakeLibvirt) findPool(match func(*fakePool) bool) *fakePool {
+	for _, p := range f.pools {
+		if match(p) {
+			return p
+		}
+	}
+	return nil
+}
+
+func (f *fakeLibvirt) listStoragePools(flags uint32) ([]dbus.ObjectPath, *dbus.Error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	paths := make([]dbus.ObjectPath, 0, len(f.pools))
+	for _, p := range f.pools {
+		paths = append(paths, p.path)
+	}
+	return paths, nil
+}
+
+func (f *fakeLibvirt) storagePoolLookupByName(name string) (dbus.ObjectPath, *dbus.Error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if p := f.findPool(func(p *fakePool) bool { return p.def.Name == name }); p != nil {
+		return p.path, nil
+	}
+	return "", fakeError("Storage pool not found: no storage pool with matching name '%s'", name)
+}
+
+func (f *fakeLibvirt) storagePoolLookupByUUID(uuid string) (dbus.ObjectPath, *dbus.Error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if p := f.findPool(func(p *fakePool) bool { return p.def.UUID == uuid }); p != nil {
+		return p.path, nil
+	}
+	return "", fakeError("Storage pool not found: no storage pool with matching uuid '%s'", uuid)
+}
+
+func (f *fakeLibvirt) storagePoolDefineXML(doc string, flags uint32) (dbus.ObjectPath, *dbus.Error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	def := new(libvirtxml.StoragePool)
+	if err := def.Unmarshal(doc); err != nil {
+		return "", fakeError("XML error: %v", err)
+	}
+	if def.Name == "" {
+		return "", fakeError("XML error: missing pool source name element")
+	}
+	if f.findPool(func(p *fakePool) bool { return p.def.Name == def.Name }) != nil {
+		return "", fakeError("operation failed: pool '%s' already exists", def.Name)
+	}
+	if def.UUID == "" {
+		def.UUID = f.newUUID()
+	}
+	if def.Target == nil {
+		def.Target = &libvirtxml.StoragePoolTarget{Path: "/" + def.Name}
+	}
+	def.Capacity = &libvirtxml.StorageSize{Value: 107374182400}
+	def.Available = &libvirtxml.StorageSize{Value: def.Capacity.Value}
+	def.Allocation = &libvirtxml.StorageSize{}
+	p, err := f.addPool(def)
+	if err != nil {
+		return "", fakeError("internal error: %v", err)
+	}
+	f.emit("StoragePoolEvent", p.path, int32(StoragePoolEventDefined), int32(0))
+	return p.path, nil
+}
+
+func (f *fakeLibvirt) addVol(p *fakePool, def *libvirtxml.StorageVolume) (*fakeVol, error) {
+	v := &fakeVol{path: fakePath("storagevol", f.newUUID()), def: def, pool: p}
+
+	err := f.exportTable(v.path, "org.libvirt.StorageVol", map[string]interface{}{
+		"Delete": func(flags uint32) *dbus.Error {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			for i, other := range p.vols {
+				if other == v {
+					p.vols = append(p.vols[:i], p.vols[i+1:]...)
+					break
+				}
+			}
+			p.def.Allocation.Value -= v.def.Allocation.Value
+			p.def.Available.Value += v.def.Allocation.Value
+			f.unexport(v.path)
+			return nil
+		},
+		"GetInfo": func(flags uint32) (StorageVolInfo, *dbus.Error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			return StorageVolInfo{0, v.def.Capacity.Value, v.def.Allocation.Value}, nil
+		},
+		"GetXMLDesc": func(flags uint32) (string, *dbus.Error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			doc, err := v.def.Marshal()
+			if err != nil {
+				return "", fakeError("internal error: %v", err)
+			}
+			return doc, nil
+		},
+		"Resize": func(capacity uint64, flags uint32) *dbus.Error {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			if capacity < v.def.Capacity.Value && StorageVolResizeFlags(flags)&StorageVolResizeShrink == 0 {
+				return fakeError("invalid argument: Can't shrink capacity below current capacity unless shrink flag explicitly specified")
+			}
+			v.def.Capacity.Value = capacity
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = f.exportProperties(v.path, "org.libvirt.StorageVol", map[string]func() interface{}{
+		"Key":  func() interface{} { return v.def.Key },
+		"Name": func() interface{} { return v.def.Name },
+		"Path": func() interface{} { return v.def.Target.Path },
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	p.vols = append(p.vols, v)
+	return v, nil
+}
+
+func (f *fakeLibvirt) findVol(match func(*fakeVol) bool) *fakeVol {
+	for _, p := range f.pools {
+		for _, v := range p.vols {
+			if match(v) {
+				return v
+			}
+		}
+	}
+	return nil
+}
+
+func (f *fakeLibvirt) storageVolLookupByPath(path string) (dbus.ObjectPath, *dbus.Error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if v := f.findVol(func(v *fakeVol) bool { return v.def.Target.Path == path }); v != nil {
+		return v.path, nil
+	}
+	return "", fakeError("Storage volume not found: no storage vol with matching path '%s'", path)
+}
+
+// fakeUsageTypes maps the usage types of secret definitions to their
+// virSecretUsageType.
+var fakeUsageTypes = map[string]SecretUsageType{
+	"volume": SecretUsageTypeVolume,
+	"ceph":   SecretUsageTypeCeph,
+	"iscsi":  SecretUsageTypeISCSI,
+	"tls":    SecretUsageTypeTLS,
+	"vtpm":   SecretUsageTypeVTPM,
+}
+
+// usageID returns what SecretLookupByUsage finds s by.
+func (s *fakeSecret) usageID() string {
+	switch u := s.def.Usage; {
+	case u == nil:
+		return ""
+	case u.Type == "volume":
+		return u.Volume
+	case u.Type == "iscsi":
+		return u.Target
+	default:
+		return u.Name
+	}
+}
+
+func (s *fakeSecret) usageType() SecretUsageType {
+	if s.def.Usage == nil {
+		return SecretUsageTypeNone
+	}
+	return fakeUsageTypes[s.def.Usage.Type]
+}
+
+func (f *fakeLibvirt) addSecret(def *libvirtxml.Secret) (*fakeSecret, error) {
+	s := &fakeSecret{path: fakePath("secret", def.UUID), def: def}
+
+	err := f.exportTable(s.path, "org.libvirt.Secret", map[string]interface{}{
+		"GetValue": func(flags uint32) ([]byte, *dbus.Error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			if s.def.Private == "yes" {
+				return nil, fakeError("operation forbidden: secret is private")
+			}
+			if s.value == nil {
+				return nil, fakeError("Secret not found: secret '%s' does not have a value", s.def.UUID)
+			}
+			return s.value, nil
+		},
+		"SetValue": func(value []byte, flags uint32) *dbus.Error {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			s.value = append([]byte{}, value...)
+			return nil
+		},
+		"GetXMLDesc": func(flags uint32) (string, *dbus.Error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			doc, err := s.def.Marshal()
+			if err != nil {
+				return "", fakeError("internal error: %v", err)
+			}
+			return doc, nil
+		},
+		"Undefine": func() *dbus.Error {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			for i, other := range f.secrets {
+				if other == s {
+					f.secrets = append(f.secrets[:i], f.secrets[i+1:]...)
+					break
+				}
+			}
+			f.unexport(s.path)
+			f.emit("SecretEvent", s.path, int32(SecretEventUndefined), int32(0))
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = f.exportProperties(s.path, "org.libvirt.Secret", map[string]func() interface{}{
+		"UUID":      func() interface{} { return s.def.UUID },
+		"UsageID":   func() interface{} { return s.usageID() },
+		"UsageType": func() interface{} { return int32(s.usageType()) },
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	f.secrets = append(f.secrets, s)
+	return s, nil
+}
+
+func (f *fakeLibvirt) findSecret(match func(*fakeSecret) bool) *fakeSecret {
+	for _, s := range f.secrets {
+		if match(s) {
+			return s
+		}
+	}
+	return nil
+}
+
+func (f *fakeLibvirt) listSecrets(flags uint32) ([]dbus.ObjectPath, *dbus.Error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	paths := make([]dbus.ObjectPath, 0, len(f.secrets))
+	for _, s := range f.secrets {
+		paths = append(paths, s.path)
+	}
+	return paths, nil
+}
+
+func (f *fakeLibvirt) secretDefineXML(doc string, flags uint32) (dbus.ObjectPath, *dbus.Error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	def := new(libvirtxml.Secret)
+	if err := def.Unmarshal(doc); err != nil {
+		return "", fakeError("XML error: %v", err)
+	}
+	if def.UUID == "" {
+		def.UUID = f.newUUID()
+	}
+	if s := f.findSecret(func(s *fakeSecret) bool { return s.def.UUID == def.UUID }); s != nil {
+		s.def = def
+		f.emit("SecretEvent", s.path, int32(SecretEventDefined), int32(0))
+		return s.path, nil
+	}
+	s, err := f.addSecret(def)
+	if err != nil {
+		return "", fakeError("internal error: %v", err)
+	}
+	f.emit("SecretEvent", s.path, int32(SecretEventDefined), int32(0))
+	return s.path, nil
+}
+
+func (f *fakeLibvirt) secretLookupByUUID(uuid string) (dbus.ObjectPath, *dbus.Error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if s := f.findSecret(func(s *fakeSecret) bool { return s.def.UUID == uuid }); s != nil {
+		return s.path, nil
+	}
+	return "", fakeError("Secret not found: no secret with matching uuid '%s'", uuid)
+}
+
+func (f *fakeLibvirt) secretLookupByUsage(usageType int32, usageID string) (dbus.ObjectPath, *dbus.Error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if s := f.findSecret(func(s *fakeSecret) bool {
+		return int32(s.usageType()) == usageType && s.usageID() == usageID
+	}); s != nil {
+		return s.path, nil
+	}
+	return "", fakeError("Secret not found: no secret with matching usage '%s'", usageID)
+}
+
+func (f *fakeLibvirt) addNodeDevice(def *libvirtxml.NodeDevice) error {
+	d := &fakeNodeDevice{path: fakeRoot + dbus.ObjectPath("/nodedev/"+def.Name), def: def}
+
+	err := f.exportTable(d.path, "org.libvirt.NodeDevice", map[string]interface{}{
+		"GetXMLDesc": func(flags uint32) (string, *dbus.Error) {
+			doc, err := d.def.Marshal()
+			if err != nil {
+				return "", fakeError("internal error: %v", err)
+			}
+			return doc, nil
+		},
+		"ListCaps": func() ([]string, *dbus.Error) {
+			names := make([]string, 0, len(d.def.Capabilities))
+			for _, c := range d.def.Capabilities {
+				names = append(names, c.Type)
+			}
+			return names, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	err = f.exportProperties(d.path, "org.libvirt.NodeDevice", map[string]func() interface{}{
+		"Name":   func() interface{} { return d.def.Name },
+		"Parent": func() interface{} { return d.def.Parent },
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	f.devices = append(f.devices, d)
+	return nil
+}
+
+func (f *fakeLibvirt) listNodeDevices(flags uint32) ([]dbus.ObjectPath, *dbus.Error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	paths := make([]dbus.ObjectPath, 0, len(f.devices))
+	for _, d := range f.devices {
+		paths = append(paths, d.path)
+	}
+	return paths, nil
+}
+
+func (f *fakeLibvirt) nodeDeviceLookupByName(name string) (dbus.ObjectPath, *dbus.Error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, d := range f.devices {
+		if d.def.Name == name {
+			return d.path, nil
+		}
+	}
+	return "", fakeError("Node device not found: no node device with matching name '%s'", name)
+}
+
+// nodeGetCPUStats and nodeGetMemoryStats report the figures of an idle
+// host with 8 GiB.
+func (f *fakeLibvirt) nodeGetCPUStats(cpuNum int32, flags uint32) (map[string]uint64, *dbus.Error) {
+	if cpuNum != NodeCPUStatsAllCPUs && cpuNum != 0 {
+		return nil, fakeError("invalid argument: Invalid cpuNum in nodeGetCPUStats")
+	}
+	return map[string]uint64{"user": 1000000000, "kernel": 500000000, "idle": 98000000000, "iowait": 500000000}, nil
+}
+
+func (f *fakeLibvirt) nodeGetMemoryStats(cellNum int32, flags uint32) (map[string]uint64, *dbus.Error) {
+	if cellNum != NodeMemoryStatsAllCells && cellNum != 0 {
+		return nil, fakeError("invalid argument: Invalid cell number")
+	}
+	return map[string]uint64{"total": 8388608, "free": 4194304, "buffers": 65536, "cached": 1048576}, nil
+}
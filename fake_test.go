@@ -0,0 +1,1079 @@
+package libvirt
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/godbus/dbus"
+	"sdstack.com/sdstack/go-libvirt/libvirtxml"
+)
+
+// The tests below run against fakeLibvirt, an in-process stand-in for
+// libvirt-dbus serving the Test driver. It also plays the bus daemon: it
+// answers Hello, keeps the match rules of every client and only sends a
+// client the signals one of its rules matches. Clients reach it over a
+// peer-to-peer connection (newTestConn) or by dialing the address returned
+// by listen, so neither a bus daemon nor libvirt is needed. Like libvirt's
+// own test driver it starts out with a running domain "test", an active
+// network "default", an active storage pool "default-pool" and a few host
+// devices.
+
+const (
+	fakeDomainUUID  = "6695eb01-f6a4-8304-79aa-97f2502e193f"
+	fakeNetworkUUID = "dd8fe884-6c02-601e-7551-cca97df1c5df"
+	fakePoolUUID    = "dfe224cb-28fb-8dd0-c4b2-64eb3f0f4566"
+
+	fakeRoot = dbus.ObjectPath("/org/libvirt/Test")
+	fakeBus  = "org.freedesktop.DBus"
+)
+
+// virDomainState values and the reasons the fake reports with them.
+const (
+	fakeStateRunning = 1
+	fakeStatePaused  = 3
+	fakeStateShutoff = 5
+
+	fakeReasonBooted    = 1
+	fakeReasonUser      = 1
+	fakeReasonShutdown  = 1
+	fakeReasonDestroyed = 2
+)
+
+type fakeDomain struct {
+	path       dbus.ObjectPath
+	def        *libvirtxml.Domain
+	id         int32
+	state      int32
+	reason     int32
+	persistent bool
+	autostart  bool
+	memtune    map[string]dbus.Variant
+	snapshots  []*fakeSnapshot
+	current    *fakeSnapshot
+}
+
+type fakeNetwork struct {
+	path       dbus.ObjectPath
+	def        *libvirtxml.Network
+	active     bool
+	persistent bool
+	autostart  bool
+}
+
+type fakeLibvirt struct {
+	mu       sync.Mutex
+	nextID   int32
+	serial   int
+	domains  []*fakeDomain
+	networks []*fakeNetwork
+	pools    []*fakePool
+	secrets  []*fakeSecret
+	devices  []*fakeNodeDevice
+	// hold, when set, keeps GetHostname from replying until it is closed,
+	// like a guest agent that does not answer.
+	hold chan struct{}
+
+	busmu   sync.Mutex
+	tables  map[dbus.ObjectPath]map[string]map[string]interface{}
+	clients []*fakeClient
+	hellos  int
+	auths   []string
+	// While down is set the listener hangs up on new clients, counting
+	// them in refused.
+	down    bool
+	refused int
+}
+
+// fakeClient is the server end of a connection to the fake and the match
+// rules its peer installed.
+type fakeClient struct {
+	srv   *dbus.Conn
+	rules map[string]int
+}
+
+// newFake returns a fakeLibvirt with its initial objects. Its connections
+// are closed when the test ends.
+func newFake(t *testing.T) *fakeLibvirt {
+	t.Helper()
+
+	f := &fakeLibvirt{nextID: 1, tables: make(map[dbus.ObjectPath]map[string]map[string]interface{})}
+	if err := f.export(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(f.drop)
+	return f
+}
+
+// newTestConn returns a Conn for the Test driver talking to a fresh
+// fakeLibvirt over a peer-to-peer connection. Both are torn down when the
+// test ends.
+func newTestConn(t *testing.T) (*Conn, *fakeLibvirt) {
+	t.Helper()
+
+	f := newFake(t)
+	client, err := f.peer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewConnWithOptions(DriverTest, WithDBusConn(client))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		c.Close()
+		client.Close()
+	})
+	return c, f
+}
+
+// peer returns a client connection to f that has authenticated and sent
+// Hello, as WithDBusConn expects.
+func (f *fakeLibvirt) peer() (*dbus.Conn, error) {
+	end, relay := net.Pipe()
+	errc := make(chan error, 1)
+	go func() {
+		_, err := acceptAuth(relay)
+		errc <- err
+	}()
+
+	client, err := dbus.NewConn(end)
+	if err == nil {
+		err = client.Auth([]dbus.Auth{dbus.AuthExternal("0")})
+	}
+	if err != nil {
+		end.Close()
+	}
+	if rerr := <-errc; err == nil {
+		err = rerr
+	}
+	if err != nil {
+		relay.Close()
+		return nil, err
+	}
+	if _, err = serve(relay, f.attach); err == nil {
+		err = client.Hello()
+	}
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+	return client, nil
+}
+
+// listen makes f reachable at the returned D-Bus address, where clients
+// authenticate and say Hello as on a bus. It stops listening when the test
+// ends.
+func (f *fakeLibvirt) listen(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "bus")
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			raw, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			f.busmu.Lock()
+			down := f.down
+			if down {
+				f.refused++
+			}
+			f.busmu.Unlock()
+			if down {
+				raw.Close()
+				continue
+			}
+			go func() {
+				auth, err := acceptAuth(raw)
+				if err == nil {
+					f.busmu.Lock()
+					f.auths = append(f.auths, auth)
+					f.busmu.Unlock()
+					_, err = serve(raw, f.attach)
+				}
+				if err != nil {
+					raw.Close()
+				}
+			}()
+		}
+	}()
+	return "unix:path=" + path
+}
+
+// serve returns a connection playing the server on raw, whose peer has
+// already authenticated, and calls setup with it before any message of
+// the peer is read. godbus only implements the client side of the
+// handshake, so the connection authenticates against a relay that accepts
+// any credentials and then forwards the messages to and from raw.
+func serve(raw net.Conn, setup func(*dbus.Conn) error) (*dbus.Conn, error) {
+	end, relay := net.Pipe()
+	errc := make(chan error, 1)
+	go func() {
+		_, err := acceptAuth(relay)
+		errc <- err
+	}()
+
+	srv, err := dbus.NewConn(end)
+	if err == nil {
+		err = srv.Auth([]dbus.Auth{dbus.AuthExternal("0")})
+	}
+	if err != nil {
+		end.Close()
+	}
+	if rerr := <-errc; err == nil {
+		err = rerr
+	}
+	if err == nil {
+		err = setup(srv)
+	}
+	if err != nil {
+		end.Close()
+		relay.Close()
+		return nil, err
+	}
+
+	forward := func(dst, src net.Conn) {
+		io.Copy(dst, src)
+		dst.Close()
+		src.Close()
+	}
+	go forward(raw, relay)
+	go forward(relay, raw)
+	return srv, nil
+}
+
+// acceptAuth plays the server side of the D-Bus authentication handshake
+// and returns the AUTH command it accepted. It reads byte by byte so that
+// nothing sent after BEGIN is consumed.
+func acceptAuth(rw io.ReadWriter) (string, error) {
+	var b [1]byte
+	if _, err := io.ReadFull(rw, b[:]); err != nil {
+		return "", err
+	}
+	var auth string
+	for {
+		var line []byte
+		for !bytes.HasSuffix(line, []byte("\r\n")) {
+			if _, err := io.ReadFull(rw, b[:]); err != nil {
+				return "", err
+			}
+			line = append(line, b[0])
+		}
+
+		var reply string
+		switch cmd := string(line[:len(line)-2]); {
+		case cmd == "BEGIN":
+			return auth, nil
+		case cmd == "AUTH":
+			reply = "REJECTED EXTERNAL"
+		case strings.HasPrefix(cmd, "AUTH EXTERNAL"):
+			auth = cmd
+			reply = "OK 0123456789abcdef0123456789abcdef"
+		default:
+			reply = "ERROR"
+		}
+		if _, err := io.WriteString(rw, reply+"\r\n"); err != nil {
+			return "", err
+		}
+	}
+}
+
+// attach serves the bus methods and every exported object on srv.
+func (f *fakeLibvirt) attach(srv *dbus.Conn) error {
+	cl := &fakeClient{srv: srv, rules: make(map[string]int)}
+
+	f.busmu.Lock()
+	defer f.busmu.Unlock()
+	f.hellos++
+	name := fmt.Sprintf(":1.%d", f.hellos)
+	err := srv.ExportMethodTable(map[string]interface{}{
+		"Hello": func() (string, *dbus.Error) { return name, nil },
+		"AddMatch": func(rule string) *dbus.Error {
+			f.busmu.Lock()
+			defer f.busmu.Unlock()
+			cl.rules[rule]++
+			return nil
+		},
+		"RemoveMatch": func(rule string) *dbus.Error {
+			f.busmu.Lock()
+			defer f.busmu.Unlock()
+			if cl.rules[rule] == 0 {
+				return dbus.NewError(fakeBus+".Error.MatchRuleNotFound", []interface{}{"The given match rule wasn't found"})
+			}
+			if cl.rules[rule]--; cl.rules[rule] == 0 {
+				delete(cl.rules, rule)
+			}
+			return nil
+		},
+	}, "/org/freedesktop/DBus", fakeBus)
+	if err != nil {
+		return err
+	}
+	for path, ifaces := range f.tables {
+		for iface, table := range ifaces {
+			if err = srv.ExportMethodTable(table, path, iface); err != nil {
+				return err
+			}
+		}
+	}
+	f.clients = append(f.clients, cl)
+	return nil
+}
+
+// setDown makes the listener refuse clients or accept them again.
+func (f *fakeLibvirt) setDown(down bool) {
+	f.busmu.Lock()
+	defer f.busmu.Unlock()
+	f.down = down
+}
+
+// drop closes the connections of all clients, as a bus going away would.
+func (f *fakeLibvirt) drop() {
+	f.busmu.Lock()
+	clients := f.clients
+	f.clients = nil
+	f.busmu.Unlock()
+
+	for _, cl := range clients {
+		cl.srv.Close()
+	}
+}
+
+// matchRules returns how many clients installed each match rule.
+func (f *fakeLibvirt) matchRules() map[string]int {
+	f.busmu.Lock()
+	defer f.busmu.Unlock()
+	rules := make(map[string]int)
+	for _, cl := range f.clients {
+		for rule := range cl.rules {
+			rules[rule]++
+		}
+	}
+	return rules
+}
+
+// exportTable serves the methods in table as iface of the object at path
+// to all present and future clients.
+func (f *fakeLibvirt) exportTable(path dbus.ObjectPath, iface string, table map[string]interface{}) error {
+	f.busmu.Lock()
+	defer f.busmu.Unlock()
+	if f.tables[path] == nil {
+		f.tables[path] = make(map[string]map[string]interface{})
+	}
+	f.tables[path][iface] = table
+	for _, cl := range f.clients {
+		if err := cl.srv.ExportMethodTable(table, path, iface); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unexport removes the object at path.
+func (f *fakeLibvirt) unexport(path dbus.ObjectPath) {
+	f.busmu.Lock()
+	defer f.busmu.Unlock()
+	for iface := range f.tables[path] {
+		for _, cl := range f.clients {
+			cl.srv.Export(nil, path, iface)
+		}
+	}
+	delete(f.tables, path)
+}
+
+// broadcast sends the signal name with args from path to the clients that
+// installed a matching rule.
+func (f *fakeLibvirt) broadcast(sender string, path dbus.ObjectPath, name string, args ...interface{}) {
+	f.busmu.Lock()
+	defer f.busmu.Unlock()
+	for _, cl := range f.clients {
+		for rule := range cl.rules {
+			if fakeMatch(rule, sender, path, name, args) {
+				cl.srv.Emit(path, name, args...)
+				break
+			}
+		}
+	}
+}
+
+// fakeMatch reports whether rule selects the signal name sent by sender
+// from path with args. It knows the keys Conn uses and rejects others.
+func fakeMatch(rule, sender string, path dbus.ObjectPath, name string, args []interface{}) bool {
+	dot := strings.LastIndex(name, ".")
+	for _, kv := range strings.Split(rule, ",") {
+		eq := strings.IndexByte(kv, '=')
+		if eq < 0 {
+			return false
+		}
+		val := strings.Trim(kv[eq+1:], "'")
+		var ok bool
+		switch kv[:eq] {
+		case "type":
+			ok = val == "signal"
+		case "sender":
+			ok = val == sender
+		case "path":
+			ok = val == string(path)
+		case "interface":
+			ok = val == name[:dot]
+		case "member":
+			ok = val == name[dot+1:]
+		case "arg0":
+			arg, isString := args[0].(string)
+			ok = len(args) > 0 && isString && arg == val
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// setOwner announces that org.libvirt changed hands, "" meaning that
+// libvirt-dbus left the bus.
+func (f *fakeLibvirt) setOwner(old, new string) {
+	f.broadcast(fakeBus, "/org/freedesktop/DBus", fakeBus+".NameOwnerChanged", "org.libvirt", old, new)
+}
+
+func fakeError(format string, args ...interface{}) *dbus.Error {
+	return dbus.NewError(libvirtErrorName, []interface{}{fmt.Sprintf(format, args...)})
+}
+
+func fakePath(kind, uuid string) dbus.ObjectPath {
+	return fakeRoot + dbus.ObjectPath("/"+kind+"/"+strings.Replace(uuid, "-", "_", -1))
+}
+
+// exportProperties serves org.freedesktop.DBus.Properties for one
+// interface of the object at path. Properties without a setter are
+// read-only.
+func (f *fakeLibvirt) exportProperties(path dbus.ObjectPath, iface string,
+	get map[string]func() interface{}, set map[string]func(dbus.Variant) *dbus.Error) error {
+	return f.exportTable(path, "org.freedesktop.DBus.Properties", map[string]interface{}{
+		"Get": func(in, name string) (dbus.Variant, *dbus.Error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			if g, ok := get[name]; ok && in == iface {
+				return dbus.MakeVariant(g()), nil
+			}
+			return dbus.Variant{}, dbus.NewError("org.freedesktop.DBus.Error.UnknownProperty", []interface{}{name})
+		},
+		"GetAll": func(in string) (map[string]dbus.Variant, *dbus.Error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			props := make(map[string]dbus.Variant)
+			if in == iface {
+				for name, g := range get {
+					props[name] = dbus.MakeVariant(g())
+				}
+			}
+			return props, nil
+		},
+		"Set": func(in, name string, v dbus.Variant) *dbus.Error {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			if s, ok := set[name]; ok && in == iface {
+				return s(v)
+			}
+			return dbus.NewError("org.freedesktop.DBus.Error.PropertyReadOnly", []interface{}{name})
+		},
+	})
+}
+
+func (f *fakeLibvirt) export() error {
+	err := f.exportTable(fakeRoot, "org.libvirt.Connect", map[string]interface{}{
+		"ListDomains":         f.listDomains,
+		"DomainLookupByName":  f.domainLookupByName,
+		"DomainLookupByUUID":  f.domainLookupByUUID,
+		"DomainLookupByID":    f.domainLookupByID,
+		"DomainDefineXML":     f.domainDefineXML,
+		"DomainCreateXML":     f.domainCreateXML,
+		"GetAllDomainStats":   f.getAllDomainStats,
+		"ListNetworks":        f.listNetworks,
+		"NetworkLookupByName": f.networkLookupByName,
+		"NetworkLookupByUUID": f.networkLookupByUUID,
+		"NetworkDefineXML":    f.networkDefineXML,
+
+		"ListStoragePools":        f.listStoragePools,
+		"StoragePoolLookupByName": f.storagePoolLookupByName,
+		"StoragePoolLookupByUUID": f.storagePoolLookupByUUID,
+		"StoragePoolDefineXML":    f.storagePoolDefineXML,
+		"StorageVolLookupByPath":  f.storageVolLookupByPath,
+		"ListSecrets":             f.listSecrets,
+		"SecretDefineXML":         f.secretDefineXML,
+		"SecretLookupByUUID":      f.secretLookupByUUID,
+		"SecretLookupByUsage":     f.secretLookupByUsage,
+		"ListNodeDevices":         f.listNodeDevices,
+		"NodeDeviceLookupByName":  f.nodeDeviceLookupByName,
+		"NodeGetCPUStats":         f.nodeGetCPUStats,
+		"NodeGetMemoryStats":      f.nodeGetMemoryStats,
+	})
+	if err != nil {
+		return err
+	}
+	err = f.exportProperties(fakeRoot, "org.libvirt.Connect", map[string]func() interface{}{
+		"Encrypted":  func() interface{} { return false },
+		"Hostname":   func() interface{} { return "fake-host" },
+		"LibVersion": func() interface{} { return uint64(6010000) },
+		"Secure":     func() interface{} { return true },
+		"Version":    func() interface{} { return uint64(2) },
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	d := &libvirtxml.Domain{Type: "test", Name: "test", UUID: fakeDomainUUID,
+		Memory: &libvirtxml.DomainMemory{Value: 8388608, Unit: "KiB"},
+		VCPU:   &libvirtxml.DomainVCPU{Value: 2},
+		OS:     &libvirtxml.DomainOS{Type: &libvirtxml.DomainOSType{Type: "hvm"}}}
+	if _, err = f.addDomain(d, true, true); err != nil {
+		return err
+	}
+	n := &libvirtxml.Network{Name: "default", UUID: fakeNetworkUUID,
+		Bridge: &libvirtxml.NetworkBridge{Name: "virbr0"}}
+	nw, err := f.addNetwork(n)
+	if err != nil {
+		return err
+	}
+	nw.active = true
+	return f.addDefaults()
+}
+
+// emit sends a signal of the Connect object.
+func (f *fakeLibvirt) emit(member string, args ...interface{}) {
+	f.broadcast("org.libvirt", fakeRoot, "org.libvirt.Connect."+member, args...)
+}
+
+// emitFrom sends the signal member of iface from the object at path.
+func (f *fakeLibvirt) emitFrom(path dbus.ObjectPath, iface, member string, args ...interface{}) {
+	f.broadcast("org.libvirt", path, iface+"."+member, args...)
+}
+
+func (f *fakeLibvirt) findDomain(match func(*fakeDomain) bool) *fakeDomain {
+	for _, d := range f.domains {
+		if match(d) {
+			return d
+		}
+	}
+	return nil
+}
+
+// addDomain exports a new domain; the caller holds f.mu or nobody can
+// call in yet.
+func (f *fakeLibvirt) addDomain(def *libvirtxml.Domain, persistent, start bool) (*fakeDomain, error) {
+	d := &fakeDomain{path: fakePath("domain", def.UUID), def: def, persistent: persistent,
+		state: fakeStateShutoff, memtune: map[string]dbus.Variant{
+			"hard_limit": dbus.MakeVariant(DomainMemoryParamUnlimited),
+			"soft_limit": dbus.MakeVariant(DomainMemoryParamUnlimited),
+		}}
+	if start {
+		f.start(d)
+	}
+
+	methods := map[string]interface{}{
+		"Create": func(flags uint32) *dbus.Error {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			if d.state != fakeStateShutoff {
+				return fakeError("Requested operation is not valid: domain is already running")
+			}
+			f.start(d)
+			f.emit("DomainEvent", d.path, int32(DomainEventStarted), int32(DomainEventStartedBooted))
+			return nil
+		},
+		"Destroy": func(flags uint32) *dbus.Error {
+			return f.stop(d, fakeReasonDestroyed, DomainEventStoppedDestroyed)
+		},
+		"Shutdown": func(flags uint32) *dbus.Error {
+			return f.stop(d, fakeReasonShutdown, DomainEventStoppedShutdown)
+		},
+		"Reboot": func(flags uint32) *dbus.Error {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			if d.state != fakeStateRunning {
+				return fakeError("Requested operation is not valid: domain is not running")
+			}
+			f.emitFrom(d.path, "org.libvirt.Domain", "Reboot")
+			return nil
+		},
+		"GetHostname": func(flags uint32) (string, *dbus.Error) {
+			f.mu.Lock()
+			hold := f.hold
+			f.mu.Unlock()
+			if hold != nil {
+				<-hold
+			}
+			return d.def.Name, nil
+		},
+		"Suspend": func() *dbus.Error {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			if d.state != fakeStateRunning {
+				return fakeError("Requested operation is not valid: domain is not running")
+			}
+			d.state, d.reason = fakeStatePaused, fakeReasonUser
+			f.emit("DomainEvent", d.path, int32(DomainEventSuspended), int32(DomainEventSuspendedPaused))
+			return nil
+		},
+		"Resume": func() *dbus.Error {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			if d.state != fakeStatePaused {
+				return fakeError("Requested operation is not valid: domain is not paused")
+			}
+			d.state, d.reason = fakeStateRunning, fakeReasonBooted
+			f.emit("DomainEvent", d.path, int32(DomainEventResumed), int32(DomainEventResumedUnpaused))
+			return nil
+		},
+		"Undefine": func(flags uint32) *dbus.Error {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			if !d.persistent {
+				return fakeError("Requested operation is not valid: cannot undefine transient domain")
+			}
+			d.persistent = false
+			f.emit("DomainEvent", d.path, int32(DomainEventUndefined), int32(DomainEventUndefinedRemoved))
+			if d.state == fakeStateShutoff {
+				f.removeDomain(d)
+			}
+			return nil
+		},
+		"GetState": func(flags uint32) (DomainState, *dbus.Error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			return DomainState{d.state, d.reason}, nil
+		},
+		"GetXMLDesc": func(flags uint32) (string, *dbus.Error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			def := *d.def
+			// Only the live XML carries the ID of a running domain.
+			if d.state != fakeStateShutoff && DomainXMLFlags(flags)&DomainXMLInactive == 0 {
+				id := int(d.id)
+				def.ID = &id
+			}
+			doc, err := def.Marshal()
+			if err != nil {
+				return "", fakeError("internal error: %v", err)
+			}
+			return doc, nil
+		},
+		"GetStats": func(stats, flags uint32) (map[string]dbus.Variant, *dbus.Error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			return f.stats(d, DomainStatsTypes(stats)), nil
+		},
+		"GetMemoryParameters": func(flags uint32) (map[string]dbus.Variant, *dbus.Error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			return d.memtune, nil
+		},
+		"SetMemoryParameters": func(params map[string]dbus.Variant, flags uint32) *dbus.Error {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			for name, v := range params {
+				if _, ok := d.memtune[name]; !ok {
+					return fakeError("argument unsupported: parameter '%s' not supported", name)
+				}
+				if _, ok := v.Value().(uint64); !ok {
+					return fakeError("invalid argument: invalid type for parameter '%s', expected 'ullong'", name)
+				}
+			}
+			for name, v := range params {
+				d.memtune[name] = v
+			}
+			return nil
+		},
+	}
+	for name, m := range f.snapshotMethods(d) {
+		methods[name] = m
+	}
+	err := f.exportTable(d.path, "org.libvirt.Domain", methods)
+	if err != nil {
+		return nil, err
+	}
+	err = f.exportProperties(d.path, "org.libvirt.Domain", map[string]func() interface{}{
+		"Active":     func() interface{} { return d.state != fakeStateShutoff },
+		"Autostart":  func() interface{} { return d.autostart },
+		"Id":         func() interface{} { return uint32(d.id) },
+		"Name":       func() interface{} { return d.def.Name },
+		"OSType":     func() interface{} { return "hvm" },
+		"Persistent": func() interface{} { return d.persistent },
+		"UUID":       func() interface{} { return d.def.UUID },
+	}, map[string]func(dbus.Variant) *dbus.Error{
+		"Autostart": func(v dbus.Variant) *dbus.Error {
+			if !d.persistent {
+				return fakeError("Requested operation is not valid: cannot set autostart for transient domain")
+			}
+			return dbusStore(v, &d.autostart)
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	f.domains = append(f.domains, d)
+	return d, nil
+}
+
+func dbusStore(v dbus.Variant, dst interface{}) *dbus.Error {
+	if err := dbus.Store([]interface{}{v.Value()}, dst); err != nil {
+		return fakeError("invalid argument: %v", err)
+	}
+	return nil
+}
+
+func (f *fakeLibvirt) removeDomain(d *fakeDomain) {
+	for i, other := range f.domains {
+		if other == d {
+			f.domains = append(f.domains[:i], f.domains[i+1:]...)
+			break
+		}
+	}
+	f.unexport(d.path)
+}
+
+func (f *fakeLibvirt) start(d *fakeDomain) {
+	d.id = f.nextID
+	f.nextID++
+	d.state, d.reason = fakeStateRunning, fakeReasonBooted
+}
+
+func (f *fakeLibvirt) stop(d *fakeDomain, reason int32, detail DomainEventStoppedDetail) *dbus.Error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if d.state == fakeStateShutoff {
+		return fakeError("Requested operation is not valid: domain is not running")
+	}
+	d.id = 0
+	d.state, d.reason = fakeStateShutoff, reason
+	f.emit("DomainEvent", d.path, int32(DomainEventStopped), int32(detail))
+	if !d.persistent {
+		f.removeDomain(d)
+	}
+	return nil
+}
+
+// fakeStatGroups maps the key prefixes of the statistics to their group.
+var fakeStatGroups = map[string]DomainStatsTypes{
+	"state":   DomainStatsState,
+	"cpu":     DomainStatsCPUTotal,
+	"balloon": DomainStatsBalloon,
+	"vcpu":    DomainStatsVCPU,
+	"net":     DomainStatsInterface,
+	"block":   DomainStatsBlock,
+	"memory":  DomainStatsMemory,
+}
+
+// stats returns the typed-parameter statistics of d in the groups selected
+// by groups, all of them if it is 0. They are a small subset of what the
+// test driver reports.
+func (f *fakeLibvirt) stats(d *fakeDomain, groups DomainStatsTypes) map[string]dbus.Variant {
+	s := map[string]dbus.Variant{
+		"state.state":  dbus.MakeVariant(d.state),
+		"state.reason": dbus.MakeVariant(d.reason),
+	}
+	if d.state != fakeStateShutoff {
+		s["cpu.time"] = dbus.MakeVariant(uint64(1000000000))
+		s["balloon.current"] = dbus.MakeVariant(d.def.Memory.Value)
+		// Like libvirt, leave out the vCPUs <vcpus> marks as disabled.
+		online := 0
+		for i := 0; i < int(d.def.VCPU.Value); i++ {
+			if d.def.VCPUs != nil && i < len(d.def.VCPUs.VCPU) && d.def.VCPUs.VCPU[i].Enabled == "no" {
+				continue
+			}
+			n := strconv.Itoa(i)
+			s["vcpu."+n+".state"] = dbus.MakeVariant(int32(1))
+			s["vcpu."+n+".time"] = dbus.MakeVariant(uint64(1000000000))
+			online++
+		}
+		s["vcpu.current"] = dbus.MakeVariant(uint32(online))
+		s["vcpu.maximum"] = dbus.MakeVariant(uint32(d.def.VCPU.Value))
+		s["net.count"] = dbus.MakeVariant(uint32(1))
+		s["net.0.name"] = dbus.MakeVariant("testnet0")
+		s["net.0.rx.bytes"] = dbus.MakeVariant(uint64(4096))
+		s["net.0.tx.bytes"] = dbus.MakeVariant(uint64(1024))
+		s["block.count"] = dbus.MakeVariant(uint32(1))
+		s["block.0.name"] = dbus.MakeVariant("vda")
+		s["block.0.rd.bytes"] = dbus.MakeVariant(uint64(8192))
+		s["block.0.wr.bytes"] = dbus.MakeVariant(uint64(2048))
+		s["memory.bandwidth.monitor.count"] = dbus.MakeVariant(uint32(1))
+		s["memory.bandwidth.monitor.0.name"] = dbus.MakeVariant("vcpus_0")
+		s["memory.bandwidth.monitor.0.vcpus"] = dbus.MakeVariant("0")
+		s["memory.bandwidth.monitor.0.node.count"] = dbus.MakeVariant(uint32(1))
+		s["memory.bandwidth.monitor.0.node.0.id"] = dbus.MakeVariant(uint32(0))
+		s["memory.bandwidth.monitor.0.node.0.bytes.local"] = dbus.MakeVariant(uint64(4096))
+		s["memory.bandwidth.monitor.0.node.0.bytes.total"] = dbus.MakeVariant(uint64(8192))
+	}
+	if groups != 0 {
+		for key := range s {
+			if fakeStatGroups[strings.SplitN(key, ".", 2)[0]]&groups == 0 {
+				delete(s, key)
+			}
+		}
+	}
+	return s
+}
+
+func (f *fakeLibvirt) listDomains(flags uint32) ([]dbus.ObjectPath, *dbus.Error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	paths := make([]dbus.ObjectPath, 0, len(f.domains))
+	for _, d := range f.domains {
+		paths = append(paths, d.path)
+	}
+	return paths, nil
+}
+
+func (f *fakeLibvirt) domainLookupByName(name string) (dbus.ObjectPath, *dbus.Error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if d := f.findDomain(func(d *fakeDomain) bool { return d.def.Name == name }); d != nil {
+		return d.path, nil
+	}
+	return "", fakeError("Domain not found: no domain with matching name '%s'", name)
+}
+
+func (f *fakeLibvirt) domainLookupByUUID(uuid string) (dbus.ObjectPath, *dbus.Error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if d := f.findDomain(func(d *fakeDomain) bool { return d.def.UUID == uuid }); d != nil {
+		return d.path, nil
+	}
+	return "", fakeError("Domain not found: no domain with matching uuid '%s'", uuid)
+}
+
+func (f *fakeLibvirt) domainLookupByID(id int32) (dbus.ObjectPath, *dbus.Error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if d := f.findDomain(func(d *fakeDomain) bool { return d.id == id && d.state != fakeStateShutoff }); d != nil {
+		return d.path, nil
+	}
+	return "", fakeError("Domain not found: no domain with matching id %d", id)
+}
+
+func (f *fakeLibvirt) parseDomain(doc string) (*libvirtxml.Domain, *dbus.Error) {
+	def := new(libvirtxml.Domain)
+	if err := def.Unmarshal(doc); err != nil {
+		return nil, fakeError("XML error: %v", err)
+	}
+	if def.Name == "" {
+		return nil, fakeError("XML error: missing domain name")
+	}
+	if def.UUID == "" {
+		def.UUID = f.newUUID()
+	}
+	if def.Memory == nil {
+		def.Memory = &libvirtxml.DomainMemory{Value: 1048576, Unit: "KiB"}
+	}
+	if def.VCPU == nil {
+		def.VCPU = &libvirtxml.DomainVCPU{Value: 1}
+	}
+	return def, nil
+}
+
+// newUUID makes up a UUID for a definition that has none.
+func (f *fakeLibvirt) newUUID() string {
+	f.serial++
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", f.serial)
+}
+
+func (f *fakeLibvirt) domainDefineXML(doc string) (dbus.ObjectPath, *dbus.Error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	def, derr := f.parseDomain(doc)
+	if derr != nil {
+		return "", derr
+	}
+	if d := f.findDomain(func(d *fakeDomain) bool { return d.def.Name == def.Name }); d != nil {
+		def.UUID = d.def.UUID
+		d.def = def
+		d.persistent = true
+		f.emit("DomainEvent", d.path, int32(DomainEventDefined), int32(DomainEventDefinedUpdated))
+		return d.path, nil
+	}
+	d, err := f.addDomain(def, true, false)
+	if err != nil {
+		return "", fakeError("internal error: %v", err)
+	}
+	f.emit("DomainEvent", d.path, int32(DomainEventDefined), int32(DomainEventDefinedAdded))
+	return d.path, nil
+}
+
+func (f *fakeLibvirt) domainCreateXML(doc string, flags uint32) (dbus.ObjectPath, *dbus.Error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	def, derr := f.parseDomain(doc)
+	if derr != nil {
+		return "", derr
+	}
+	if f.findDomain(func(d *fakeDomain) bool { return d.def.Name == def.Name }) != nil {
+		return "", fakeError("operation failed: domain '%s' already exists", def.Name)
+	}
+	d, err := f.addDomain(def, false, true)
+	if err != nil {
+		return "", fakeError("internal error: %v", err)
+	}
+	f.emit("DomainEvent", d.path, int32(DomainEventStarted), int32(DomainEventStartedBooted))
+	return d.path, nil
+}
+
+type fakeStatsRecord struct {
+	Name  string
+	Stats map[string]dbus.Variant
+}
+
+func (f *fakeLibvirt) getAllDomainStats(stats, flags uint32) ([]fakeStatsRecord, *dbus.Error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	records := make([]fakeStatsRecord, 0, len(f.domains))
+	for _, d := range f.domains {
+		records = append(records, fakeStatsRecord{d.def.Name, f.stats(d, DomainStatsTypes(stats))})
+	}
+	return records, nil
+}
+
+func (f *fakeLibvirt) addNetwork(def *libvirtxml.Network) (*fakeNetwork, error) {
+	n := &fakeNetwork{path: fakePath("network", def.UUID), def: def, persistent: true}
+
+	methods := map[string]interface{}{
+		"Create": func() *dbus.Error {
+			return f.setNetworkActive(n, true, NetworkEventStarted)
+		},
+		"Destroy": func() *dbus.Error {
+			return f.setNetworkActive(n, false, NetworkEventStopped)
+		},
+		"Undefine": func() *dbus.Error {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			if n.active {
+				return fakeError("Requested operation is not valid: network is still active")
+			}
+			for i, other := range f.networks {
+				if other == n {
+					f.networks = append(f.networks[:i], f.networks[i+1:]...)
+					break
+				}
+			}
+			f.unexport(n.path)
+			f.emit("NetworkEvent", n.path, int32(NetworkEventUndefined))
+			return nil
+		},
+		"GetXMLDesc": func(flags uint32) (string, *dbus.Error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			doc, err := n.def.Marshal()
+			if err != nil {
+				return "", fakeError("internal error: %v", err)
+			}
+			return doc, nil
+		},
+	}
+	err := f.exportTable(n.path, "org.libvirt.Network", methods)
+	if err != nil {
+		return nil, err
+	}
+	err = f.exportProperties(n.path, "org.libvirt.Network", map[string]func() interface{}{
+		"Active":     func() interface{} { return n.active },
+		"Autostart":  func() interface{} { return n.autostart },
+		"BridgeName": func() interface{} { return n.def.Bridge.Name },
+		"Name":       func() interface{} { return n.def.Name },
+		"Persistent": func() interface{} { return n.persistent },
+		"UUID":       func() interface{} { return n.def.UUID },
+	}, map[string]func(dbus.Variant) *dbus.Error{
+		"Autostart": func(v dbus.Variant) *dbus.Error { return dbusStore(v, &n.autostart) },
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	f.networks = append(f.networks, n)
+	return n, nil
+}
+
+func (f *fakeLibvirt) setNetworkActive(n *fakeNetwork, active bool, event NetworkEventType) *dbus.Error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if n.active == active {
+		return fakeError("Requested operation is not valid: network is already in the requested state")
+	}
+	n.active = active
+	f.emit("NetworkEvent", n.path, int32(event))
+	return nil
+}
+
+func (f *fakeLibvirt) findNetwork(match func(*fakeNetwork) bool) *fakeNetwork {
+	for _, n := range f.networks {
+		if match(n) {
+			return n
+		}
+	}
+	return nil
+}
+
+func (f *fakeLibvirt) listNetworks(flags uint32) ([]dbus.ObjectPath, *dbus.Error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	paths := make([]dbus.ObjectPath, 0, len(f.networks))
+	for _, n := range f.networks {
+		paths = append(paths, n.path)
+	}
+	return paths, nil
+}
+
+func (f *fakeLibvirt) networkLookupByName(name string) (dbus.ObjectPath, *dbus.Error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if n := f.findNetwork(func(n *fakeNetwork) bool { return n.def.Name == name }); n != nil {
+		return n.path, nil
+	}
+	return "", fakeError("Network not found: no network with matching name '%s'", name)
+}
+
+func (f *fakeLibvirt) networkLookupByUUID(uuid string) (dbus.ObjectPath, *dbus.Error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if n := f.findNetwork(func(n *fakeNetwork) bool { return n.def.UUID == uuid }); n != nil {
+		return n.path, nil
+	}
+	return "", fakeError("Network not found: no network with matching uuid '%s'", uuid)
+}
+
+func (f *fakeLibvirt) networkDefineXML(doc string) (dbus.ObjectPath, *dbus.Error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	def := new(libvirtxml.Network)
+	if err := def.Unmarshal(doc); err != nil {
+		return "", fakeError("XML error: %v", err)
+	}
+	if def.Name == "" {
+		return "", fakeError("XML error: missing network name")
+	}
+	if f.findNetwork(func(n *fakeNetwork) bool { return n.def.Name == def.Name }) != nil {
+		return "", fakeError("operation failed: network '%s' already exists", def.Name)
+	}
+	if def.UUID == "" {
+		def.UUID = f.newUUID()
+	}
+	if def.Bridge == nil {
+		def.Bridge = &libvirtxml.NetworkBridge{Name: fmt.Sprintf("virbr%d", len(f.networks))}
+	}
+	n, err := f.addNetwork(def)
+	if err != nil {
+		return "", fakeError("internal error: %v", err)
+	}
+	f.emit("NetworkEvent", n.path, int32(NetworkEventDefined))
+	return n.path, nil
+}
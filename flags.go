@@ -0,0 +1,730 @@
+package libvirt
+
+// Flags and enum arguments of the generated methods. gen.go's argTypes table
+// says which argument takes which of these types; flags arguments libvirt
+// defines no values for stay uint32 and must be 0.
+
+// ConnectBaselineCPUFlags mirrors virConnectBaselineCPUFlags.
+type ConnectBaselineCPUFlags uint32
+
+const (
+	ConnectBaselineCPUExpandFeatures ConnectBaselineCPUFlags = 1 << iota
+	ConnectBaselineCPUMigratable
+)
+
+// ConnectCompareCPUFlags mirrors virConnectCompareCPUFlags.
+type ConnectCompareCPUFlags uint32
+
+const (
+	ConnectCompareCPUFailIncompatible ConnectCompareCPUFlags = 1 << iota
+	ConnectCompareCPUValidateXML
+)
+
+// ConnectListAllDomainsFlags mirrors virConnectListAllDomainsFlags.
+type ConnectListAllDomainsFlags uint32
+
+const (
+	ConnectListDomainsActive ConnectListAllDomainsFlags = 1 << iota
+	ConnectListDomainsInactive
+	ConnectListDomainsPersistent
+	ConnectListDomainsTransient
+	ConnectListDomainsRunning
+	ConnectListDomainsPaused
+	ConnectListDomainsShutoff
+	ConnectListDomainsOther
+	ConnectListDomainsManagedSave
+	ConnectListDomainsNoManagedSave
+	ConnectListDomainsAutostart
+	ConnectListDomainsNoAutostart
+	ConnectListDomainsHasSnapshot
+	ConnectListDomainsNoSnapshot
+	ConnectListDomainsHasCheckpoint
+	ConnectListDomainsNoCheckpoint
+)
+
+// ConnectListAllInterfacesFlags mirrors virConnectListAllInterfacesFlags.
+type ConnectListAllInterfacesFlags uint32
+
+const (
+	ConnectListInterfacesInactive ConnectListAllInterfacesFlags = 1 << iota
+	ConnectListInterfacesActive
+)
+
+// ConnectListAllNetworksFlags mirrors virConnectListAllNetworksFlags.
+type ConnectListAllNetworksFlags uint32
+
+const (
+	ConnectListNetworksInactive ConnectListAllNetworksFlags = 1 << iota
+	ConnectListNetworksActive
+	ConnectListNetworksPersistent
+	ConnectListNetworksTransient
+	ConnectListNetworksAutostart
+	ConnectListNetworksNoAutostart
+)
+
+// ConnectListAllNodeDeviceFlags mirrors virConnectListAllNodeDeviceFlags.
+type ConnectListAllNodeDeviceFlags uint32
+
+const (
+	ConnectListNodeDevicesCapSystem ConnectListAllNodeDeviceFlags = 1 << iota
+	ConnectListNodeDevicesCapPCIDev
+	ConnectListNodeDevicesCapUSBDev
+	ConnectListNodeDevicesCapUSBInterface
+	ConnectListNodeDevicesCapNet
+	ConnectListNodeDevicesCapSCSIHost
+	ConnectListNodeDevicesCapSCSITarget
+	ConnectListNodeDevicesCapSCSI
+	ConnectListNodeDevicesCapStorage
+	ConnectListNodeDevicesCapFCHost
+	ConnectListNodeDevicesCapVports
+	ConnectListNodeDevicesCapSCSIGeneric
+	ConnectListNodeDevicesCapDRM
+	ConnectListNodeDevicesCapMdevTypes
+	ConnectListNodeDevicesCapMdev
+	ConnectListNodeDevicesCapCCWDev
+)
+
+// ConnectListAllSecretsFlags mirrors virConnectListAllSecretsFlags.
+type ConnectListAllSecretsFlags uint32
+
+const (
+	ConnectListSecretsEphemeral ConnectListAllSecretsFlags = 1 << iota
+	ConnectListSecretsNoEphemeral
+	ConnectListSecretsPrivate
+	ConnectListSecretsNoPrivate
+)
+
+// ConnectListAllStoragePoolsFlags mirrors virConnectListAllStoragePoolsFlags.
+type ConnectListAllStoragePoolsFlags uint32
+
+const (
+	ConnectListStoragePoolsInactive ConnectListAllStoragePoolsFlags = 1 << iota
+	ConnectListStoragePoolsActive
+	ConnectListStoragePoolsPersistent
+	ConnectListStoragePoolsTransient
+	ConnectListStoragePoolsAutostart
+	ConnectListStoragePoolsNoAutostart
+	ConnectListStoragePoolsDir
+	ConnectListStoragePoolsFS
+	ConnectListStoragePoolsNetFS
+	ConnectListStoragePoolsLogical
+	ConnectListStoragePoolsDisk
+	ConnectListStoragePoolsISCSI
+	ConnectListStoragePoolsSCSI
+	ConnectListStoragePoolsMpath
+	ConnectListStoragePoolsRBD
+	ConnectListStoragePoolsSheepdog
+	ConnectListStoragePoolsGluster
+	ConnectListStoragePoolsZFS
+	ConnectListStoragePoolsVstorage
+	ConnectListStoragePoolsISCSIDirect
+)
+
+// DomainStatsTypes mirrors virDomainStatsTypes; it selects the stat groups
+// Connect.GetAllDomainStats and Domain.GetStats return, 0 meaning all of
+// them.
+type DomainStatsTypes uint32
+
+const (
+	DomainStatsState DomainStatsTypes = 1 << iota
+	DomainStatsCPUTotal
+	DomainStatsBalloon
+	DomainStatsVCPU
+	DomainStatsInterface
+	DomainStatsBlock
+	DomainStatsPerf
+	DomainStatsIOThread
+	DomainStatsMemory
+)
+
+// ConnectGetAllDomainStatsFlags mirrors virConnectGetAllDomainStatsFlags.
+type ConnectGetAllDomainStatsFlags uint32
+
+const (
+	ConnectGetAllDomainsStatsActive ConnectGetAllDomainStatsFlags = 1 << iota
+	ConnectGetAllDomainsStatsInactive
+	ConnectGetAllDomainsStatsPersistent
+	ConnectGetAllDomainsStatsTransient
+	ConnectGetAllDomainsStatsRunning
+	ConnectGetAllDomainsStatsPaused
+	ConnectGetAllDomainsStatsShutoff
+	ConnectGetAllDomainsStatsOther
+
+	ConnectGetAllDomainsStatsNowait       ConnectGetAllDomainStatsFlags = 1 << 29
+	ConnectGetAllDomainsStatsBacking      ConnectGetAllDomainStatsFlags = 1 << 30
+	ConnectGetAllDomainsStatsEnforceStats ConnectGetAllDomainStatsFlags = 1 << 31
+)
+
+// Pass these to Connect.NodeGetCPUStats and Connect.NodeGetMemoryStats for
+// the totals of the host instead of a single CPU or NUMA cell.
+const (
+	NodeCPUStatsAllCPUs     = -1
+	NodeMemoryStatsAllCells = -1
+)
+
+// SecretUsageType mirrors virSecretUsageType.
+type SecretUsageType int32
+
+const (
+	SecretUsageTypeNone SecretUsageType = iota
+	SecretUsageTypeVolume
+	SecretUsageTypeCeph
+	SecretUsageTypeISCSI
+	SecretUsageTypeTLS
+	SecretUsageTypeVTPM
+)
+
+// DomainModificationImpact mirrors virDomainModificationImpact, which is
+// what most methods that read or change a domain's tunables take as flags.
+type DomainModificationImpact uint32
+
+const (
+	DomainAffectCurrent DomainModificationImpact = 0
+	DomainAffectLive    DomainModificationImpact = 1 << 0
+	DomainAffectConfig  DomainModificationImpact = 1 << 1
+)
+
+// DomainCreateFlags mirrors virDomainCreateFlags.
+type DomainCreateFlags uint32
+
+const (
+	DomainStartPaused DomainCreateFlags = 1 << iota
+	DomainStartAutodestroy
+	DomainStartBypassCache
+	DomainStartForceBoot
+	DomainStartValidate
+)
+
+// DomainDestroyFlags mirrors virDomainDestroyFlagsValues.
+type DomainDestroyFlags uint32
+
+const (
+	DomainDestroyDefault  DomainDestroyFlags = 0
+	DomainDestroyGraceful DomainDestroyFlags = 1 << 0
+)
+
+// DomainShutdownFlags mirrors virDomainShutdownFlagValues.
+type DomainShutdownFlags uint32
+
+const (
+	DomainShutdownDefault      DomainShutdownFlags = 0
+	DomainShutdownAcpiPowerBtn DomainShutdownFlags = 1 << 0
+	DomainShutdownGuestAgent   DomainShutdownFlags = 1 << 1
+	DomainShutdownInitctl      DomainShutdownFlags = 1 << 2
+	DomainShutdownSignal       DomainShutdownFlags = 1 << 3
+	DomainShutdownParavirt     DomainShutdownFlags = 1 << 4
+)
+
+// DomainRebootFlags mirrors virDomainRebootFlagValues.
+type DomainRebootFlags uint32
+
+const (
+	DomainRebootDefault      DomainRebootFlags = 0
+	DomainRebootAcpiPowerBtn DomainRebootFlags = 1 << 0
+	DomainRebootGuestAgent   DomainRebootFlags = 1 << 1
+	DomainRebootInitctl      DomainRebootFlags = 1 << 2
+	DomainRebootSignal       DomainRebootFlags = 1 << 3
+	DomainRebootParavirt     DomainRebootFlags = 1 << 4
+)
+
+// DomainUndefineFlags mirrors virDomainUndefineFlagsValues.
+type DomainUndefineFlags uint32
+
+const (
+	DomainUndefineManagedSave DomainUndefineFlags = 1 << iota
+	DomainUndefineSnapshotsMetadata
+	DomainUndefineNVRAM
+	DomainUndefineKeepNVRAM
+	DomainUndefineCheckpointsMetadata
+)
+
+// DomainSaveRestoreFlags mirrors virDomainSaveRestoreFlags.
+type DomainSaveRestoreFlags uint32
+
+const (
+	DomainSaveBypassCache DomainSaveRestoreFlags = 1 << iota
+	DomainSaveRunning
+	DomainSavePaused
+)
+
+// DomainXMLFlags mirrors virDomainXMLFlags.
+type DomainXMLFlags uint32
+
+const (
+	DomainXMLSecure DomainXMLFlags = 1 << iota
+	DomainXMLInactive
+	DomainXMLUpdateCPU
+	DomainXMLMigratable
+)
+
+// DomainDeviceModifyFlags mirrors virDomainDeviceModifyFlags.
+type DomainDeviceModifyFlags uint32
+
+const (
+	DomainDeviceModifyCurrent DomainDeviceModifyFlags = 0
+	DomainDeviceModifyLive    DomainDeviceModifyFlags = 1 << 0
+	DomainDeviceModifyConfig  DomainDeviceModifyFlags = 1 << 1
+	DomainDeviceModifyForce   DomainDeviceModifyFlags = 1 << 2
+)
+
+// DomainVcpuFlags mirrors virDomainVcpuFlags.
+type DomainVcpuFlags uint32
+
+const (
+	DomainVcpuCurrent      DomainVcpuFlags = 0
+	DomainVcpuLive         DomainVcpuFlags = 1 << 0
+	DomainVcpuConfig       DomainVcpuFlags = 1 << 1
+	DomainVcpuMaximum      DomainVcpuFlags = 1 << 2
+	DomainVcpuGuest        DomainVcpuFlags = 1 << 3
+	DomainVcpuHotpluggable DomainVcpuFlags = 1 << 4
+)
+
+// DomainMemoryModFlags mirrors virDomainMemoryModFlags.
+type DomainMemoryModFlags uint32
+
+const (
+	DomainMemCurrent DomainMemoryModFlags = 0
+	DomainMemLive    DomainMemoryModFlags = 1 << 0
+	DomainMemConfig  DomainMemoryModFlags = 1 << 1
+	DomainMemMaximum DomainMemoryModFlags = 1 << 2
+)
+
+// DomainMemoryFlags mirrors virDomainMemoryFlags.
+type DomainMemoryFlags uint32
+
+const (
+	DomainMemoryVirtual DomainMemoryFlags = 1 << iota
+	DomainMemoryPhysical
+)
+
+// DomainBlockCommitFlags mirrors virDomainBlockCommitFlags.
+type DomainBlockCommitFlags uint32
+
+const (
+	DomainBlockCommitShallow DomainBlockCommitFlags = 1 << iota
+	DomainBlockCommitDelete
+	DomainBlockCommitActive
+	DomainBlockCommitRelative
+	DomainBlockCommitBandwidthBytes
+)
+
+// DomainBlockCopyFlags mirrors virDomainBlockCopyFlags.
+type DomainBlockCopyFlags uint32
+
+const (
+	DomainBlockCopyShallow DomainBlockCopyFlags = 1 << iota
+	DomainBlockCopyReuseExt
+	DomainBlockCopyTransientJob
+)
+
+// DomainBlockJobAbortFlags mirrors virDomainBlockJobAbortFlags.
+type DomainBlockJobAbortFlags uint32
+
+const (
+	DomainBlockJobAbortAsync DomainBlockJobAbortFlags = 1 << iota
+	DomainBlockJobAbortPivot
+)
+
+// DomainBlockJobSetSpeedFlags mirrors virDomainBlockJobSetSpeedFlags.
+type DomainBlockJobSetSpeedFlags uint32
+
+const (
+	DomainBlockJobSpeedBandwidthBytes DomainBlockJobSetSpeedFlags = 1 << 0
+)
+
+// DomainBlockJobInfoFlags mirrors virDomainBlockJobInfoFlags.
+type DomainBlockJobInfoFlags uint32
+
+const (
+	DomainBlockJobInfoBandwidthBytes DomainBlockJobInfoFlags = 1 << 0
+)
+
+// DomainBlockPullFlags mirrors virDomainBlockPullFlags.
+type DomainBlockPullFlags uint32
+
+const (
+	DomainBlockPullBandwidthBytes DomainBlockPullFlags = 1 << 6
+)
+
+// DomainBlockRebaseFlags mirrors virDomainBlockRebaseFlags.
+type DomainBlockRebaseFlags uint32
+
+const (
+	DomainBlockRebaseShallow DomainBlockRebaseFlags = 1 << iota
+	DomainBlockRebaseReuseExt
+	DomainBlockRebaseCopyRaw
+	DomainBlockRebaseCopy
+	DomainBlockRebaseRelative
+	DomainBlockRebaseCopyDev
+	DomainBlockRebaseBandwidthBytes
+)
+
+// DomainBlockResizeFlags mirrors virDomainBlockResizeFlags.
+type DomainBlockResizeFlags uint32
+
+const (
+	DomainBlockResizeBytes DomainBlockResizeFlags = 1 << 0
+)
+
+// DomainCoreDumpFormat mirrors virDomainCoreDumpFormat.
+type DomainCoreDumpFormat uint32
+
+const (
+	DomainCoreDumpFormatRaw DomainCoreDumpFormat = iota
+	DomainCoreDumpFormatKdumpZlib
+	DomainCoreDumpFormatKdumpLzo
+	DomainCoreDumpFormatKdumpSnappy
+)
+
+// DomainCoreDumpFlags mirrors virDomainCoreDumpFlags.
+type DomainCoreDumpFlags uint32
+
+const (
+	DomainDumpCrash DomainCoreDumpFlags = 1 << iota
+	DomainDumpLive
+	DomainDumpBypassCache
+	DomainDumpReset
+	DomainDumpMemoryOnly
+)
+
+// DomainGetHostnameFlags mirrors virDomainGetHostnameFlags.
+type DomainGetHostnameFlags uint32
+
+const (
+	DomainGetHostnameLease DomainGetHostnameFlags = 1 << iota
+	DomainGetHostnameAgent
+)
+
+// DomainGetJobStatsFlags mirrors virDomainGetJobStatsFlags.
+type DomainGetJobStatsFlags uint32
+
+const (
+	DomainJobStatsCompleted DomainGetJobStatsFlags = 1 << iota
+	DomainJobStatsKeepCompleted
+)
+
+// DomainMetadataType mirrors virDomainMetadataType.
+type DomainMetadataType int32
+
+const (
+	DomainMetadataDescription DomainMetadataType = iota
+	DomainMetadataTitle
+	DomainMetadataElement
+)
+
+// DomainInterfaceAddressesSource mirrors virDomainInterfaceAddressesSource.
+type DomainInterfaceAddressesSource uint32
+
+const (
+	DomainInterfaceAddressesSrcLease DomainInterfaceAddressesSource = iota
+	DomainInterfaceAddressesSrcAgent
+	DomainInterfaceAddressesSrcARP
+)
+
+// DomainMigrateFlags mirrors virDomainMigrateFlags.
+type DomainMigrateFlags uint32
+
+const (
+	DomainMigrateLive DomainMigrateFlags = 1 << iota
+	DomainMigratePeer2Peer
+	DomainMigrateTunnelled
+	DomainMigratePersistDest
+	DomainMigrateUndefineSource
+	DomainMigratePaused
+	DomainMigrateNonSharedDisk
+	DomainMigrateNonSharedInc
+	DomainMigrateChangeProtection
+	DomainMigrateUnsafe
+	DomainMigrateOffline
+	DomainMigrateCompressed
+	DomainMigrateAbortOnError
+	DomainMigrateAutoConverge
+	DomainMigrateRDMAPinAll
+	DomainMigratePostcopy
+	DomainMigrateTLS
+	DomainMigrateParallel
+)
+
+// DomainMigrateMaxSpeedFlags mirrors virDomainMigrateMaxSpeedFlags.
+type DomainMigrateMaxSpeedFlags uint32
+
+const (
+	DomainMigrateMaxSpeedPostcopy DomainMigrateMaxSpeedFlags = 1 << 0
+)
+
+// DomainOpenGraphicsFlags mirrors virDomainOpenGraphicsFlags.
+type DomainOpenGraphicsFlags uint32
+
+const (
+	DomainOpenGraphicsSkipauth DomainOpenGraphicsFlags = 1 << 0
+)
+
+// KeycodeSet mirrors virKeycodeSet.
+type KeycodeSet uint32
+
+const (
+	KeycodeSetLinux KeycodeSet = iota
+	KeycodeSetXT
+	KeycodeSetATSet1
+	KeycodeSetATSet2
+	KeycodeSetATSet3
+	KeycodeSetOSX
+	KeycodeSetXTKbd
+	KeycodeSetUSB
+	KeycodeSetWin32
+	KeycodeSetQNum
+)
+
+// DomainProcessSignal mirrors virDomainProcessSignal. The real-time signals
+// follow DomainProcessSignalSys and have no names here.
+type DomainProcessSignal uint32
+
+const (
+	DomainProcessSignalNop DomainProcessSignal = iota
+	DomainProcessSignalHup
+	DomainProcessSignalInt
+	DomainProcessSignalQuit
+	DomainProcessSignalIll
+	DomainProcessSignalTrap
+	DomainProcessSignalAbrt
+	DomainProcessSignalBus
+	DomainProcessSignalFpe
+	DomainProcessSignalKill
+	DomainProcessSignalUsr1
+	DomainProcessSignalSegv
+	DomainProcessSignalUsr2
+	DomainProcessSignalPipe
+	DomainProcessSignalAlrm
+	DomainProcessSignalTerm
+	DomainProcessSignalStkflt
+	DomainProcessSignalChld
+	DomainProcessSignalCont
+	DomainProcessSignalStop
+	DomainProcessSignalTstp
+	DomainProcessSignalTtin
+	DomainProcessSignalTtou
+	DomainProcessSignalUrg
+	DomainProcessSignalXcpu
+	DomainProcessSignalXfsz
+	DomainProcessSignalVtalrm
+	DomainProcessSignalProf
+	DomainProcessSignalWinch
+	DomainProcessSignalPoll
+	DomainProcessSignalPwr
+	DomainProcessSignalSys
+)
+
+// DomainSetTimeFlags mirrors virDomainSetTimeFlags.
+type DomainSetTimeFlags uint32
+
+const (
+	DomainTimeSync DomainSetTimeFlags = 1 << 0
+)
+
+// DomainSetUserPasswordFlags mirrors virDomainSetUserPasswordFlags.
+type DomainSetUserPasswordFlags uint32
+
+const (
+	DomainPasswordEncrypted DomainSetUserPasswordFlags = 1 << 0
+)
+
+// DomainSnapshotCreateFlags mirrors virDomainSnapshotCreateFlags.
+type DomainSnapshotCreateFlags uint32
+
+const (
+	DomainSnapshotCreateRedefine DomainSnapshotCreateFlags = 1 << iota
+	DomainSnapshotCreateCurrent
+	DomainSnapshotCreateNoMetadata
+	DomainSnapshotCreateHalt
+	DomainSnapshotCreateDiskOnly
+	DomainSnapshotCreateReuseExt
+	DomainSnapshotCreateQuiesce
+	DomainSnapshotCreateAtomic
+	DomainSnapshotCreateLive
+	DomainSnapshotCreateValidate
+)
+
+// DomainSnapshotListFlags mirrors virDomainSnapshotListFlags.
+// DomainSnapshotListRoots is only valid for Domain.ListDomainSnapshots and
+// DomainSnapshotListDescendants, which has the same value, only for
+// DomainSnapshot.ListChildren.
+type DomainSnapshotListFlags uint32
+
+const (
+	DomainSnapshotListDescendants DomainSnapshotListFlags = 1 << 0
+	DomainSnapshotListRoots       DomainSnapshotListFlags = 1 << 0
+	DomainSnapshotListMetadata    DomainSnapshotListFlags = 1 << 1
+	DomainSnapshotListLeaves      DomainSnapshotListFlags = 1 << 2
+	DomainSnapshotListNoLeaves    DomainSnapshotListFlags = 1 << 3
+	DomainSnapshotListNoMetadata  DomainSnapshotListFlags = 1 << 4
+	DomainSnapshotListInactive    DomainSnapshotListFlags = 1 << 5
+	DomainSnapshotListActive      DomainSnapshotListFlags = 1 << 6
+	DomainSnapshotListDiskOnly    DomainSnapshotListFlags = 1 << 7
+	DomainSnapshotListInternal    DomainSnapshotListFlags = 1 << 8
+	DomainSnapshotListExternal    DomainSnapshotListFlags = 1 << 9
+	DomainSnapshotListTopological DomainSnapshotListFlags = 1 << 10
+)
+
+// DomainSnapshotDeleteFlags mirrors virDomainSnapshotDeleteFlags.
+type DomainSnapshotDeleteFlags uint32
+
+const (
+	DomainSnapshotDeleteChildren DomainSnapshotDeleteFlags = 1 << iota
+	DomainSnapshotDeleteMetadataOnly
+	DomainSnapshotDeleteChildrenOnly
+)
+
+// DomainSnapshotRevertFlags mirrors virDomainSnapshotRevertFlags.
+type DomainSnapshotRevertFlags uint32
+
+const (
+	DomainSnapshotRevertRunning DomainSnapshotRevertFlags = 1 << iota
+	DomainSnapshotRevertPaused
+	DomainSnapshotRevertForce
+)
+
+// DomainSnapshotXMLFlags mirrors virDomainSnapshotXMLFlags.
+type DomainSnapshotXMLFlags uint32
+
+const (
+	DomainSnapshotXMLSecure DomainSnapshotXMLFlags = 1 << 0
+)
+
+// InterfaceXMLFlags mirrors virInterfaceXMLFlags.
+type InterfaceXMLFlags uint32
+
+const (
+	InterfaceXMLInactive InterfaceXMLFlags = 1 << 0
+)
+
+// NetworkXMLFlags mirrors virNetworkXMLFlags.
+type NetworkXMLFlags uint32
+
+const (
+	NetworkXMLInactive NetworkXMLFlags = 1 << 0
+)
+
+// NetworkUpdateCommand mirrors virNetworkUpdateCommand.
+type NetworkUpdateCommand uint32
+
+const (
+	NetworkUpdateCommandNone NetworkUpdateCommand = iota
+	NetworkUpdateCommandModify
+	NetworkUpdateCommandDelete
+	NetworkUpdateCommandAddLast
+	NetworkUpdateCommandAddFirst
+)
+
+// NetworkUpdateSection mirrors virNetworkUpdateSection.
+type NetworkUpdateSection uint32
+
+const (
+	NetworkSectionNone NetworkUpdateSection = iota
+	NetworkSectionBridge
+	NetworkSectionDomain
+	NetworkSectionIP
+	NetworkSectionIPDHCPHost
+	NetworkSectionIPDHCPRange
+	NetworkSectionForward
+	NetworkSectionForwardInterface
+	NetworkSectionForwardPF
+	NetworkSectionPortgroup
+	NetworkSectionDNSHost
+	NetworkSectionDNSTxt
+	NetworkSectionDNSSrv
+)
+
+// NetworkUpdateFlags mirrors virNetworkUpdateFlags.
+type NetworkUpdateFlags uint32
+
+const (
+	NetworkUpdateAffectCurrent NetworkUpdateFlags = 0
+	NetworkUpdateAffectLive    NetworkUpdateFlags = 1 << 0
+	NetworkUpdateAffectConfig  NetworkUpdateFlags = 1 << 1
+)
+
+// StoragePoolCreateFlags mirrors virStoragePoolCreateFlags.
+type StoragePoolCreateFlags uint32
+
+const (
+	StoragePoolCreateNormal               StoragePoolCreateFlags = 0
+	StoragePoolCreateWithBuild            StoragePoolCreateFlags = 1 << 0
+	StoragePoolCreateWithBuildOverwrite   StoragePoolCreateFlags = 1 << 1
+	StoragePoolCreateWithBuildNoOverwrite StoragePoolCreateFlags = 1 << 2
+)
+
+// StoragePoolBuildFlags mirrors virStoragePoolBuildFlags.
+type StoragePoolBuildFlags uint32
+
+const (
+	StoragePoolBuildNew         StoragePoolBuildFlags = 0
+	StoragePoolBuildRepair      StoragePoolBuildFlags = 1 << 0
+	StoragePoolBuildResize      StoragePoolBuildFlags = 1 << 1
+	StoragePoolBuildNoOverwrite StoragePoolBuildFlags = 1 << 2
+	StoragePoolBuildOverwrite   StoragePoolBuildFlags = 1 << 3
+)
+
+// StoragePoolDeleteFlags mirrors virStoragePoolDeleteFlags.
+type StoragePoolDeleteFlags uint32
+
+const (
+	StoragePoolDeleteNormal StoragePoolDeleteFlags = 0
+	StoragePoolDeleteZeroed StoragePoolDeleteFlags = 1 << 0
+)
+
+// StorageXMLFlags mirrors virStorageXMLFlags.
+type StorageXMLFlags uint32
+
+const (
+	StorageXMLInactive StorageXMLFlags = 1 << 0
+)
+
+// StorageVolCreateFlags mirrors virStorageVolCreateFlags.
+type StorageVolCreateFlags uint32
+
+const (
+	StorageVolCreatePreallocMetadata StorageVolCreateFlags = 1 << iota
+	StorageVolCreateReflink
+)
+
+// StorageVolDeleteFlags mirrors virStorageVolDeleteFlags.
+type StorageVolDeleteFlags uint32
+
+const (
+	StorageVolDeleteNormal        StorageVolDeleteFlags = 0
+	StorageVolDeleteZeroed        StorageVolDeleteFlags = 1 << 0
+	StorageVolDeleteWithSnapshots StorageVolDeleteFlags = 1 << 1
+)
+
+// StorageVolInfoFlags mirrors virStorageVolInfoFlags.
+type StorageVolInfoFlags uint32
+
+const (
+	StorageVolUseAllocation StorageVolInfoFlags = 0
+	StorageVolGetPhysical   StorageVolInfoFlags = 1 << 0
+)
+
+// StorageVolResizeFlags mirrors virStorageVolResizeFlags.
+type StorageVolResizeFlags uint32
+
+const (
+	StorageVolResizeAllocate StorageVolResizeFlags = 1 << iota
+	StorageVolResizeDelta
+	StorageVolResizeShrink
+)
+
+// StorageVolWipeAlgorithm mirrors virStorageVolWipeAlgorithm.
+type StorageVolWipeAlgorithm uint32
+
+const (
+	StorageVolWipeAlgZero StorageVolWipeAlgorithm = iota
+	StorageVolWipeAlgNNSA
+	StorageVolWipeAlgDOD
+	StorageVolWipeAlgBSI
+	StorageVolWipeAlgGutmann
+	StorageVolWipeAlgSchneier
+	StorageVolWipeAlgPfitzner7
+	StorageVolWipeAlgPfitzner33
+	StorageVolWipeAlgRandom
+	StorageVolWipeAlgTrim
+)
@@ -4,12 +4,14 @@ package main
 
 import (
 	"encoding/xml"
+	"flag"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 
 	"text/template"
@@ -30,12 +32,378 @@ func ifc2obj(ifc string) string {
 	return "/" + strings.Replace(ifc, ".", "/", -1)
 }
 
+// structType names the Go type generated for a D-Bus struct signature and
+// its fields, in signature order.
+type structType struct {
+	Name   string
+	Fields []string
+}
+
+// structTypes maps "Interface.Member.arg" (or "Interface.Property") to the
+// Go struct used for it; signatures not listed here stay interface{}.
+var structTypes = map[string]structType{
+	"Connect.GetAllDomainStats.records":          {"DomainStatsRecord", []string{"Domain", "Stats"}},
+	"Connect.NodeGetSecurityModel.secModel":      {"NodeSecurityModel", []string{"Model", "DOI"}},
+	"Domain.GetDiskErrors.diskErrors":            {"DomainDiskError", []string{"Disk", "Error"}},
+	"Domain.GetFSInfo.fsInfo":                    {"DomainFSInfo", []string{"MountPoint", "Name", "FSType", "DevAliases"}},
+	"Domain.GetIOThreadInfo.ioThreadInfo":        {"DomainIOThreadInfo", []string{"IOThreadID", "CPUMap"}},
+	"Domain.GetSecurityLabelList.securityLabels": {"DomainSecurityLabel", []string{"Label", "Enforcing"}},
+	"Domain.InterfaceAddresses.ifaces":           {"DomainInterface", []string{"Name", "HwAddr", "Addrs"}},
+	"DomainInterface.Addrs":                      {"DomainIPAddress", []string{"Type", "Addr", "Prefix"}},
+	"Network.GetDHCPLeases.leases": {"NetworkDHCPLease", []string{"Iface", "ExpiryTime", "Type", "Mac",
+		"Iaid", "IPAddr", "Prefix", "Hostname", "ClientID"}},
+	"Domain.GetBlockJobInfo.blockJobInfo": {"DomainBlockJobInfo", []string{"Type", "Bandwidth", "Cur", "End"}},
+	"Domain.GetControlInfo.controlInfo":   {"DomainControlInfo", []string{"State", "Details", "StateTime"}},
+	"Domain.GetJobInfo.jobInfo": {"DomainJobInfo", []string{"Type", "TimeElapsed", "TimeRemaining",
+		"DataTotal", "DataProcessed", "DataRemaining", "MemTotal", "MemProcessed", "MemRemaining",
+		"FileTotal", "FileProcessed", "FileRemaining"}},
+	"Domain.GetJobStats.stats":   {"DomainJobStats", []string{"Type", "Stats"}},
+	"Domain.GetState.state":      {"DomainState", []string{"State", "Reason"}},
+	"Domain.GetTime.time":        {"DomainTime", []string{"Seconds", "Nseconds"}},
+	"Domain.Graphics.identities": {"DomainGraphicsIdentity", []string{"Type", "Name"}},
+	"Domain.Graphics.local":      {"DomainGraphicsAddress", []string{"Family", "Node", "Service"}},
+	"Domain.Graphics.remote":     {"DomainGraphicsAddress", []string{"Family", "Node", "Service"}},
+	"Domain.SchedulerType":       {"DomainSchedulerType", []string{"Type", "NParams"}},
+	"StoragePool.GetInfo.info":   {"StoragePoolInfo", []string{"State", "Capacity", "Allocation", "Available"}},
+	"StorageVol.GetInfo.info":    {"StorageVolInfo", []string{"Type", "Capacity", "Allocation"}},
+}
+
+// lifecycleSignals are decoded by hand in events.go, by
+// Subscribe<Type>Lifecycle into a <Type>LifecycleEvent for the type given
+// here. Only their channel variants are generated.
+var lifecycleSignals = map[string]string{
+	"Connect.DomainEvent":      "Domain",
+	"Connect.NetworkEvent":     "Network",
+	"Connect.NodeDeviceEvent":  "NodeDevice",
+	"Connect.SecretEvent":      "Secret",
+	"Connect.StoragePoolEvent": "StoragePool",
+}
+
+// objectTypes maps the names libvirt-dbus gives object path arguments (or
+// "Interface.Member.arg" where the name alone is ambiguous) to the
+// generated type wrapping the object.
+var objectTypes = map[string]string{
+	"DomainSnapshot.GetParent.parent": "DomainSnapshot",
+
+	"domain":       "Domain",
+	"domains":      "Domain",
+	"interface":    "Interface",
+	"interfaces":   "Interface",
+	"network":      "Network",
+	"networks":     "Network",
+	"dev":          "NodeDevice",
+	"devs":         "NodeDevice",
+	"nwfilter":     "NWFilter",
+	"nwfilters":    "NWFilter",
+	"secret":       "Secret",
+	"secrets":      "Secret",
+	"storagePool":  "StoragePool",
+	"storagePools": "StoragePool",
+	"storageVol":   "StorageVol",
+	"storageVols":  "StorageVol",
+	"snapshot":     "DomainSnapshot",
+	"snapshots":    "DomainSnapshot",
+}
+
+// argTypes maps "Interface.Member.arg" to the named type from flags.go used
+// for a flags or enum argument; arguments not listed here keep the plain
+// integer type of their signature.
+var argTypes = map[string]string{
+	"Connect.BaselineCPU.flags":                 "ConnectBaselineCPUFlags",
+	"Connect.CompareCPU.flags":                  "ConnectCompareCPUFlags",
+	"Connect.DomainCreateXML.flags":             "DomainCreateFlags",
+	"Connect.DomainCreateXMLWithFiles.flags":    "DomainCreateFlags",
+	"Connect.DomainRestore.flags":               "DomainSaveRestoreFlags",
+	"Connect.DomainSaveImageDefineXML.flags":    "DomainSaveRestoreFlags",
+	"Connect.DomainSaveImageGetXMLDesc.flags":   "DomainXMLFlags",
+	"Connect.GetAllDomainStats.stats":           "DomainStatsTypes",
+	"Connect.GetAllDomainStats.flags":           "ConnectGetAllDomainStatsFlags",
+	"Connect.ListDomains.flags":                 "ConnectListAllDomainsFlags",
+	"Connect.ListInterfaces.flags":              "ConnectListAllInterfacesFlags",
+	"Connect.ListNetworks.flags":                "ConnectListAllNetworksFlags",
+	"Connect.ListNodeDevices.flags":             "ConnectListAllNodeDeviceFlags",
+	"Connect.ListSecrets.flags":                 "ConnectListAllSecretsFlags",
+	"Connect.ListStoragePools.flags":            "ConnectListAllStoragePoolsFlags",
+	"Connect.SecretLookupByUsage.usageType":     "SecretUsageType",
+	"Connect.StoragePoolCreateXML.flags":        "StoragePoolCreateFlags",
+	"Domain.AddIOThread.flags":                  "DomainModificationImpact",
+	"Domain.AttachDevice.flags":                 "DomainDeviceModifyFlags",
+	"Domain.BlockCommit.flags":                  "DomainBlockCommitFlags",
+	"Domain.BlockCopy.flags":                    "DomainBlockCopyFlags",
+	"Domain.BlockJobAbort.flags":                "DomainBlockJobAbortFlags",
+	"Domain.BlockJobSetSpeed.flags":             "DomainBlockJobSetSpeedFlags",
+	"Domain.BlockPull.flags":                    "DomainBlockPullFlags",
+	"Domain.BlockRebase.flags":                  "DomainBlockRebaseFlags",
+	"Domain.BlockResize.flags":                  "DomainBlockResizeFlags",
+	"Domain.CoreDump.dumpformat":                "DomainCoreDumpFormat",
+	"Domain.CoreDump.flags":                     "DomainCoreDumpFlags",
+	"Domain.Create.flags":                       "DomainCreateFlags",
+	"Domain.CreateWithFiles.flags":              "DomainCreateFlags",
+	"Domain.DelIOThread.flags":                  "DomainModificationImpact",
+	"Domain.Destroy.flags":                      "DomainDestroyFlags",
+	"Domain.DetachDevice.flags":                 "DomainDeviceModifyFlags",
+	"Domain.GetBlockIOParameters.flags":         "DomainModificationImpact",
+	"Domain.GetBlockIOTune.flags":               "DomainModificationImpact",
+	"Domain.GetBlockJobInfo.flags":              "DomainBlockJobInfoFlags",
+	"Domain.GetEmulatorPinInfo.flags":           "DomainModificationImpact",
+	"Domain.GetHostname.flags":                  "DomainGetHostnameFlags",
+	"Domain.GetInterfaceParameters.flags":       "DomainModificationImpact",
+	"Domain.GetIOThreadInfo.flags":              "DomainModificationImpact",
+	"Domain.GetJobStats.flags":                  "DomainGetJobStatsFlags",
+	"Domain.GetMemoryParameters.flags":          "DomainModificationImpact",
+	"Domain.GetMetadata.type":                   "DomainMetadataType",
+	"Domain.GetMetadata.flags":                  "DomainModificationImpact",
+	"Domain.GetNumaParameters.flags":            "DomainModificationImpact",
+	"Domain.GetPerfEvents.flags":                "DomainModificationImpact",
+	"Domain.GetSchedulerParameters.flags":       "DomainModificationImpact",
+	"Domain.GetStats.stats":                     "DomainStatsTypes",
+	"Domain.GetStats.flags":                     "ConnectGetAllDomainStatsFlags",
+	"Domain.GetVcpuPinInfo.flags":               "DomainModificationImpact",
+	"Domain.GetVcpus.flags":                     "DomainVcpuFlags",
+	"Domain.GetXMLDesc.flags":                   "DomainXMLFlags",
+	"Domain.InterfaceAddresses.source":          "DomainInterfaceAddressesSource",
+	"Domain.ListDomainSnapshots.flags":          "DomainSnapshotListFlags",
+	"Domain.ManagedSave.flags":                  "DomainSaveRestoreFlags",
+	"Domain.MemoryPeek.flags":                   "DomainMemoryFlags",
+	"Domain.MigrateGetMaxSpeed.flags":           "DomainMigrateMaxSpeedFlags",
+	"Domain.MigrateSetMaxSpeed.flags":           "DomainMigrateMaxSpeedFlags",
+	"Domain.MigrateToURI3.flags":                "DomainMigrateFlags",
+	"Domain.OpenGraphicsFD.flags":               "DomainOpenGraphicsFlags",
+	"Domain.PinEmulator.flags":                  "DomainModificationImpact",
+	"Domain.PinIOThread.flags":                  "DomainModificationImpact",
+	"Domain.PinVcpu.flags":                      "DomainModificationImpact",
+	"Domain.Reboot.flags":                       "DomainRebootFlags",
+	"Domain.Save.flags":                         "DomainSaveRestoreFlags",
+	"Domain.SendKey.codeset":                    "KeycodeSet",
+	"Domain.SendProcessSignal.sigNum":           "DomainProcessSignal",
+	"Domain.SetBlockIOParameters.flags":         "DomainModificationImpact",
+	"Domain.SetBlockIOTune.flags":               "DomainModificationImpact",
+	"Domain.SetInterfaceParameters.flags":       "DomainModificationImpact",
+	"Domain.SetMemory.flags":                    "DomainMemoryModFlags",
+	"Domain.SetMemoryParameters.flags":          "DomainModificationImpact",
+	"Domain.SetMemoryStatsPeriod.flags":         "DomainMemoryModFlags",
+	"Domain.SetMetadata.type":                   "DomainMetadataType",
+	"Domain.SetMetadata.flags":                  "DomainModificationImpact",
+	"Domain.SetNumaParameters.flags":            "DomainModificationImpact",
+	"Domain.SetPerfEvents.flags":                "DomainModificationImpact",
+	"Domain.SetSchedulerParameters.flags":       "DomainModificationImpact",
+	"Domain.SetTime.flags":                      "DomainSetTimeFlags",
+	"Domain.SetUserPassword.flags":              "DomainSetUserPasswordFlags",
+	"Domain.SetVcpus.flags":                     "DomainVcpuFlags",
+	"Domain.Shutdown.flags":                     "DomainShutdownFlags",
+	"Domain.SnapshotCreateXML.flags":            "DomainSnapshotCreateFlags",
+	"Domain.Undefine.flags":                     "DomainUndefineFlags",
+	"Domain.UpdateDevice.flags":                 "DomainDeviceModifyFlags",
+	"DomainSnapshot.Delete.flags":               "DomainSnapshotDeleteFlags",
+	"DomainSnapshot.GetXMLDesc.flags":           "DomainSnapshotXMLFlags",
+	"DomainSnapshot.ListChildren.flags":         "DomainSnapshotListFlags",
+	"DomainSnapshot.Revert.flags":               "DomainSnapshotRevertFlags",
+	"Interface.GetXMLDesc.flags":                "InterfaceXMLFlags",
+	"Network.GetXMLDesc.flags":                  "NetworkXMLFlags",
+	"Network.Update.command":                    "NetworkUpdateCommand",
+	"Network.Update.section":                    "NetworkUpdateSection",
+	"Network.Update.flags":                      "NetworkUpdateFlags",
+	"StoragePool.Build.flags":                   "StoragePoolBuildFlags",
+	"StoragePool.Create.flags":                  "StoragePoolCreateFlags",
+	"StoragePool.Delete.flags":                  "StoragePoolDeleteFlags",
+	"StoragePool.GetXMLDesc.flags":              "StorageXMLFlags",
+	"StoragePool.StorageVolCreateXML.flags":     "StorageVolCreateFlags",
+	"StoragePool.StorageVolCreateXMLFrom.flags": "StorageVolCreateFlags",
+	"StorageVol.Delete.flags":                   "StorageVolDeleteFlags",
+	"StorageVol.GetInfo.flags":                  "StorageVolInfoFlags",
+	"StorageVol.Resize.flags":                   "StorageVolResizeFlags",
+	"StorageVol.Wipe.pattern":                   "StorageVolWipeAlgorithm",
+}
+
+// paramTypes maps "Interface.Member.arg" of typed parameter (a{sv})
+// arguments to the struct from params.go used for them; the others stay
+// map[string]interface{}.
+var paramTypes = map[string]string{
+	"Domain.BlockCopy.params":                           "DomainBlockCopyParameters",
+	"Domain.GetBlockIOParameters.BlkioParameters":       "DomainBlockIOParameters",
+	"Domain.GetBlockIOTune.blockIOTune":                 "DomainBlockIOTune",
+	"Domain.GetInterfaceParameters.interfaceParameters": "DomainInterfaceParameters",
+	"Domain.GetMemoryParameters.memoryParameters":       "DomainMemoryParameters",
+	"Domain.GetNumaParameters.numaParameters":           "DomainNumaParameters",
+	"Domain.GetPerfEvents.perfEvents":                   "DomainPerfEvents",
+	"Domain.GetSchedulerParameters.SchedulerParameters": "DomainSchedulerParameters",
+	"Domain.MigrateToURI3.params":                       "DomainMigrateParameters",
+	"Domain.SetBlockIOParameters.params":                "DomainBlockIOParameters",
+	"Domain.SetBlockIOTune.params":                      "DomainBlockIOTune",
+	"Domain.SetInterfaceParameters.params":              "DomainInterfaceParameters",
+	"Domain.SetMemoryParameters.params":                 "DomainMemoryParameters",
+	"Domain.SetNumaParameters.params":                   "DomainNumaParameters",
+	"Domain.SetPerfEvents.params":                       "DomainPerfEvents",
+	"Domain.SetSchedulerParameters.params":              "DomainSchedulerParameters",
+}
+
+// findParamOut returns the typed parameter struct member returns, or nil
+// if it returns anything else or more than that.
+func findParamOut(member string, args []introspect.Arg) *structField {
+	var out *structField
+	for _, a := range args {
+		if a.Direction != "out" {
+			continue
+		}
+		typ, ok := paramTypes[member+"."+a.Name]
+		if out != nil || !ok {
+			return nil
+		}
+		out = &structField{Name: a.Name, Type: typ}
+	}
+	return out
+}
+
+// objectOut describes the single object path returned by a method.
+type objectOut struct {
+	Name   string
+	Type   string
+	Slice  bool
+	Suffix string
+}
+
+// findObjectOut returns the object path member returns, or nil if it
+// returns anything else or more than that.
+func findObjectOut(member string, args []introspect.Arg) *objectOut {
+	var out *objectOut
+	for _, a := range args {
+		if a.Direction != "out" {
+			continue
+		}
+		typ, ok := objectTypes[member+"."+a.Name]
+		if !ok {
+			typ, ok = objectTypes[a.Name]
+		}
+		if out != nil || !ok || (a.Type != "o" && a.Type != "ao") {
+			return nil
+		}
+		name := a.Name
+		if getKeyword(name) {
+			name = "o" + name
+		}
+		out = &objectOut{Name: name, Type: typ, Slice: a.Type == "ao", Suffix: "Object"}
+		if out.Slice {
+			out.Suffix = "Objects"
+		}
+	}
+	return out
+}
+
+// findObjectProp returns the object path property prop of iface holds,
+// or nil if it holds anything else. Properties are looked up in
+// objectTypes as "Interface.Property" or by their name with a lower-case
+// first letter, like arguments.
+func findObjectProp(iface string, prop introspect.Property) *objectOut {
+	if prop.Type != "o" && prop.Type != "ao" {
+		return nil
+	}
+	typ, ok := objectTypes[iface+"."+prop.Name]
+	if !ok {
+		typ, ok = objectTypes[strings.ToLower(prop.Name[:1])+prop.Name[1:]]
+	}
+	if !ok {
+		return nil
+	}
+	out := &objectOut{Name: "v", Type: typ, Slice: prop.Type == "ao", Suffix: "Object"}
+	if out.Slice {
+		out.Suffix = "Objects"
+	}
+	return out
+}
+
+type structField struct {
+	Name string
+	Type string
+}
+
+type structDef struct {
+	Name   string
+	Doc    string
+	Fields []structField
+}
+
+// splitSig splits a struct signature like "(ia{sv})" into the signatures of
+// its members.
+func splitSig(sig string) []string {
+	var ret []string
+	sig = strings.TrimSuffix(strings.TrimPrefix(sig, "("), ")")
+	for len(sig) > 0 {
+		n := sigLen(sig)
+		ret = append(ret, sig[:n])
+		sig = sig[n:]
+	}
+	return ret
+}
+
+// sigLen returns the length of the first complete type in sig. It is a
+// copy of sigLen in subscription.go, which gen.go cannot import; change
+// both together.
+func sigLen(sig string) int {
+	switch sig[0] {
+	case 'a':
+		return 1 + sigLen(sig[1:])
+	case '(', '{':
+		depth := 0
+		for i, c := range sig {
+			switch c {
+			case '(', '{':
+				depth++
+			case ')', '}':
+				depth--
+				if depth == 0 {
+					return i + 1
+				}
+			}
+		}
+	}
+	return 1
+}
+
+// collectStructs appends the struct types used by sig (found under key) to
+// defs, nested ones first. source tells how the value reaches the caller
+// ("returned by" or "sent with") and is empty for struct fields and
+// properties.
+func collectStructs(defs []structDef, seen map[string]bool, key string, sig string, source string) []structDef {
+	elem := strings.HasPrefix(sig, "a")
+	sig = strings.TrimLeft(sig, "a")
+	if !strings.HasPrefix(sig, "(") {
+		return defs
+	}
+	st, ok := structTypes[key]
+	if !ok || seen[st.Name] {
+		return defs
+	}
+	seen[st.Name] = true
+	parts := strings.Split(key, ".")
+	def := structDef{Name: st.Name}
+	switch {
+	case len(parts) == 3 && elem:
+		def.Doc = "is an element of the " + parts[2] + " " + source + " " + parts[0] + "." + parts[1] + "."
+	case len(parts) == 3:
+		def.Doc = "holds the " + parts[2] + " " + source + " " + parts[0] + "." + parts[1] + "."
+	case elem:
+		def.Doc = "is an element of " + parts[0] + "." + parts[1] + "."
+	default:
+		def.Doc = "holds the " + parts[1] + " of " + parts[0] + "."
+	}
+	for i, fsig := range splitSig(sig) {
+		name := st.Fields[i]
+		defs = collectStructs(defs, seen, st.Name+"."+name, fsig, "")
+		dtype, _ := GuessType(name, fsig, st.Name)
+		def.Fields = append(def.Fields, structField{name, dtype})
+	}
+	return append(defs, def)
+}
+
 func GuessType(val string, arg string, obj string) (string, string) {
 	var rtype string
 	var robj string
 	switch arg[0] {
 	case 'a':
-		if arg[1] == '{' {
+		if name, ok := paramTypes[obj+"."+val]; ok && arg == "a{sv}" {
+			rtype = "*" + name
+			robj = obj
+		} else if arg[1] == '{' {
 			rtype += "map["
 			dtype, _ := GuessType(val, arg[2:], obj)
 			rtype += dtype
@@ -51,11 +419,7 @@ func GuessType(val string, arg string, obj string) (string, string) {
 		} else {
 			rtype += "[]"
 			dtype, dobj := GuessType(val, arg[1:], obj)
-			//if arg[1] == 'o' {
-			//				rtype += dtype[:len(dtype)-1]
-			//		} else {
 			rtype += dtype
-			//	}
 			robj = dobj
 		}
 	case 'y':
@@ -65,7 +429,11 @@ func GuessType(val string, arg string, obj string) (string, string) {
 		rtype = "uint32"
 		robj = obj
 	case '(':
-		rtype = "interface{}"
+		if st, ok := structTypes[obj+"."+val]; ok {
+			rtype = st.Name
+		} else {
+			rtype = "interface{}"
+		}
 		robj = obj
 	case 'n':
 		rtype = "int16"
@@ -74,7 +442,11 @@ func GuessType(val string, arg string, obj string) (string, string) {
 		rtype = "uint16"
 		robj = obj
 	case 'i':
-		rtype = "int32"
+		if named, ok := argTypes[obj+"."+val]; ok {
+			rtype = named
+		} else {
+			rtype = "int32"
+		}
 		robj = obj
 	case 'x':
 		rtype = "int64"
@@ -83,7 +455,11 @@ func GuessType(val string, arg string, obj string) (string, string) {
 		rtype = "uint64"
 		robj = obj
 	case 'u':
-		rtype = "uint32"
+		if named, ok := argTypes[obj+"."+val]; ok {
+			rtype = named
+		} else {
+			rtype = "uint32"
+		}
 		robj = obj
 	case 's':
 		rtype = "string"
@@ -96,44 +472,89 @@ func GuessType(val string, arg string, obj string) (string, string) {
 		robj = obj
 	case 'o':
 		rtype = "dbus.ObjectPath"
-		/*
-			switch val {
-			case "dev":
-				rtype = "*NodeDevice"
-			case "devs":
-				rtype = "*NodeDevices"
-			case "nwfilter":
-				rtype = "*NWFilter"
-			case "nwfilters":
-				rtype = "*NWFilters"
-			default:
-				rtype = "*" + strings.Title(val)
-			}
-		*/
 		robj = obj
 	}
 	return rtype, robj
 }
+
+var (
+	version = flag.String("version", "1.4.0", "libvirt-dbus release whose introspection XML under data/ is used")
+	dataDir = flag.String("dir", "", "read the introspection XML from `dir` instead of data/<version>")
+	fetch   = flag.Bool("fetch", false, "download the introspection XML of -version into data/<version> first")
+)
+
+// ifaces lists the libvirt-dbus interfaces bindings are generated for and
+// the files they are written to. Interfaces the selected release lacks are
+// skipped.
+var ifaces = []struct {
+	Name string
+	File string
+}{
+	{"Connect", "connect.go"},
+	{"Domain", "domain.go"},
+	{"DomainSnapshot", "domainsnapshot.go"},
+	{"Interface", "interface.go"},
+	{"NWFilter", "nwfilter.go"},
+	{"Network", "network.go"},
+	{"NodeDevice", "nodedev.go"},
+	{"Secret", "secret.go"},
+	{"StoragePool", "storagepool.go"},
+	{"StorageVol", "storagevol.go"},
+}
+
+// download stores the introspection XML of iface from the libvirt-dbus
+// release tag into dir. Interfaces missing from the release are skipped.
+func download(dir string, iface string) error {
+	name := "org.libvirt." + iface + ".xml"
+	res, err := http.Get("https://gitlab.com/libvirt/libvirt-dbus/-/raw/v" + *version + "/data/" + name)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetching %s: %s", name, res.Status)
+	}
+	buf, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, name), buf, 0644)
+}
+
 func main() {
 	var err error
 
+	flag.Parse()
+	dir := *dataDir
+	if dir == "" {
+		dir = filepath.Join("data", *version)
+	}
+	if *fetch {
+		if err = os.MkdirAll(dir, 0755); err != nil {
+			log.Fatal(err)
+		}
+		for _, iface := range ifaces {
+			if err = download(dir, iface.Name); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+
 	tplbuf, err := ioutil.ReadFile("template.tpl")
 	if err != nil {
-		panic(err)
+		log.Fatal(err)
 	}
-	httpPref := "https://raw.githubusercontent.com/libvirt/libvirt-dbus/master/data/"
-	ifaces := []string{"Connect", "Domain", "Interface", "NWFilter", "Network", "NodeDevice", "Secret", "StoragePool", "StorageVol"}
 	for _, iface := range ifaces {
-		res, err := http.Get(httpPref + "org.libvirt." + iface + ".xml")
+		node, err := readNode(dir, iface.Name)
 		if err != nil {
-			panic(err)
+			log.Fatal(err)
 		}
-		dec := xml.NewDecoder(res.Body)
-		var node introspect.Node
-		err = dec.Decode(&node)
-		res.Body.Close()
-		if err != nil {
-			panic(err)
+		if node == nil {
+			log.Printf("skipping %s: not in %s", iface.Name, dir)
+			continue
 		}
 
 		funcs := template.FuncMap{
@@ -160,6 +581,25 @@ func main() {
 				return strings.Join(ret, " ")
 			},
 			"PropWritable": func(prop introspect.Property) bool { return prop.Access == "readwrite" },
+			"Structs": func(ifc introspect.Interface) []structDef {
+				var defs []structDef
+				seen := make(map[string]bool)
+				export := strings.Split(ifc.Name, ".")[2]
+				for _, m := range ifc.Methods {
+					for _, a := range m.Args {
+						defs = collectStructs(defs, seen, export+"."+m.Name+"."+a.Name, a.Type, "returned by")
+					}
+				}
+				for _, sig := range ifc.Signals {
+					for _, a := range sig.Args {
+						defs = collectStructs(defs, seen, export+"."+sig.Name+"."+a.Name, a.Type, "sent with")
+					}
+				}
+				for _, p := range ifc.Properties {
+					defs = collectStructs(defs, seen, export+"."+p.Name, p.Type, "")
+				}
+				return defs
+			},
 			"GuessType": func(val string, arg string, obj string) string {
 				dtype, _ := GuessType(val, arg, obj)
 				return dtype
@@ -190,6 +630,28 @@ func main() {
 				}
 				return
 			},
+			"ArgName": func(arg introspect.Arg) string {
+				if getKeyword(arg.Name) {
+					return "o" + arg.Name
+				}
+				return arg.Name
+			},
+			"ObjectOut":  findObjectOut,
+			"ObjectProp": findObjectProp,
+			"FieldName": func(arg introspect.Arg) string {
+				return strings.ToUpper(arg.Name[:1]) + arg.Name[1:]
+			},
+			"Streamed": func(signal string) bool { return lifecycleSignals[signal] == "" },
+			// EventName drops a trailing "Event" from a signal name, which
+			// the channel variants append again.
+			"EventName": func(signal string) string { return strings.TrimSuffix(signal, "Event") },
+			"Lifecycle": func(signal string) string { return lifecycleSignals[signal] },
+			"Signature": func(args []introspect.Arg) (ret string) {
+				for _, arg := range args {
+					ret += arg.Type
+				}
+				return
+			},
 			"Repeat": func(str string, sep string, times int) (r string) {
 				for i := 0; i < times; i++ {
 					if i != 0 {
@@ -212,6 +674,22 @@ func main() {
 				}
 				return
 			},
+			"CallArgs": func(member string, args []introspect.Arg) (ret string) {
+				for _, arg := range args {
+					if arg.Direction == "in" {
+						name := arg.Name
+						if getKeyword(name) {
+							name = "i" + name
+						}
+						if _, ok := paramTypes[member+"."+arg.Name]; ok {
+							name = "encodeParams(" + name + ")"
+						}
+						ret += ", " + name
+					}
+				}
+				return
+			},
+			"ParamOut": findParamOut,
 			"GetParamterOuts": func(args []introspect.Arg) (ret string) {
 				var notFirst = false
 				for _, arg := range args {
@@ -229,7 +707,7 @@ func main() {
 				}
 				return
 			},
-			"GetParamterOutsProto": func(args []introspect.Arg) (ret string) {
+			"GetParamterOutsProto": func(member string, args []introspect.Arg) (ret string) {
 				var notFirst = false
 				for _, arg := range args {
 					if arg.Direction == "out" || arg.Direction == "" {
@@ -237,7 +715,7 @@ func main() {
 							ret += ", "
 						}
 						notFirst = true
-						dtype, _ := GuessType(arg.Name, arg.Type, "")
+						dtype, _ := GuessType(arg.Name, arg.Type, member)
 						if getKeyword(arg.Name) {
 							ret += "o" + arg.Name + " " + dtype
 						} else {
@@ -247,7 +725,7 @@ func main() {
 				}
 				return
 			},
-			"GetParamterInsProto": func(args []introspect.Arg) (ret string) {
+			"GetParamterInsProto": func(member string, args []introspect.Arg) (ret string) {
 				var notFirst = false
 				for _, arg := range args {
 					if arg.Direction == "in" || arg.Direction == "" {
@@ -255,14 +733,14 @@ func main() {
 							ret += ", "
 						}
 						notFirst = true
-						if strings.Contains(arg.Type, "(") {
+						if _, ok := structTypes[member+"."+arg.Name]; !ok && strings.Contains(arg.Type, "(") {
 							if getKeyword(arg.Name) {
 								ret += "i" + arg.Name + " interface{}"
 							} else {
 								ret += arg.Name + " interface{}"
 							}
 						} else {
-							dtype, _ := GuessType(arg.Name, arg.Type, "")
+							dtype, _ := GuessType(arg.Name, arg.Type, member)
 							if getKeyword(arg.Name) {
 								ret += "i" + arg.Name + " " + dtype
 							} else {
@@ -273,47 +751,49 @@ func main() {
 				}
 				return
 			},
-			/*
-				"TryConvertObjectPath": func(prop introspect.Property) string {
-					if v := getObjectPathConvert("Property", prop.Annotations); v != "" {
-						return tryConvertObjectPathGo(node, prop.Type, v)
-					}
-					return ""
-				},
-				"GetObjectPathType": func(prop introspect.Property) (ret string) {
-					if v := getObjectPathConvert("Property", prop.Annotations); v != "" {
-						ret, _ = guessTypeGo(node, prop.Type, v)
-						return
-					}
-					return dbus.TypeFor(prop.Type)
-				},
-			*/
 		}
 
-		parts := strings.Split(node.Name, "/")
-		fname := parts[len(parts)-1]
+		fname := iface.File
 		tpl, err := template.New(fname).Funcs(funcs).Parse(string(tplbuf))
 		if err != nil {
-			panic(err)
+			log.Fatal(err)
 		}
 		fmt.Printf("writing %s\n", fname)
-		fp, err := os.OpenFile(fname+".go", os.O_CREATE|os.O_TRUNC|os.O_RDWR, os.FileMode(0600))
+		fp, err := os.OpenFile(fname, os.O_CREATE|os.O_TRUNC|os.O_RDWR, os.FileMode(0644))
 		if err != nil {
-			panic(err)
+			log.Fatal(err)
 		}
 		for _, ifc := range node.Interfaces {
 			if err = tpl.Execute(fp, ifc); err != nil {
-				log.Println("executing template:", err)
+				log.Fatalf("executing template for %s: %v", iface.Name, err)
 			}
 		}
 		fp.Close()
-		cmd := exec.Command("goimports", "-w", fname+".go")
+		cmd := exec.Command("goimports", "-w", fname)
 		if err = cmd.Run(); err != nil {
-			panic(err)
+			log.Fatalf("goimports %s: %v", fname, err)
 		}
 	}
 }
 
+// readNode decodes the introspection XML of iface in dir. It returns nil
+// if dir has none.
+func readNode(dir string, iface string) (*introspect.Node, error) {
+	res, err := os.Open(filepath.Join(dir, "org.libvirt."+iface+".xml"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	var node introspect.Node
+	if err = xml.NewDecoder(res).Decode(&node); err != nil {
+		return nil, fmt.Errorf("decoding %s: %v", res.Name(), err)
+	}
+	return &node, nil
+}
+
 func getKeyword(arg string) bool {
 	var r bool
 	switch arg {
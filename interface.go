@@ -1,18 +1,14 @@
 package libvirt
 
 import (
-	"sync"
+	"context"
 
 	"github.com/godbus/dbus"
 )
 
 type Interface struct {
-	conn   *Conn
-	object dbus.BusObject
-	path   dbus.ObjectPath
-
-	sigs  map[<-chan *dbus.Signal]struct{}
-	sigmu sync.Mutex
+	conn *Conn
+	path dbus.ObjectPath
 
 	//Active bool
 	//MAC string
@@ -21,59 +17,99 @@ type Interface struct {
 
 // NewInterface() TODO
 func NewInterface(c *Conn, path dbus.ObjectPath) *Interface {
-	m := &Interface{conn: c}
-	if path != "" {
-		m.object = c.conn.Object("org.libvirt", path)
-	} else {
-		m.object = c.object
+	m := &Interface{conn: c, path: path}
+	if path == "" {
+		m.path = c.path
 	}
-	m.path = c.object.Path()
-
-	m.sigmu.Lock()
-	m.sigs = make(map[<-chan *dbus.Signal]struct{})
-	m.sigmu.Unlock()
 
 	return m
 }
 
 // Create See https://libvirt.org/html/libvirt-libvirt-interface.html#virInterfaceCreate
 func (m *Interface) Create(flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Interface.Create", 0, flags).Store()
+	return m.CreateContext(context.Background(), flags)
+}
+
+// CreateContext is like Create but gives up waiting for the reply once ctx is done.
+func (m *Interface) CreateContext(ctx context.Context, flags uint32) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Interface.Create", flags).Store()
 	return
 }
 
 // Destroy See https://libvirt.org/html/libvirt-libvirt-interface.html#virInterfaceDestroy
 func (m *Interface) Destroy(flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Interface.Destroy", 0, flags).Store()
+	return m.DestroyContext(context.Background(), flags)
+}
+
+// DestroyContext is like Destroy but gives up waiting for the reply once ctx is done.
+func (m *Interface) DestroyContext(ctx context.Context, flags uint32) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Interface.Destroy", flags).Store()
 	return
 }
 
 // GetXMLDesc See https://libvirt.org/html/libvirt-libvirt-interface.html#virInterfaceGetXMLDesc
-func (m *Interface) GetXMLDesc(flags uint32) (xml string, err error) {
-	err = m.object.Call("org.libvirt.Interface.GetXMLDesc", 0, flags).Store(&xml)
+func (m *Interface) GetXMLDesc(flags InterfaceXMLFlags) (xml string, err error) {
+	return m.GetXMLDescContext(context.Background(), flags)
+}
+
+// GetXMLDescContext is like GetXMLDesc but gives up waiting for the reply once ctx is done.
+func (m *Interface) GetXMLDescContext(ctx context.Context, flags InterfaceXMLFlags) (xml string, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Interface.GetXMLDesc", flags).Store(&xml)
 	return
 }
 
 // Undefine See https://libvirt.org/html/libvirt-libvirt-interface.html#virInterfaceUndefine
 func (m *Interface) Undefine() (err error) {
-	err = m.object.Call("org.libvirt.Interface.Undefine", 0).Store()
+	return m.UndefineContext(context.Background())
+}
+
+// UndefineContext is like Undefine but gives up waiting for the reply once ctx is done.
+func (m *Interface) UndefineContext(ctx context.Context) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Interface.Undefine").Store()
 	return
 }
 
 // GetActive See https://libvirt.org/html/libvirt-libvirt-interface.html#virInterfaceIsActive
 func (m *Interface) GetActive() (v bool, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.Interface", "Active").Store(&v)
+	return m.GetActiveContext(context.Background())
+}
+
+// GetActiveContext is like GetActive but gives up waiting for the reply once ctx is done.
+func (m *Interface) GetActiveContext(ctx context.Context) (v bool, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.Interface", "Active").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
 
 // GetMAC See https://libvirt.org/html/libvirt-libvirt-interface.html#virInterfaceGetMACString
 func (m *Interface) GetMAC() (v string, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.Interface", "MAC").Store(&v)
+	return m.GetMACContext(context.Background())
+}
+
+// GetMACContext is like GetMAC but gives up waiting for the reply once ctx is done.
+func (m *Interface) GetMACContext(ctx context.Context) (v string, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.Interface", "MAC").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
 
 // GetName See https://libvirt.org/html/libvirt-libvirt-interface.html#virInterfaceGetName
 func (m *Interface) GetName() (v string, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.Interface", "Name").Store(&v)
+	return m.GetNameContext(context.Background())
+}
+
+// GetNameContext is like GetName but gives up waiting for the reply once ctx is done.
+func (m *Interface) GetNameContext(ctx context.Context) (v string, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.Interface", "Name").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
@@ -0,0 +1,328 @@
+package libvirtxml
+
+import (
+	"encoding/xml"
+)
+
+// Caps describes the host and the guests it can run, as returned by
+// Connect.GetCapabilities.
+// See https://libvirt.org/formatcaps.html
+type Caps struct {
+	XMLName      xml.Name    `xml:"capabilities"`
+	Host         CapsHost    `xml:"host"`
+	Guests       []CapsGuest `xml:"guest"`
+	UnknownAttrs []Attr      `xml:",any,attr"`
+	Unknown      []Element   `xml:",any"`
+}
+
+// Unmarshal parses doc into c.
+func (c *Caps) Unmarshal(doc string) error {
+	return unmarshal(doc, c)
+}
+
+// Marshal returns c as an XML document.
+func (c *Caps) Marshal() (string, error) {
+	return marshal(c)
+}
+
+type CapsHost struct {
+	UUID              string             `xml:"uuid,omitempty"`
+	CPU               *CapsHostCPU       `xml:"cpu"`
+	PowerManagement   *CapsHostPM        `xml:"power_management"`
+	IOMMU             *CapsHostIOMMU     `xml:"iommu"`
+	MigrationFeatures *CapsHostMigration `xml:"migration_features"`
+	NUMA              *CapsHostNUMA      `xml:"topology"`
+	SecModels         []CapsHostSecModel `xml:"secmodel"`
+	UnknownAttrs      []Attr             `xml:",any,attr"`
+	Unknown           []Element          `xml:",any"`
+}
+
+type CapsHostCPU struct {
+	Arch         string               `xml:"arch,omitempty"`
+	Model        string               `xml:"model,omitempty"`
+	Vendor       string               `xml:"vendor,omitempty"`
+	Microcode    *CapsHostMicrocode   `xml:"microcode"`
+	Counter      *CapsHostCPUCounter  `xml:"counter"`
+	Topology     *DomainCPUTopology   `xml:"topology"`
+	Features     []CapsHostCPUFeature `xml:"feature"`
+	Pages        []CapsHostCPUPage    `xml:"pages"`
+	UnknownAttrs []Attr               `xml:",any,attr"`
+	Unknown      []Element            `xml:",any"`
+}
+
+type CapsHostMicrocode struct {
+	Version      uint   `xml:"version,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type CapsHostCPUCounter struct {
+	Name         string `xml:"name,attr"`
+	Frequency    uint64 `xml:"frequency,attr"`
+	Scaling      string `xml:"scaling,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type CapsHostCPUFeature struct {
+	Name         string `xml:"name,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+// CapsHostCPUPage is a memory page size supported by the host.
+type CapsHostCPUPage struct {
+	Size         uint   `xml:"size,attr"`
+	Unit         string `xml:"unit,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type CapsHostPM struct {
+	SuspendMem    *Empty    `xml:"suspend_mem"`
+	SuspendDisk   *Empty    `xml:"suspend_disk"`
+	SuspendHybrid *Empty    `xml:"suspend_hybrid"`
+	UnknownAttrs  []Attr    `xml:",any,attr"`
+	Unknown       []Element `xml:",any"`
+}
+
+type CapsHostIOMMU struct {
+	Support      string `xml:"support,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type CapsHostMigration struct {
+	Live          *Empty    `xml:"live"`
+	URITransports []string  `xml:"uri_transports>uri_transport"`
+	UnknownAttrs  []Attr    `xml:",any,attr"`
+	Unknown       []Element `xml:",any"`
+}
+
+type CapsHostNUMA struct {
+	Cells        *CapsHostNUMACells `xml:"cells"`
+	UnknownAttrs []Attr             `xml:",any,attr"`
+	Unknown      []Element          `xml:",any"`
+}
+
+type CapsHostNUMACells struct {
+	Num          uint               `xml:"num,attr,omitempty"`
+	Cells        []CapsHostNUMACell `xml:"cell"`
+	UnknownAttrs []Attr             `xml:",any,attr"`
+	Unknown      []Element          `xml:",any"`
+}
+
+type CapsHostNUMACell struct {
+	ID           uint                 `xml:"id,attr"`
+	Memory       *DomainMemory        `xml:"memory"`
+	Pages        []CapsHostNUMAPage   `xml:"pages"`
+	Distances    *DomainCellDistances `xml:"distances"`
+	CPUs         *CapsHostNUMACPUs    `xml:"cpus"`
+	UnknownAttrs []Attr               `xml:",any,attr"`
+	Unknown      []Element            `xml:",any"`
+}
+
+// CapsHostNUMAPage is the number of pages of a size the cell has.
+type CapsHostNUMAPage struct {
+	Size         uint   `xml:"size,attr"`
+	Unit         string `xml:"unit,attr,omitempty"`
+	Count        uint64 `xml:",chardata"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type CapsHostNUMACPUs struct {
+	Num          uint              `xml:"num,attr,omitempty"`
+	CPUs         []CapsHostNUMACPU `xml:"cpu"`
+	UnknownAttrs []Attr            `xml:",any,attr"`
+	Unknown      []Element         `xml:",any"`
+}
+
+type CapsHostNUMACPU struct {
+	ID           uint   `xml:"id,attr"`
+	SocketID     *uint  `xml:"socket_id,attr"`
+	DieID        *uint  `xml:"die_id,attr"`
+	CoreID       *uint  `xml:"core_id,attr"`
+	Siblings     string `xml:"siblings,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type CapsHostSecModel struct {
+	Name         string                  `xml:"model"`
+	DOI          string                  `xml:"doi"`
+	BaseLabels   []CapsHostSecModelLabel `xml:"baselabel"`
+	UnknownAttrs []Attr                  `xml:",any,attr"`
+	Unknown      []Element               `xml:",any"`
+}
+
+type CapsHostSecModelLabel struct {
+	Type         string `xml:"type,attr"`
+	Value        string `xml:",chardata"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type CapsGuest struct {
+	OSType       string             `xml:"os_type"`
+	Arch         CapsGuestArch      `xml:"arch"`
+	Features     *CapsGuestFeatures `xml:"features"`
+	UnknownAttrs []Attr             `xml:",any,attr"`
+	Unknown      []Element          `xml:",any"`
+}
+
+type CapsGuestArch struct {
+	Name         string             `xml:"name,attr"`
+	WordSize     uint               `xml:"wordsize"`
+	Emulator     string             `xml:"emulator,omitempty"`
+	Loader       string             `xml:"loader,omitempty"`
+	Machines     []CapsGuestMachine `xml:"machine"`
+	Domains      []CapsGuestDomain  `xml:"domain"`
+	UnknownAttrs []Attr             `xml:",any,attr"`
+	Unknown      []Element          `xml:",any"`
+}
+
+type CapsGuestMachine struct {
+	Name         string `xml:",chardata"`
+	MaxCPUs      uint   `xml:"maxCpus,attr,omitempty"`
+	Canonical    string `xml:"canonical,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type CapsGuestDomain struct {
+	Type         string             `xml:"type,attr"`
+	Emulator     string             `xml:"emulator,omitempty"`
+	Machines     []CapsGuestMachine `xml:"machine"`
+	UnknownAttrs []Attr             `xml:",any,attr"`
+	Unknown      []Element          `xml:",any"`
+}
+
+// CapsGuestFeatures lists optional guest features; the many flags libvirt
+// reports are all kept in Unknown, apart from the most asked-for ones.
+type CapsGuestFeatures struct {
+	PAE          *Empty         `xml:"pae"`
+	NonPAE       *Empty         `xml:"nonpae"`
+	ACPI         *DomainFeature `xml:"acpi"`
+	APIC         *DomainFeature `xml:"apic"`
+	CPUSelection *Empty         `xml:"cpuselection"`
+	DeviceBoot   *Empty         `xml:"deviceboot"`
+	DiskSnapshot *DomainFeature `xml:"disksnapshot"`
+	UnknownAttrs []Attr         `xml:",any,attr"`
+	Unknown      []Element      `xml:",any"`
+}
+
+// DomainCaps describes what one emulator and machine type can offer to a
+// domain, as returned by Connect.GetDomainCapabilities.
+// See https://libvirt.org/formatdomaincaps.html
+type DomainCaps struct {
+	XMLName      xml.Name            `xml:"domainCapabilities"`
+	Path         string              `xml:"path"`
+	Domain       string              `xml:"domain"`
+	Machine      string              `xml:"machine,omitempty"`
+	Arch         string              `xml:"arch"`
+	VCPU         *DomainCapsVCPU     `xml:"vcpu"`
+	IOThreads    *DomainCapsEnumSet  `xml:"iothreads"`
+	OS           *DomainCapsOS       `xml:"os"`
+	CPU          *DomainCapsCPU      `xml:"cpu"`
+	Devices      *DomainCapsDevices  `xml:"devices"`
+	Features     *DomainCapsFeatures `xml:"features"`
+	UnknownAttrs []Attr              `xml:",any,attr"`
+	Unknown      []Element           `xml:",any"`
+}
+
+// Unmarshal parses doc into c.
+func (c *DomainCaps) Unmarshal(doc string) error {
+	return unmarshal(doc, c)
+}
+
+// Marshal returns c as an XML document.
+func (c *DomainCaps) Marshal() (string, error) {
+	return marshal(c)
+}
+
+type DomainCapsVCPU struct {
+	Max          uint   `xml:"max,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+// DomainCapsEnum lists the values a domain XML attribute may take.
+type DomainCapsEnum struct {
+	Name         string    `xml:"name,attr"`
+	Values       []string  `xml:"value"`
+	UnknownAttrs []Attr    `xml:",any,attr"`
+	Unknown      []Element `xml:",any"`
+}
+
+// DomainCapsEnumSet is an element with a supported attribute and the
+// enums that apply when it is "yes", the shape of most of domain
+// capabilities.
+type DomainCapsEnumSet struct {
+	Supported    string           `xml:"supported,attr,omitempty"`
+	Enums        []DomainCapsEnum `xml:"enum"`
+	UnknownAttrs []Attr           `xml:",any,attr"`
+	Unknown      []Element        `xml:",any"`
+}
+
+type DomainCapsOS struct {
+	Supported    string            `xml:"supported,attr,omitempty"`
+	Enums        []DomainCapsEnum  `xml:"enum"`
+	Loader       *DomainCapsLoader `xml:"loader"`
+	UnknownAttrs []Attr            `xml:",any,attr"`
+	Unknown      []Element         `xml:",any"`
+}
+
+type DomainCapsLoader struct {
+	Supported    string           `xml:"supported,attr,omitempty"`
+	Values       []string         `xml:"value"`
+	Enums        []DomainCapsEnum `xml:"enum"`
+	UnknownAttrs []Attr           `xml:",any,attr"`
+	Unknown      []Element        `xml:",any"`
+}
+
+type DomainCapsCPU struct {
+	Modes        []DomainCapsCPUMode `xml:"mode"`
+	UnknownAttrs []Attr              `xml:",any,attr"`
+	Unknown      []Element           `xml:",any"`
+}
+
+// DomainCapsCPUMode tells whether a CPU mode is usable and, for
+// host-model and custom, which models it offers.
+type DomainCapsCPUMode struct {
+	Name         string               `xml:"name,attr"`
+	Supported    string               `xml:"supported,attr"`
+	Models       []DomainCapsCPUModel `xml:"model"`
+	Features     []DomainCPUFeature   `xml:"feature"`
+	Vendor       string               `xml:"vendor,omitempty"`
+	Enums        []DomainCapsEnum     `xml:"enum"`
+	UnknownAttrs []Attr               `xml:",any,attr"`
+	Unknown      []Element            `xml:",any"`
+}
+
+type DomainCapsCPUModel struct {
+	Name         string `xml:",chardata"`
+	Usable       string `xml:"usable,attr,omitempty"`
+	Fallback     string `xml:"fallback,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainCapsDevices struct {
+	Disk         *DomainCapsEnumSet `xml:"disk"`
+	Graphics     *DomainCapsEnumSet `xml:"graphics"`
+	Video        *DomainCapsEnumSet `xml:"video"`
+	Hostdev      *DomainCapsEnumSet `xml:"hostdev"`
+	RNG          *DomainCapsEnumSet `xml:"rng"`
+	Filesystem   *DomainCapsEnumSet `xml:"filesystem"`
+	TPM          *DomainCapsEnumSet `xml:"tpm"`
+	UnknownAttrs []Attr             `xml:",any,attr"`
+	Unknown      []Element          `xml:",any"`
+}
+
+type DomainCapsFeatures struct {
+	GIC          *DomainCapsEnumSet `xml:"gic"`
+	VMCoreInfo   *DomainCapsEnumSet `xml:"vmcoreinfo"`
+	GenID        *DomainCapsEnumSet `xml:"genid"`
+	Backup       *DomainCapsEnumSet `xml:"backup"`
+	SEV          *DomainCapsSEV     `xml:"sev"`
+	UnknownAttrs []Attr             `xml:",any,attr"`
+	Unknown      []Element          `xml:",any"`
+}
+
+type DomainCapsSEV struct {
+	Supported       string    `xml:"supported,attr"`
+	CBitPos         uint      `xml:"cbitpos,omitempty"`
+	ReducedPhysBits uint      `xml:"reducedPhysBits,omitempty"`
+	UnknownAttrs    []Attr    `xml:",any,attr"`
+	Unknown         []Element `xml:",any"`
+}
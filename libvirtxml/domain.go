@@ -0,0 +1,1097 @@
+package libvirtxml
+
+import (
+	"encoding/xml"
+)
+
+// Domain is a libvirt domain definition.
+// See https://libvirt.org/formatdomain.html
+type Domain struct {
+	XMLName       xml.Name             `xml:"domain"`
+	Type          string               `xml:"type,attr,omitempty"`
+	ID            *int                 `xml:"id,attr"`
+	Name          string               `xml:"name,omitempty"`
+	UUID          string               `xml:"uuid,omitempty"`
+	GenID         string               `xml:"genid,omitempty"`
+	Title         string               `xml:"title,omitempty"`
+	Description   string               `xml:"description,omitempty"`
+	Metadata      *DomainMetadata      `xml:"metadata"`
+	MaximumMemory *DomainMaxMemory     `xml:"maxMemory"`
+	Memory        *DomainMemory        `xml:"memory"`
+	CurrentMemory *DomainMemory        `xml:"currentMemory"`
+	MemoryTune    *DomainMemoryTune    `xml:"memtune"`
+	MemoryBacking *DomainMemoryBacking `xml:"memoryBacking"`
+	VCPU          *DomainVCPU          `xml:"vcpu"`
+	VCPUs         *DomainVCPUs         `xml:"vcpus"`
+	IOThreads     uint                 `xml:"iothreads,omitempty"`
+	CPUTune       *DomainCPUTune       `xml:"cputune"`
+	NUMATune      *DomainNUMATune      `xml:"numatune"`
+	Resource      *DomainResource      `xml:"resource"`
+	OS            *DomainOS            `xml:"os"`
+	Features      *DomainFeatureList   `xml:"features"`
+	CPU           *DomainCPU           `xml:"cpu"`
+	Clock         *DomainClock         `xml:"clock"`
+	OnPoweroff    string               `xml:"on_poweroff,omitempty"`
+	OnReboot      string               `xml:"on_reboot,omitempty"`
+	OnCrash       string               `xml:"on_crash,omitempty"`
+	PM            *DomainPM            `xml:"pm"`
+	Devices       *DomainDeviceList    `xml:"devices"`
+	SecLabel      []DomainSecLabel     `xml:"seclabel"`
+	UnknownAttrs  []Attr               `xml:",any,attr"`
+	Unknown       []Element            `xml:",any"`
+}
+
+// Unmarshal parses doc into d.
+func (d *Domain) Unmarshal(doc string) error {
+	return unmarshal(doc, d)
+}
+
+// Marshal returns d as an XML document.
+func (d *Domain) Marshal() (string, error) {
+	return marshal(d)
+}
+
+// DomainMetadata holds the custom metadata of applications, kept verbatim
+// since every application uses its own namespace.
+type DomainMetadata struct {
+	XML string `xml:",innerxml"`
+}
+
+type DomainMaxMemory struct {
+	Value        uint64 `xml:",chardata"`
+	Unit         string `xml:"unit,attr,omitempty"`
+	Slots        uint   `xml:"slots,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainMemory struct {
+	Value        uint64 `xml:",chardata"`
+	Unit         string `xml:"unit,attr,omitempty"`
+	DumpCore     string `xml:"dumpCore,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainMemoryTune struct {
+	HardLimit     *DomainMemoryLimit `xml:"hard_limit"`
+	SoftLimit     *DomainMemoryLimit `xml:"soft_limit"`
+	MinGuarantee  *DomainMemoryLimit `xml:"min_guarantee"`
+	SwapHardLimit *DomainMemoryLimit `xml:"swap_hard_limit"`
+	UnknownAttrs  []Attr             `xml:",any,attr"`
+	Unknown       []Element          `xml:",any"`
+}
+
+type DomainMemoryLimit struct {
+	Value        uint64 `xml:",chardata"`
+	Unit         string `xml:"unit,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainMemoryBacking struct {
+	HugePages    *DomainMemoryHugePages `xml:"hugepages"`
+	NoSharePages *Empty                 `xml:"nosharepages"`
+	Locked       *Empty                 `xml:"locked"`
+	Source       *DomainMemorySource    `xml:"source"`
+	Access       *DomainMemoryAccess    `xml:"access"`
+	Allocation   *DomainMemoryAccess    `xml:"allocation"`
+	Discard      *Empty                 `xml:"discard"`
+	UnknownAttrs []Attr                 `xml:",any,attr"`
+	Unknown      []Element              `xml:",any"`
+}
+
+type DomainMemoryHugePages struct {
+	Pages        []DomainMemoryHugePage `xml:"page"`
+	UnknownAttrs []Attr                 `xml:",any,attr"`
+	Unknown      []Element              `xml:",any"`
+}
+
+type DomainMemoryHugePage struct {
+	Size         uint64 `xml:"size,attr"`
+	Unit         string `xml:"unit,attr,omitempty"`
+	Nodeset      string `xml:"nodeset,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainMemorySource struct {
+	Type         string `xml:"type,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+// DomainMemoryAccess is used for both <access mode=...> and
+// <allocation mode=...>.
+type DomainMemoryAccess struct {
+	Mode         string `xml:"mode,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainVCPU struct {
+	Value        uint   `xml:",chardata"`
+	Placement    string `xml:"placement,attr,omitempty"`
+	CPUSet       string `xml:"cpuset,attr,omitempty"`
+	Current      uint   `xml:"current,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainVCPUs struct {
+	VCPU         []DomainVCPUsVCPU `xml:"vcpu"`
+	UnknownAttrs []Attr            `xml:",any,attr"`
+	Unknown      []Element         `xml:",any"`
+}
+
+type DomainVCPUsVCPU struct {
+	ID           *uint  `xml:"id,attr"`
+	Enabled      string `xml:"enabled,attr,omitempty"`
+	Hotpluggable string `xml:"hotpluggable,attr,omitempty"`
+	Order        *uint  `xml:"order,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainCPUTune struct {
+	Shares         *uint64                `xml:"shares"`
+	Period         *uint64                `xml:"period"`
+	Quota          *int64                 `xml:"quota"`
+	GlobalPeriod   *uint64                `xml:"global_period"`
+	GlobalQuota    *int64                 `xml:"global_quota"`
+	EmulatorPeriod *uint64                `xml:"emulator_period"`
+	EmulatorQuota  *int64                 `xml:"emulator_quota"`
+	IOThreadPeriod *uint64                `xml:"iothread_period"`
+	IOThreadQuota  *int64                 `xml:"iothread_quota"`
+	VCPUPin        []DomainCPUTuneVCPUPin `xml:"vcpupin"`
+	EmulatorPin    *DomainCPUTunePin      `xml:"emulatorpin"`
+	IOThreadPin    []DomainCPUTuneIOPin   `xml:"iothreadpin"`
+	UnknownAttrs   []Attr                 `xml:",any,attr"`
+	Unknown        []Element              `xml:",any"`
+}
+
+type DomainCPUTuneVCPUPin struct {
+	VCPU         uint   `xml:"vcpu,attr"`
+	CPUSet       string `xml:"cpuset,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainCPUTunePin struct {
+	CPUSet       string `xml:"cpuset,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainCPUTuneIOPin struct {
+	IOThread     uint   `xml:"iothread,attr"`
+	CPUSet       string `xml:"cpuset,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainNUMATune struct {
+	Memory       *DomainNUMATuneMemory `xml:"memory"`
+	MemNodes     []DomainNUMATuneNode  `xml:"memnode"`
+	UnknownAttrs []Attr                `xml:",any,attr"`
+	Unknown      []Element             `xml:",any"`
+}
+
+type DomainNUMATuneMemory struct {
+	Mode         string `xml:"mode,attr,omitempty"`
+	Nodeset      string `xml:"nodeset,attr,omitempty"`
+	Placement    string `xml:"placement,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainNUMATuneNode struct {
+	CellID       uint   `xml:"cellid,attr"`
+	Mode         string `xml:"mode,attr"`
+	Nodeset      string `xml:"nodeset,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainResource struct {
+	Partition    string    `xml:"partition,omitempty"`
+	UnknownAttrs []Attr    `xml:",any,attr"`
+	Unknown      []Element `xml:",any"`
+}
+
+type DomainOS struct {
+	Firmware     string             `xml:"firmware,attr,omitempty"`
+	Type         *DomainOSType      `xml:"type"`
+	Loader       *DomainLoader      `xml:"loader"`
+	NVRAM        *DomainNVRAM       `xml:"nvram"`
+	Init         string             `xml:"init,omitempty"`
+	InitArgs     []string           `xml:"initarg"`
+	Kernel       string             `xml:"kernel,omitempty"`
+	Initrd       string             `xml:"initrd,omitempty"`
+	Cmdline      string             `xml:"cmdline,omitempty"`
+	DTB          string             `xml:"dtb,omitempty"`
+	BootDevices  []DomainBootDevice `xml:"boot"`
+	BootMenu     *DomainBootMenu    `xml:"bootmenu"`
+	SMBios       *DomainSMBios      `xml:"smbios"`
+	BIOS         *DomainBIOS        `xml:"bios"`
+	UnknownAttrs []Attr             `xml:",any,attr"`
+	Unknown      []Element          `xml:",any"`
+}
+
+type DomainOSType struct {
+	Type         string `xml:",chardata"`
+	Arch         string `xml:"arch,attr,omitempty"`
+	Machine      string `xml:"machine,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainLoader struct {
+	Path         string `xml:",chardata"`
+	Readonly     string `xml:"readonly,attr,omitempty"`
+	Secure       string `xml:"secure,attr,omitempty"`
+	Type         string `xml:"type,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainNVRAM struct {
+	Path         string `xml:",chardata"`
+	Template     string `xml:"template,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainBootDevice struct {
+	Dev          string `xml:"dev,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainBootMenu struct {
+	Enable       string `xml:"enable,attr,omitempty"`
+	Timeout      string `xml:"timeout,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainSMBios struct {
+	Mode         string `xml:"mode,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainBIOS struct {
+	UseSerial     string `xml:"useserial,attr,omitempty"`
+	RebootTimeout *int   `xml:"rebootTimeout,attr"`
+	UnknownAttrs  []Attr `xml:",any,attr"`
+}
+
+// DomainFeatureList is the <features> element. Features not listed here,
+// including newer ones, are kept in Unknown.
+type DomainFeatureList struct {
+	PAE          *DomainFeature       `xml:"pae"`
+	ACPI         *DomainFeature       `xml:"acpi"`
+	APIC         *DomainFeatureAPIC   `xml:"apic"`
+	HAP          *DomainFeature       `xml:"hap"`
+	Viridian     *DomainFeature       `xml:"viridian"`
+	PrivNet      *DomainFeature       `xml:"privnet"`
+	HyperV       *DomainFeatureHyperV `xml:"hyperv"`
+	KVM          *DomainFeatureKVM    `xml:"kvm"`
+	PVSpinlock   *DomainFeature       `xml:"pvspinlock"`
+	PMU          *DomainFeature       `xml:"pmu"`
+	VMPort       *DomainFeature       `xml:"vmport"`
+	GIC          *DomainFeatureGIC    `xml:"gic"`
+	SMM          *DomainFeature       `xml:"smm"`
+	IOAPIC       *DomainFeatureIOAPIC `xml:"ioapic"`
+	VMCoreInfo   *DomainFeature       `xml:"vmcoreinfo"`
+	HTM          *DomainFeature       `xml:"htm"`
+	UnknownAttrs []Attr               `xml:",any,attr"`
+	Unknown      []Element            `xml:",any"`
+}
+
+// DomainFeature is a feature switched on by its presence or by its state
+// attribute.
+type DomainFeature struct {
+	State        string    `xml:"state,attr,omitempty"`
+	UnknownAttrs []Attr    `xml:",any,attr"`
+	Unknown      []Element `xml:",any"`
+}
+
+type DomainFeatureAPIC struct {
+	EOI          string `xml:"eoi,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainFeatureHyperV struct {
+	Mode            string                        `xml:"mode,attr,omitempty"`
+	Relaxed         *DomainFeature                `xml:"relaxed"`
+	VAPIC           *DomainFeature                `xml:"vapic"`
+	Spinlocks       *DomainFeatureHyperVSpinlocks `xml:"spinlocks"`
+	VPIndex         *DomainFeature                `xml:"vpindex"`
+	Runtime         *DomainFeature                `xml:"runtime"`
+	Synic           *DomainFeature                `xml:"synic"`
+	STimer          *DomainFeature                `xml:"stimer"`
+	Reset           *DomainFeature                `xml:"reset"`
+	VendorID        *DomainFeatureHyperVVendorID  `xml:"vendor_id"`
+	Frequencies     *DomainFeature                `xml:"frequencies"`
+	ReEnlightenment *DomainFeature                `xml:"reenlightenment"`
+	TLBFlush        *DomainFeature                `xml:"tlbflush"`
+	IPI             *DomainFeature                `xml:"ipi"`
+	EVMCS           *DomainFeature                `xml:"evmcs"`
+	UnknownAttrs    []Attr                        `xml:",any,attr"`
+	Unknown         []Element                     `xml:",any"`
+}
+
+type DomainFeatureHyperVSpinlocks struct {
+	State        string `xml:"state,attr,omitempty"`
+	Retries      uint   `xml:"retries,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainFeatureHyperVVendorID struct {
+	State        string `xml:"state,attr,omitempty"`
+	Value        string `xml:"value,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainFeatureKVM struct {
+	Hidden        *DomainFeature `xml:"hidden"`
+	HintDedicated *DomainFeature `xml:"hint-dedicated"`
+	UnknownAttrs  []Attr         `xml:",any,attr"`
+	Unknown       []Element      `xml:",any"`
+}
+
+type DomainFeatureGIC struct {
+	Version      string `xml:"version,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainFeatureIOAPIC struct {
+	Driver       string `xml:"driver,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainCPU struct {
+	Mode         string             `xml:"mode,attr,omitempty"`
+	Match        string             `xml:"match,attr,omitempty"`
+	Check        string             `xml:"check,attr,omitempty"`
+	Migratable   string             `xml:"migratable,attr,omitempty"`
+	Model        *DomainCPUModel    `xml:"model"`
+	Vendor       string             `xml:"vendor,omitempty"`
+	Topology     *DomainCPUTopology `xml:"topology"`
+	Cache        *DomainCPUCache    `xml:"cache"`
+	Features     []DomainCPUFeature `xml:"feature"`
+	NUMA         *DomainNUMA        `xml:"numa"`
+	UnknownAttrs []Attr             `xml:",any,attr"`
+	Unknown      []Element          `xml:",any"`
+}
+
+type DomainCPUModel struct {
+	Value        string `xml:",chardata"`
+	Fallback     string `xml:"fallback,attr,omitempty"`
+	VendorID     string `xml:"vendor_id,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainCPUTopology struct {
+	Sockets      uint   `xml:"sockets,attr,omitempty"`
+	Dies         uint   `xml:"dies,attr,omitempty"`
+	Cores        uint   `xml:"cores,attr,omitempty"`
+	Threads      uint   `xml:"threads,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainCPUCache struct {
+	Level        uint   `xml:"level,attr,omitempty"`
+	Mode         string `xml:"mode,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainCPUFeature struct {
+	Policy       string `xml:"policy,attr,omitempty"`
+	Name         string `xml:"name,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainNUMA struct {
+	Cells        []DomainCell `xml:"cell"`
+	UnknownAttrs []Attr       `xml:",any,attr"`
+	Unknown      []Element    `xml:",any"`
+}
+
+type DomainCell struct {
+	ID           *uint                `xml:"id,attr"`
+	CPUs         string               `xml:"cpus,attr,omitempty"`
+	Memory       uint64               `xml:"memory,attr"`
+	Unit         string               `xml:"unit,attr,omitempty"`
+	MemAccess    string               `xml:"memAccess,attr,omitempty"`
+	Discard      string               `xml:"discard,attr,omitempty"`
+	Distances    *DomainCellDistances `xml:"distances"`
+	UnknownAttrs []Attr               `xml:",any,attr"`
+	Unknown      []Element            `xml:",any"`
+}
+
+type DomainCellDistances struct {
+	Siblings     []DomainCellSibling `xml:"sibling"`
+	UnknownAttrs []Attr              `xml:",any,attr"`
+	Unknown      []Element           `xml:",any"`
+}
+
+type DomainCellSibling struct {
+	ID           uint   `xml:"id,attr"`
+	Value        uint   `xml:"value,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainClock struct {
+	Offset       string        `xml:"offset,attr,omitempty"`
+	Basis        string        `xml:"basis,attr,omitempty"`
+	Adjustment   string        `xml:"adjustment,attr,omitempty"`
+	TimeZone     string        `xml:"timezone,attr,omitempty"`
+	Timers       []DomainTimer `xml:"timer"`
+	UnknownAttrs []Attr        `xml:",any,attr"`
+	Unknown      []Element     `xml:",any"`
+}
+
+type DomainTimer struct {
+	Name         string              `xml:"name,attr"`
+	Track        string              `xml:"track,attr,omitempty"`
+	TickPolicy   string              `xml:"tickpolicy,attr,omitempty"`
+	Present      string              `xml:"present,attr,omitempty"`
+	Frequency    uint64              `xml:"frequency,attr,omitempty"`
+	Mode         string              `xml:"mode,attr,omitempty"`
+	CatchUp      *DomainTimerCatchUp `xml:"catchup"`
+	UnknownAttrs []Attr              `xml:",any,attr"`
+	Unknown      []Element           `xml:",any"`
+}
+
+type DomainTimerCatchUp struct {
+	Threshold    uint   `xml:"threshold,attr,omitempty"`
+	Slew         uint   `xml:"slew,attr,omitempty"`
+	Limit        uint   `xml:"limit,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainPM struct {
+	SuspendToMem  *DomainPMPolicy `xml:"suspend-to-mem"`
+	SuspendToDisk *DomainPMPolicy `xml:"suspend-to-disk"`
+	UnknownAttrs  []Attr          `xml:",any,attr"`
+	Unknown       []Element       `xml:",any"`
+}
+
+type DomainPMPolicy struct {
+	Enabled      string `xml:"enabled,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainSecLabel struct {
+	Type         string    `xml:"type,attr,omitempty"`
+	Model        string    `xml:"model,attr,omitempty"`
+	Relabel      string    `xml:"relabel,attr,omitempty"`
+	Label        string    `xml:"label,omitempty"`
+	ImageLabel   string    `xml:"imagelabel,omitempty"`
+	BaseLabel    string    `xml:"baselabel,omitempty"`
+	UnknownAttrs []Attr    `xml:",any,attr"`
+	Unknown      []Element `xml:",any"`
+}
+
+// DomainDeviceList is the <devices> element. Device kinds not listed here
+// are kept in Unknown.
+type DomainDeviceList struct {
+	Emulator     string             `xml:"emulator,omitempty"`
+	Disks        []DomainDisk       `xml:"disk"`
+	Controllers  []DomainController `xml:"controller"`
+	Filesystems  []DomainFilesystem `xml:"filesystem"`
+	Interfaces   []DomainInterface  `xml:"interface"`
+	Serials      []DomainChardev    `xml:"serial"`
+	Parallels    []DomainChardev    `xml:"parallel"`
+	Consoles     []DomainChardev    `xml:"console"`
+	Channels     []DomainChardev    `xml:"channel"`
+	Inputs       []DomainInput      `xml:"input"`
+	TPMs         []DomainTPM        `xml:"tpm"`
+	Graphics     []DomainGraphic    `xml:"graphics"`
+	Sounds       []DomainSound      `xml:"sound"`
+	Videos       []DomainVideo      `xml:"video"`
+	Hostdevs     []DomainHostdev    `xml:"hostdev"`
+	Watchdog     *DomainWatchdog    `xml:"watchdog"`
+	MemBalloon   *DomainMemBalloon  `xml:"memballoon"`
+	RNGs         []DomainRNG        `xml:"rng"`
+	Panics       []DomainPanic      `xml:"panic"`
+	UnknownAttrs []Attr             `xml:",any,attr"`
+	Unknown      []Element          `xml:",any"`
+}
+
+// DomainAddress is the address of a device on its bus. Which attributes
+// are used depends on Type; values such as "0x1f" are kept as written.
+type DomainAddress struct {
+	Type          string `xml:"type,attr,omitempty"`
+	Domain        string `xml:"domain,attr,omitempty"`
+	Bus           string `xml:"bus,attr,omitempty"`
+	Slot          string `xml:"slot,attr,omitempty"`
+	Function      string `xml:"function,attr,omitempty"`
+	Multifunction string `xml:"multifunction,attr,omitempty"`
+	Controller    string `xml:"controller,attr,omitempty"`
+	Target        string `xml:"target,attr,omitempty"`
+	Unit          string `xml:"unit,attr,omitempty"`
+	Port          string `xml:"port,attr,omitempty"`
+	UnknownAttrs  []Attr `xml:",any,attr"`
+}
+
+type DomainAlias struct {
+	Name         string `xml:"name,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainDeviceBoot struct {
+	Order        uint   `xml:"order,attr"`
+	LoadParm     string `xml:"loadparm,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainDisk struct {
+	Type         string                  `xml:"type,attr,omitempty"`
+	Device       string                  `xml:"device,attr,omitempty"`
+	Model        string                  `xml:"model,attr,omitempty"`
+	RawIO        string                  `xml:"rawio,attr,omitempty"`
+	SGIO         string                  `xml:"sgio,attr,omitempty"`
+	Snapshot     string                  `xml:"snapshot,attr,omitempty"`
+	Driver       *DomainDiskDriver       `xml:"driver"`
+	Source       *DomainDiskSource       `xml:"source"`
+	BackingStore *DomainDiskBackingStore `xml:"backingStore"`
+	Target       *DomainDiskTarget       `xml:"target"`
+	IOTune       *DomainDiskIOTune       `xml:"iotune"`
+	ReadOnly     *Empty                  `xml:"readonly"`
+	Shareable    *Empty                  `xml:"shareable"`
+	Transient    *Empty                  `xml:"transient"`
+	Serial       string                  `xml:"serial,omitempty"`
+	WWN          string                  `xml:"wwn,omitempty"`
+	Vendor       string                  `xml:"vendor,omitempty"`
+	Product      string                  `xml:"product,omitempty"`
+	Boot         *DomainDeviceBoot       `xml:"boot"`
+	Alias        *DomainAlias            `xml:"alias"`
+	Address      *DomainAddress          `xml:"address"`
+	UnknownAttrs []Attr                  `xml:",any,attr"`
+	Unknown      []Element               `xml:",any"`
+}
+
+type DomainDiskDriver struct {
+	Name         string    `xml:"name,attr,omitempty"`
+	Type         string    `xml:"type,attr,omitempty"`
+	Cache        string    `xml:"cache,attr,omitempty"`
+	ErrorPolicy  string    `xml:"error_policy,attr,omitempty"`
+	RErrorPolicy string    `xml:"rerror_policy,attr,omitempty"`
+	IO           string    `xml:"io,attr,omitempty"`
+	IOEventFD    string    `xml:"ioeventfd,attr,omitempty"`
+	EventIDX     string    `xml:"event_idx,attr,omitempty"`
+	CopyOnRead   string    `xml:"copy_on_read,attr,omitempty"`
+	Discard      string    `xml:"discard,attr,omitempty"`
+	DetectZeroes string    `xml:"detect_zeroes,attr,omitempty"`
+	Queues       *uint     `xml:"queues,attr"`
+	IOThread     *uint     `xml:"iothread,attr"`
+	UnknownAttrs []Attr    `xml:",any,attr"`
+	Unknown      []Element `xml:",any"`
+}
+
+type DomainDiskSource struct {
+	File          string                 `xml:"file,attr,omitempty"`
+	Dev           string                 `xml:"dev,attr,omitempty"`
+	Dir           string                 `xml:"dir,attr,omitempty"`
+	Pool          string                 `xml:"pool,attr,omitempty"`
+	Volume        string                 `xml:"volume,attr,omitempty"`
+	Mode          string                 `xml:"mode,attr,omitempty"`
+	Protocol      string                 `xml:"protocol,attr,omitempty"`
+	Name          string                 `xml:"name,attr,omitempty"`
+	StartupPolicy string                 `xml:"startupPolicy,attr,omitempty"`
+	Index         uint                   `xml:"index,attr,omitempty"`
+	Hosts         []DomainDiskSourceHost `xml:"host"`
+	UnknownAttrs  []Attr                 `xml:",any,attr"`
+	Unknown       []Element              `xml:",any"`
+}
+
+type DomainDiskSourceHost struct {
+	Transport    string `xml:"transport,attr,omitempty"`
+	Name         string `xml:"name,attr,omitempty"`
+	Port         string `xml:"port,attr,omitempty"`
+	Socket       string `xml:"socket,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainDiskBackingStore struct {
+	Index        uint                    `xml:"index,attr,omitempty"`
+	Type         string                  `xml:"type,attr,omitempty"`
+	Format       *DomainDiskFormat       `xml:"format"`
+	Source       *DomainDiskSource       `xml:"source"`
+	BackingStore *DomainDiskBackingStore `xml:"backingStore"`
+	UnknownAttrs []Attr                  `xml:",any,attr"`
+	Unknown      []Element               `xml:",any"`
+}
+
+type DomainDiskFormat struct {
+	Type         string `xml:"type,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainDiskTarget struct {
+	Dev          string `xml:"dev,attr,omitempty"`
+	Bus          string `xml:"bus,attr,omitempty"`
+	Tray         string `xml:"tray,attr,omitempty"`
+	Removable    string `xml:"removable,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainDiskIOTune struct {
+	TotalBytesSec *uint64   `xml:"total_bytes_sec"`
+	ReadBytesSec  *uint64   `xml:"read_bytes_sec"`
+	WriteBytesSec *uint64   `xml:"write_bytes_sec"`
+	TotalIOPSSec  *uint64   `xml:"total_iops_sec"`
+	ReadIOPSSec   *uint64   `xml:"read_iops_sec"`
+	WriteIOPSSec  *uint64   `xml:"write_iops_sec"`
+	GroupName     string    `xml:"group_name,omitempty"`
+	UnknownAttrs  []Attr    `xml:",any,attr"`
+	Unknown       []Element `xml:",any"`
+}
+
+type DomainController struct {
+	Type         string                  `xml:"type,attr"`
+	Index        *uint                   `xml:"index,attr"`
+	Model        string                  `xml:"model,attr,omitempty"`
+	Ports        *uint                   `xml:"ports,attr"`
+	Vectors      *uint                   `xml:"vectors,attr"`
+	Driver       *DomainControllerDriver `xml:"driver"`
+	Alias        *DomainAlias            `xml:"alias"`
+	Address      *DomainAddress          `xml:"address"`
+	UnknownAttrs []Attr                  `xml:",any,attr"`
+	Unknown      []Element               `xml:",any"`
+}
+
+type DomainControllerDriver struct {
+	Queues       *uint  `xml:"queues,attr"`
+	CmdPerLUN    *uint  `xml:"cmd_per_lun,attr"`
+	MaxSectors   *uint  `xml:"max_sectors,attr"`
+	IOEventFD    string `xml:"ioeventfd,attr,omitempty"`
+	IOThread     *uint  `xml:"iothread,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainFilesystem struct {
+	Type         string                  `xml:"type,attr,omitempty"`
+	AccessMode   string                  `xml:"accessmode,attr,omitempty"`
+	Driver       *DomainFilesystemDriver `xml:"driver"`
+	Source       *DomainFilesystemSource `xml:"source"`
+	Target       *DomainFilesystemTarget `xml:"target"`
+	ReadOnly     *Empty                  `xml:"readonly"`
+	Alias        *DomainAlias            `xml:"alias"`
+	Address      *DomainAddress          `xml:"address"`
+	UnknownAttrs []Attr                  `xml:",any,attr"`
+	Unknown      []Element               `xml:",any"`
+}
+
+type DomainFilesystemDriver struct {
+	Type         string `xml:"type,attr,omitempty"`
+	Format       string `xml:"format,attr,omitempty"`
+	Queue        uint   `xml:"queue,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainFilesystemSource struct {
+	Dir          string `xml:"dir,attr,omitempty"`
+	File         string `xml:"file,attr,omitempty"`
+	Name         string `xml:"name,attr,omitempty"`
+	Socket       string `xml:"socket,attr,omitempty"`
+	Usage        uint64 `xml:"usage,attr,omitempty"`
+	Units        string `xml:"units,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainFilesystemTarget struct {
+	Dir          string `xml:"dir,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainInterface struct {
+	Type                string                      `xml:"type,attr,omitempty"`
+	Managed             string                      `xml:"managed,attr,omitempty"`
+	TrustGuestRXFilters string                      `xml:"trustGuestRxFilters,attr,omitempty"`
+	MAC                 *DomainInterfaceMAC         `xml:"mac"`
+	Source              *DomainInterfaceSource      `xml:"source"`
+	VirtualPort         *DomainInterfaceVirtualPort `xml:"virtualport"`
+	Target              *DomainInterfaceTarget      `xml:"target"`
+	Model               *DomainInterfaceModel       `xml:"model"`
+	Driver              *DomainInterfaceDriver      `xml:"driver"`
+	Bandwidth           *DomainInterfaceBandwidth   `xml:"bandwidth"`
+	VLAN                *DomainInterfaceVLAN        `xml:"vlan"`
+	Link                *DomainInterfaceLink        `xml:"link"`
+	MTU                 *DomainInterfaceMTU         `xml:"mtu"`
+	FilterRef           *DomainInterfaceFilterRef   `xml:"filterref"`
+	Boot                *DomainDeviceBoot           `xml:"boot"`
+	Alias               *DomainAlias                `xml:"alias"`
+	Address             *DomainAddress              `xml:"address"`
+	UnknownAttrs        []Attr                      `xml:",any,attr"`
+	Unknown             []Element                   `xml:",any"`
+}
+
+type DomainInterfaceMAC struct {
+	Address      string `xml:"address,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainInterfaceSource struct {
+	Network      string    `xml:"network,attr,omitempty"`
+	PortGroup    string    `xml:"portgroup,attr,omitempty"`
+	PortID       string    `xml:"portid,attr,omitempty"`
+	Bridge       string    `xml:"bridge,attr,omitempty"`
+	Dev          string    `xml:"dev,attr,omitempty"`
+	Mode         string    `xml:"mode,attr,omitempty"`
+	Type         string    `xml:"type,attr,omitempty"`
+	Path         string    `xml:"path,attr,omitempty"`
+	Address      string    `xml:"address,attr,omitempty"`
+	Port         string    `xml:"port,attr,omitempty"`
+	UnknownAttrs []Attr    `xml:",any,attr"`
+	Unknown      []Element `xml:",any"`
+}
+
+type DomainInterfaceVirtualPort struct {
+	Type         string                                `xml:"type,attr,omitempty"`
+	Parameters   *DomainInterfaceVirtualPortParameters `xml:"parameters"`
+	UnknownAttrs []Attr                                `xml:",any,attr"`
+	Unknown      []Element                             `xml:",any"`
+}
+
+type DomainInterfaceVirtualPortParameters struct {
+	InterfaceID  string `xml:"interfaceid,attr,omitempty"`
+	ProfileID    string `xml:"profileid,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainInterfaceTarget struct {
+	Dev          string `xml:"dev,attr"`
+	Managed      string `xml:"managed,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainInterfaceModel struct {
+	Type         string `xml:"type,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainInterfaceDriver struct {
+	Name         string    `xml:"name,attr,omitempty"`
+	TXMode       string    `xml:"txmode,attr,omitempty"`
+	IOEventFD    string    `xml:"ioeventfd,attr,omitempty"`
+	EventIDX     string    `xml:"event_idx,attr,omitempty"`
+	Queues       uint      `xml:"queues,attr,omitempty"`
+	RXQueueSize  uint      `xml:"rx_queue_size,attr,omitempty"`
+	TXQueueSize  uint      `xml:"tx_queue_size,attr,omitempty"`
+	UnknownAttrs []Attr    `xml:",any,attr"`
+	Unknown      []Element `xml:",any"`
+}
+
+type DomainInterfaceBandwidth struct {
+	Inbound      *DomainBandwidthParams `xml:"inbound"`
+	Outbound     *DomainBandwidthParams `xml:"outbound"`
+	UnknownAttrs []Attr                 `xml:",any,attr"`
+	Unknown      []Element              `xml:",any"`
+}
+
+// DomainBandwidthParams gives rates in KiB/s and burst sizes in KiB.
+type DomainBandwidthParams struct {
+	Average      *uint64 `xml:"average,attr"`
+	Peak         *uint64 `xml:"peak,attr"`
+	Floor        *uint64 `xml:"floor,attr"`
+	Burst        *uint64 `xml:"burst,attr"`
+	UnknownAttrs []Attr  `xml:",any,attr"`
+}
+
+type DomainInterfaceVLAN struct {
+	Trunk        string                   `xml:"trunk,attr,omitempty"`
+	Tags         []DomainInterfaceVLANTag `xml:"tag"`
+	UnknownAttrs []Attr                   `xml:",any,attr"`
+	Unknown      []Element                `xml:",any"`
+}
+
+type DomainInterfaceVLANTag struct {
+	ID           uint   `xml:"id,attr"`
+	NativeMode   string `xml:"nativeMode,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainInterfaceLink struct {
+	State        string `xml:"state,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainInterfaceMTU struct {
+	Size         uint   `xml:"size,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainInterfaceFilterRef struct {
+	Filter       string                       `xml:"filter,attr"`
+	Parameters   []DomainInterfaceFilterParam `xml:"parameter"`
+	UnknownAttrs []Attr                       `xml:",any,attr"`
+	Unknown      []Element                    `xml:",any"`
+}
+
+type DomainInterfaceFilterParam struct {
+	Name         string `xml:"name,attr"`
+	Value        string `xml:"value,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+// DomainChardev is a serial, parallel, console or channel device.
+type DomainChardev struct {
+	Type         string                 `xml:"type,attr,omitempty"`
+	TTY          string                 `xml:"tty,attr,omitempty"`
+	Source       *DomainChardevSource   `xml:"source"`
+	Protocol     *DomainChardevProtocol `xml:"protocol"`
+	Target       *DomainChardevTarget   `xml:"target"`
+	Log          *DomainChardevLog      `xml:"log"`
+	Alias        *DomainAlias           `xml:"alias"`
+	Address      *DomainAddress         `xml:"address"`
+	UnknownAttrs []Attr                 `xml:",any,attr"`
+	Unknown      []Element              `xml:",any"`
+}
+
+type DomainChardevSource struct {
+	Path         string    `xml:"path,attr,omitempty"`
+	Mode         string    `xml:"mode,attr,omitempty"`
+	Host         string    `xml:"host,attr,omitempty"`
+	Service      string    `xml:"service,attr,omitempty"`
+	Channel      string    `xml:"channel,attr,omitempty"`
+	Append       string    `xml:"append,attr,omitempty"`
+	UnknownAttrs []Attr    `xml:",any,attr"`
+	Unknown      []Element `xml:",any"`
+}
+
+type DomainChardevProtocol struct {
+	Type         string `xml:"type,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainChardevTarget struct {
+	Type         string                    `xml:"type,attr,omitempty"`
+	Name         string                    `xml:"name,attr,omitempty"`
+	State        string                    `xml:"state,attr,omitempty"`
+	Port         *uint                     `xml:"port,attr"`
+	Address      string                    `xml:"address,attr,omitempty"`
+	Model        *DomainChardevTargetModel `xml:"model"`
+	UnknownAttrs []Attr                    `xml:",any,attr"`
+	Unknown      []Element                 `xml:",any"`
+}
+
+type DomainChardevTargetModel struct {
+	Name         string `xml:"name,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainChardevLog struct {
+	File         string `xml:"file,attr"`
+	Append       string `xml:"append,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainInput struct {
+	Type         string             `xml:"type,attr"`
+	Bus          string             `xml:"bus,attr,omitempty"`
+	Model        string             `xml:"model,attr,omitempty"`
+	Source       *DomainInputSource `xml:"source"`
+	Alias        *DomainAlias       `xml:"alias"`
+	Address      *DomainAddress     `xml:"address"`
+	UnknownAttrs []Attr             `xml:",any,attr"`
+	Unknown      []Element          `xml:",any"`
+}
+
+type DomainInputSource struct {
+	EVDev        string `xml:"evdev,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainTPM struct {
+	Model        string            `xml:"model,attr,omitempty"`
+	Backend      *DomainTPMBackend `xml:"backend"`
+	Alias        *DomainAlias      `xml:"alias"`
+	Address      *DomainAddress    `xml:"address"`
+	UnknownAttrs []Attr            `xml:",any,attr"`
+	Unknown      []Element         `xml:",any"`
+}
+
+type DomainTPMBackend struct {
+	Type         string                  `xml:"type,attr"`
+	Version      string                  `xml:"version,attr,omitempty"`
+	Device       *DomainTPMBackendDevice `xml:"device"`
+	UnknownAttrs []Attr                  `xml:",any,attr"`
+	Unknown      []Element               `xml:",any"`
+}
+
+type DomainTPMBackendDevice struct {
+	Path         string `xml:"path,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+// DomainGraphic is a graphical framebuffer such as VNC or SPICE.
+type DomainGraphic struct {
+	Type          string                `xml:"type,attr"`
+	Port          string                `xml:"port,attr,omitempty"`
+	TLSPort       string                `xml:"tlsPort,attr,omitempty"`
+	AutoPort      string                `xml:"autoport,attr,omitempty"`
+	WebSocket     string                `xml:"websocket,attr,omitempty"`
+	Listen        string                `xml:"listen,attr,omitempty"`
+	Socket        string                `xml:"socket,attr,omitempty"`
+	Passwd        string                `xml:"passwd,attr,omitempty"`
+	PasswdValidTo string                `xml:"passwdValidTo,attr,omitempty"`
+	Keymap        string                `xml:"keymap,attr,omitempty"`
+	SharePolicy   string                `xml:"sharePolicy,attr,omitempty"`
+	DefaultMode   string                `xml:"defaultMode,attr,omitempty"`
+	Display       string                `xml:"display,attr,omitempty"`
+	XAuth         string                `xml:"xauth,attr,omitempty"`
+	FullScreen    string                `xml:"fullscreen,attr,omitempty"`
+	Listeners     []DomainGraphicListen `xml:"listen"`
+	GL            *DomainGraphicGL      `xml:"gl"`
+	UnknownAttrs  []Attr                `xml:",any,attr"`
+	Unknown       []Element             `xml:",any"`
+}
+
+type DomainGraphicListen struct {
+	Type         string `xml:"type,attr"`
+	Address      string `xml:"address,attr,omitempty"`
+	Network      string `xml:"network,attr,omitempty"`
+	Socket       string `xml:"socket,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainGraphicGL struct {
+	Enable       string `xml:"enable,attr,omitempty"`
+	RenderNode   string `xml:"rendernode,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainSound struct {
+	Model        string             `xml:"model,attr"`
+	Codecs       []DomainSoundCodec `xml:"codec"`
+	Alias        *DomainAlias       `xml:"alias"`
+	Address      *DomainAddress     `xml:"address"`
+	UnknownAttrs []Attr             `xml:",any,attr"`
+	Unknown      []Element          `xml:",any"`
+}
+
+type DomainSoundCodec struct {
+	Type         string `xml:"type,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainVideo struct {
+	Model        *DomainVideoModel  `xml:"model"`
+	Driver       *DomainVideoDriver `xml:"driver"`
+	Alias        *DomainAlias       `xml:"alias"`
+	Address      *DomainAddress     `xml:"address"`
+	UnknownAttrs []Attr             `xml:",any,attr"`
+	Unknown      []Element          `xml:",any"`
+}
+
+type DomainVideoModel struct {
+	Type         string            `xml:"type,attr"`
+	Heads        uint              `xml:"heads,attr,omitempty"`
+	Ram          uint              `xml:"ram,attr,omitempty"`
+	VRam         uint              `xml:"vram,attr,omitempty"`
+	VRam64       uint              `xml:"vram64,attr,omitempty"`
+	VGAMem       uint              `xml:"vgamem,attr,omitempty"`
+	Primary      string            `xml:"primary,attr,omitempty"`
+	Acceleration *DomainVideoAccel `xml:"acceleration"`
+	UnknownAttrs []Attr            `xml:",any,attr"`
+	Unknown      []Element         `xml:",any"`
+}
+
+type DomainVideoAccel struct {
+	Accel3D      string `xml:"accel3d,attr,omitempty"`
+	Accel2D      string `xml:"accel2d,attr,omitempty"`
+	RenderNode   string `xml:"rendernode,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainVideoDriver struct {
+	Name         string `xml:"name,attr,omitempty"`
+	VGAConf      string `xml:"vgaconf,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainHostdev struct {
+	Mode         string               `xml:"mode,attr"`
+	Type         string               `xml:"type,attr,omitempty"`
+	Managed      string               `xml:"managed,attr,omitempty"`
+	SGIO         string               `xml:"sgio,attr,omitempty"`
+	RawIO        string               `xml:"rawio,attr,omitempty"`
+	Model        string               `xml:"model,attr,omitempty"`
+	Source       *DomainHostdevSource `xml:"source"`
+	Driver       *DomainHostdevDriver `xml:"driver"`
+	Boot         *DomainDeviceBoot    `xml:"boot"`
+	Alias        *DomainAlias         `xml:"alias"`
+	Address      *DomainAddress       `xml:"address"`
+	UnknownAttrs []Attr               `xml:",any,attr"`
+	Unknown      []Element            `xml:",any"`
+}
+
+type DomainHostdevSource struct {
+	StartupPolicy string             `xml:"startupPolicy,attr,omitempty"`
+	Vendor        *DomainHostdevID   `xml:"vendor"`
+	Product       *DomainHostdevID   `xml:"product"`
+	Adapter       *DomainHostdevName `xml:"adapter"`
+	Address       *DomainAddress     `xml:"address"`
+	UnknownAttrs  []Attr             `xml:",any,attr"`
+	Unknown       []Element          `xml:",any"`
+}
+
+type DomainHostdevID struct {
+	ID           string `xml:"id,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainHostdevName struct {
+	Name         string `xml:"name,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainHostdevDriver struct {
+	Name         string `xml:"name,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainWatchdog struct {
+	Model        string         `xml:"model,attr"`
+	Action       string         `xml:"action,attr,omitempty"`
+	Alias        *DomainAlias   `xml:"alias"`
+	Address      *DomainAddress `xml:"address"`
+	UnknownAttrs []Attr         `xml:",any,attr"`
+	Unknown      []Element      `xml:",any"`
+}
+
+type DomainMemBalloon struct {
+	Model             string                 `xml:"model,attr"`
+	AutoDeflate       string                 `xml:"autodeflate,attr,omitempty"`
+	FreePageReporting string                 `xml:"freePageReporting,attr,omitempty"`
+	Stats             *DomainMemBalloonStats `xml:"stats"`
+	Alias             *DomainAlias           `xml:"alias"`
+	Address           *DomainAddress         `xml:"address"`
+	UnknownAttrs      []Attr                 `xml:",any,attr"`
+	Unknown           []Element              `xml:",any"`
+}
+
+type DomainMemBalloonStats struct {
+	Period       uint   `xml:"period,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type DomainRNG struct {
+	Model        string            `xml:"model,attr"`
+	Rate         *DomainRNGRate    `xml:"rate"`
+	Backend      *DomainRNGBackend `xml:"backend"`
+	Alias        *DomainAlias      `xml:"alias"`
+	Address      *DomainAddress    `xml:"address"`
+	UnknownAttrs []Attr            `xml:",any,attr"`
+	Unknown      []Element         `xml:",any"`
+}
+
+type DomainRNGRate struct {
+	Bytes        uint   `xml:"bytes,attr"`
+	Period       uint   `xml:"period,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+// DomainRNGBackend is either a device path given as Value (model
+// "random") or a chardev described by the elements kept in Unknown.
+type DomainRNGBackend struct {
+	Model        string    `xml:"model,attr"`
+	Type         string    `xml:"type,attr,omitempty"`
+	Value        string    `xml:",chardata"`
+	UnknownAttrs []Attr    `xml:",any,attr"`
+	Unknown      []Element `xml:",any"`
+}
+
+type DomainPanic struct {
+	Model        string         `xml:"model,attr,omitempty"`
+	Alias        *DomainAlias   `xml:"alias"`
+	Address      *DomainAddress `xml:"address"`
+	UnknownAttrs []Attr         `xml:",any,attr"`
+	Unknown      []Element      `xml:",any"`
+}
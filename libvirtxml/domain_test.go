@@ -0,0 +1,122 @@
+package libvirtxml
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testDomain = `<domain type='kvm' id='3' xmlns:qemu='http://libvirt.org/schemas/domain/qemu/1.0'>
+  <name>guest</name>
+  <uuid>a6b0e1c2-3d4e-4f50-8a9b-0c1d2e3f4a5b</uuid>
+  <metadata>
+    <app:tags xmlns:app="http://example.org/app/1"><app:tag>web</app:tag></app:tags>
+  </metadata>
+  <memory unit='KiB'>2097152</memory>
+  <currentMemory unit='KiB'>1048576</currentMemory>
+  <vcpu placement='static' current='2'>4</vcpu>
+  <os>
+    <type arch='x86_64' machine='pc-q35-5.2'>hvm</type>
+    <boot dev='hd'/>
+  </os>
+  <features>
+    <acpi/>
+    <apic/>
+    <hyperv mode='custom'>
+      <relaxed state='on'/>
+      <spinlocks state='on' retries='8191'/>
+    </hyperv>
+    <sev-future enabled='yes'/>
+  </features>
+  <cpu mode='host-model' check='partial'>
+    <topology sockets='1' cores='2' threads='2'/>
+    <numa>
+      <cell id='0' cpus='0-3' memory='2097152' unit='KiB'/>
+    </numa>
+  </cpu>
+  <clock offset='utc'>
+    <timer name='rtc' tickpolicy='catchup'/>
+  </clock>
+  <on_poweroff>destroy</on_poweroff>
+  <devices>
+    <emulator>/usr/bin/qemu-system-x86_64</emulator>
+    <disk type='file' device='disk'>
+      <driver name='qemu' type='qcow2' cache='none'/>
+      <source file='/var/lib/libvirt/images/guest.qcow2' index='1'/>
+      <target dev='vda' bus='virtio'/>
+      <readonly/>
+      <address type='pci' domain='0x0000' bus='0x04' slot='0x00' function='0x0'/>
+    </disk>
+    <interface type='network'>
+      <mac address='52:54:00:12:34:56'/>
+      <source network='default'/>
+      <model type='virtio'/>
+      <bandwidth>
+        <inbound average='1000' peak='5000'/>
+      </bandwidth>
+    </interface>
+    <graphics type='vnc' port='-1' autoport='yes'>
+      <listen type='address' address='127.0.0.1'/>
+    </graphics>
+    <shmem name='ivshmem'><model type='ivshmem-plain'/></shmem>
+  </devices>
+  <qemu:commandline>
+    <qemu:arg value='-newarg'/>
+  </qemu:commandline>
+</domain>`
+
+func TestDomainRoundTrip(t *testing.T) {
+	var d Domain
+	if err := d.Unmarshal(testDomain); err != nil {
+		t.Fatal(err)
+	}
+
+	if d.Type != "kvm" || d.ID == nil || *d.ID != 3 || d.Name != "guest" {
+		t.Errorf("got type %q, id %v, name %q", d.Type, d.ID, d.Name)
+	}
+	if d.Memory.Value != 2097152 || d.Memory.Unit != "KiB" {
+		t.Errorf("got memory %+v", d.Memory)
+	}
+	if d.VCPU.Value != 4 || d.VCPU.Current != 2 {
+		t.Errorf("got vcpu %+v", d.VCPU)
+	}
+	if d.CPU.Topology.Threads != 2 || len(d.CPU.NUMA.Cells) != 1 {
+		t.Errorf("got cpu %+v", d.CPU)
+	}
+	if d.Features.HyperV.Spinlocks.Retries != 8191 {
+		t.Errorf("got hyperv %+v", d.Features.HyperV)
+	}
+	disk := d.Devices.Disks[0]
+	if disk.Source.File != "/var/lib/libvirt/images/guest.qcow2" || disk.Target.Dev != "vda" || disk.ReadOnly == nil {
+		t.Errorf("got disk %+v", disk)
+	}
+	iface := d.Devices.Interfaces[0]
+	if iface.MAC.Address != "52:54:00:12:34:56" || *iface.Bandwidth.Inbound.Peak != 5000 {
+		t.Errorf("got interface %+v", iface)
+	}
+
+	doc, err := d.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{
+		`xmlns:qemu="http://libvirt.org/schemas/domain/qemu/1.0"`,
+		`<commandline xmlns="http://libvirt.org/schemas/domain/qemu/1.0">`,
+		`<qemu:arg value='-newarg'/>`,
+		`<app:tags xmlns:app="http://example.org/app/1"><app:tag>web</app:tag></app:tags>`,
+		`<sev-future enabled="yes">`,
+		`<shmem name="ivshmem"><model type='ivshmem-plain'/></shmem>`,
+	} {
+		if !strings.Contains(doc, s) {
+			t.Errorf("%s missing from\n%s", s, doc)
+		}
+	}
+
+	var again Domain
+	if err := again.Unmarshal(doc); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(d, again) {
+		t.Errorf("round trip changed the domain:\n%s", doc)
+	}
+}
@@ -0,0 +1,236 @@
+package libvirtxml
+
+import (
+	"encoding/xml"
+)
+
+// Network is a libvirt virtual network definition.
+// See https://libvirt.org/formatnetwork.html
+type Network struct {
+	XMLName             xml.Name             `xml:"network"`
+	IPv6                string               `xml:"ipv6,attr,omitempty"`
+	TrustGuestRXFilters string               `xml:"trustGuestRxFilters,attr,omitempty"`
+	Connections         uint                 `xml:"connections,attr,omitempty"`
+	Name                string               `xml:"name,omitempty"`
+	UUID                string               `xml:"uuid,omitempty"`
+	Metadata            *DomainMetadata      `xml:"metadata"`
+	Forward             *NetworkForward      `xml:"forward"`
+	Bridge              *NetworkBridge       `xml:"bridge"`
+	MTU                 *NetworkMTU          `xml:"mtu"`
+	MAC                 *NetworkMAC          `xml:"mac"`
+	Domain              *NetworkDomain       `xml:"domain"`
+	DNS                 *NetworkDNS          `xml:"dns"`
+	VLAN                *DomainInterfaceVLAN `xml:"vlan"`
+	Bandwidth           *NetworkBandwidth    `xml:"bandwidth"`
+	PortGroups          []NetworkPortGroup   `xml:"portgroup"`
+	IPs                 []NetworkIP          `xml:"ip"`
+	Routes              []NetworkRoute       `xml:"route"`
+	VirtualPort         *NetworkVirtualPort  `xml:"virtualport"`
+	UnknownAttrs        []Attr               `xml:",any,attr"`
+	Unknown             []Element            `xml:",any"`
+}
+
+// Unmarshal parses doc into n.
+func (n *Network) Unmarshal(doc string) error {
+	return unmarshal(doc, n)
+}
+
+// Marshal returns n as an XML document.
+func (n *Network) Marshal() (string, error) {
+	return marshal(n)
+}
+
+type NetworkForward struct {
+	Mode         string                    `xml:"mode,attr,omitempty"`
+	Dev          string                    `xml:"dev,attr,omitempty"`
+	Managed      string                    `xml:"managed,attr,omitempty"`
+	Driver       *NetworkForwardDriver     `xml:"driver"`
+	PFs          []NetworkForwardPF        `xml:"pf"`
+	NAT          *NetworkForwardNAT        `xml:"nat"`
+	Interfaces   []NetworkForwardInterface `xml:"interface"`
+	Addresses    []DomainAddress           `xml:"address"`
+	UnknownAttrs []Attr                    `xml:",any,attr"`
+	Unknown      []Element                 `xml:",any"`
+}
+
+type NetworkForwardDriver struct {
+	Name         string `xml:"name,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type NetworkForwardPF struct {
+	Dev          string `xml:"dev,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type NetworkForwardNAT struct {
+	IPv6         string                   `xml:"ipv6,attr,omitempty"`
+	Addresses    []NetworkForwardNATRange `xml:"address"`
+	Ports        []NetworkForwardNATPort  `xml:"port"`
+	UnknownAttrs []Attr                   `xml:",any,attr"`
+	Unknown      []Element                `xml:",any"`
+}
+
+type NetworkForwardNATRange struct {
+	Start        string `xml:"start,attr"`
+	End          string `xml:"end,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type NetworkForwardNATPort struct {
+	Start        uint   `xml:"start,attr"`
+	End          uint   `xml:"end,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type NetworkForwardInterface struct {
+	Dev          string `xml:"dev,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type NetworkBridge struct {
+	Name            string `xml:"name,attr,omitempty"`
+	STP             string `xml:"stp,attr,omitempty"`
+	Delay           string `xml:"delay,attr,omitempty"`
+	MACTableManager string `xml:"macTableManager,attr,omitempty"`
+	Zone            string `xml:"zone,attr,omitempty"`
+	UnknownAttrs    []Attr `xml:",any,attr"`
+}
+
+type NetworkMTU struct {
+	Size         uint   `xml:"size,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type NetworkMAC struct {
+	Address      string `xml:"address,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type NetworkDomain struct {
+	Name         string `xml:"name,attr,omitempty"`
+	LocalOnly    string `xml:"localOnly,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type NetworkDNS struct {
+	Enable            string                `xml:"enable,attr,omitempty"`
+	ForwardPlainNames string                `xml:"forwardPlainNames,attr,omitempty"`
+	Forwarders        []NetworkDNSForwarder `xml:"forwarder"`
+	TXTs              []NetworkDNSTXT       `xml:"txt"`
+	Hosts             []NetworkDNSHost      `xml:"host"`
+	SRVs              []NetworkDNSSRV       `xml:"srv"`
+	UnknownAttrs      []Attr                `xml:",any,attr"`
+	Unknown           []Element             `xml:",any"`
+}
+
+type NetworkDNSForwarder struct {
+	Domain       string `xml:"domain,attr,omitempty"`
+	Addr         string `xml:"addr,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type NetworkDNSTXT struct {
+	Name         string `xml:"name,attr"`
+	Value        string `xml:"value,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type NetworkDNSHost struct {
+	IP           string    `xml:"ip,attr"`
+	Hostnames    []string  `xml:"hostname"`
+	UnknownAttrs []Attr    `xml:",any,attr"`
+	Unknown      []Element `xml:",any"`
+}
+
+type NetworkDNSSRV struct {
+	Service      string `xml:"service,attr,omitempty"`
+	Protocol     string `xml:"protocol,attr,omitempty"`
+	Target       string `xml:"target,attr,omitempty"`
+	Port         uint   `xml:"port,attr,omitempty"`
+	Priority     uint   `xml:"priority,attr,omitempty"`
+	Weight       uint   `xml:"weight,attr,omitempty"`
+	Domain       string `xml:"domain,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type NetworkBandwidth struct {
+	Inbound      *DomainBandwidthParams `xml:"inbound"`
+	Outbound     *DomainBandwidthParams `xml:"outbound"`
+	UnknownAttrs []Attr                 `xml:",any,attr"`
+	Unknown      []Element              `xml:",any"`
+}
+
+type NetworkPortGroup struct {
+	Name         string               `xml:"name,attr"`
+	Default      string               `xml:"default,attr,omitempty"`
+	VLAN         *DomainInterfaceVLAN `xml:"vlan"`
+	VirtualPort  *NetworkVirtualPort  `xml:"virtualport"`
+	Bandwidth    *NetworkBandwidth    `xml:"bandwidth"`
+	UnknownAttrs []Attr               `xml:",any,attr"`
+	Unknown      []Element            `xml:",any"`
+}
+
+type NetworkVirtualPort struct {
+	Type         string                                `xml:"type,attr,omitempty"`
+	Parameters   *DomainInterfaceVirtualPortParameters `xml:"parameters"`
+	UnknownAttrs []Attr                                `xml:",any,attr"`
+	Unknown      []Element                             `xml:",any"`
+}
+
+type NetworkIP struct {
+	Address      string       `xml:"address,attr,omitempty"`
+	Family       string       `xml:"family,attr,omitempty"`
+	Netmask      string       `xml:"netmask,attr,omitempty"`
+	Prefix       uint         `xml:"prefix,attr,omitempty"`
+	LocalPtr     string       `xml:"localPtr,attr,omitempty"`
+	TFTP         *NetworkTFTP `xml:"tftp"`
+	DHCP         *NetworkDHCP `xml:"dhcp"`
+	UnknownAttrs []Attr       `xml:",any,attr"`
+	Unknown      []Element    `xml:",any"`
+}
+
+type NetworkTFTP struct {
+	Root         string `xml:"root,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type NetworkDHCP struct {
+	Ranges       []NetworkDHCPRange `xml:"range"`
+	Hosts        []NetworkDHCPHost  `xml:"host"`
+	Bootp        *NetworkBootp      `xml:"bootp"`
+	UnknownAttrs []Attr             `xml:",any,attr"`
+	Unknown      []Element          `xml:",any"`
+}
+
+type NetworkDHCPRange struct {
+	Start        string    `xml:"start,attr"`
+	End          string    `xml:"end,attr"`
+	UnknownAttrs []Attr    `xml:",any,attr"`
+	Unknown      []Element `xml:",any"`
+}
+
+type NetworkDHCPHost struct {
+	ID           string    `xml:"id,attr,omitempty"`
+	MAC          string    `xml:"mac,attr,omitempty"`
+	Name         string    `xml:"name,attr,omitempty"`
+	IP           string    `xml:"ip,attr,omitempty"`
+	UnknownAttrs []Attr    `xml:",any,attr"`
+	Unknown      []Element `xml:",any"`
+}
+
+type NetworkBootp struct {
+	File         string `xml:"file,attr,omitempty"`
+	Server       string `xml:"server,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type NetworkRoute struct {
+	Family       string `xml:"family,attr,omitempty"`
+	Address      string `xml:"address,attr,omitempty"`
+	Netmask      string `xml:"netmask,attr,omitempty"`
+	Prefix       uint   `xml:"prefix,attr,omitempty"`
+	Gateway      string `xml:"gateway,attr,omitempty"`
+	Metric       uint   `xml:"metric,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
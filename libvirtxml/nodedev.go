@@ -0,0 +1,99 @@
+package libvirtxml
+
+import (
+	"encoding/xml"
+)
+
+// NodeDevice is a libvirt host device description.
+// See https://libvirt.org/formatnode.html
+type NodeDevice struct {
+	XMLName      xml.Name               `xml:"device"`
+	Name         string                 `xml:"name,omitempty"`
+	Path         string                 `xml:"path,omitempty"`
+	DevNodes     []NodeDeviceDevNode    `xml:"devnode"`
+	Parent       string                 `xml:"parent,omitempty"`
+	Driver       *NodeDeviceDriver      `xml:"driver"`
+	Capabilities []NodeDeviceCapability `xml:"capability"`
+	UnknownAttrs []Attr                 `xml:",any,attr"`
+	Unknown      []Element              `xml:",any"`
+}
+
+// Unmarshal parses doc into n.
+func (n *NodeDevice) Unmarshal(doc string) error {
+	return unmarshal(doc, n)
+}
+
+// Marshal returns n as an XML document.
+func (n *NodeDevice) Marshal() (string, error) {
+	return marshal(n)
+}
+
+type NodeDeviceDevNode struct {
+	Type         string `xml:"type,attr,omitempty"`
+	Path         string `xml:",chardata"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type NodeDeviceDriver struct {
+	Name         string    `xml:"name,omitempty"`
+	UnknownAttrs []Attr    `xml:",any,attr"`
+	Unknown      []Element `xml:",any"`
+}
+
+// NodeDeviceCapability describes what the device is. Which fields are set
+// depends on Type, such as "pci", "usb_device", "net", "scsi_host",
+// "storage" or "system"; the rest of the description is kept in Unknown.
+type NodeDeviceCapability struct {
+	Type string `xml:"type,attr"`
+
+	// pci
+	Domain   *uint             `xml:"domain"`
+	Bus      *uint             `xml:"bus"`
+	Slot     *uint             `xml:"slot"`
+	Function *uint             `xml:"function"`
+	Product  *NodeDeviceIDName `xml:"product"`
+	Vendor   *NodeDeviceIDName `xml:"vendor"`
+	IOMMU    *NodeDeviceIOMMU  `xml:"iommuGroup"`
+
+	// usb_device
+	Device *uint `xml:"device"`
+
+	// net
+	Interface string          `xml:"interface,omitempty"`
+	Address   string          `xml:"address,omitempty"`
+	Link      *NodeDeviceLink `xml:"link"`
+
+	// scsi_host
+	Host     *uint  `xml:"host"`
+	UniqueID string `xml:"unique_id,omitempty"`
+
+	// storage
+	Block     string  `xml:"block,omitempty"`
+	DriveType string  `xml:"drive_type,omitempty"`
+	Model     string  `xml:"model,omitempty"`
+	Serial    string  `xml:"serial,omitempty"`
+	Size      *uint64 `xml:"size"`
+
+	UnknownAttrs []Attr    `xml:",any,attr"`
+	Unknown      []Element `xml:",any"`
+}
+
+// NodeDeviceIDName is a numeric id such as "0x8086" with its
+// human-readable name as content.
+type NodeDeviceIDName struct {
+	ID           string `xml:"id,attr,omitempty"`
+	Name         string `xml:",chardata"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type NodeDeviceIOMMU struct {
+	Number       uint      `xml:"number,attr"`
+	UnknownAttrs []Attr    `xml:",any,attr"`
+	Unknown      []Element `xml:",any"`
+}
+
+type NodeDeviceLink struct {
+	State        string `xml:"state,attr,omitempty"`
+	Speed        uint   `xml:"speed,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
@@ -0,0 +1,106 @@
+package libvirtxml
+
+import (
+	"encoding/xml"
+)
+
+// NWFilter is a libvirt network filter definition.
+// See https://libvirt.org/formatnwfilter.html
+type NWFilter struct {
+	XMLName      xml.Name        `xml:"filter"`
+	Name         string          `xml:"name,attr"`
+	Chain        string          `xml:"chain,attr,omitempty"`
+	Priority     *int            `xml:"priority,attr"`
+	UUID         string          `xml:"uuid,omitempty"`
+	Entries      []NWFilterEntry `xml:",any"`
+	UnknownAttrs []Attr          `xml:",any,attr"`
+}
+
+// Unmarshal parses doc into f.
+func (f *NWFilter) Unmarshal(doc string) error {
+	return unmarshal(doc, f)
+}
+
+// Marshal returns f as an XML document.
+func (f *NWFilter) Marshal() (string, error) {
+	return marshal(f)
+}
+
+// NWFilterEntry is a rule or a reference to another filter. Entries are
+// kept in document order, which decides between entries of equal priority.
+// Exactly one field is set.
+type NWFilterEntry struct {
+	Rule      *NWFilterRule
+	FilterRef *NWFilterRef
+	Unknown   *Element
+}
+
+func (e *NWFilterEntry) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	switch start.Name.Local {
+	case "rule":
+		e.Rule = new(NWFilterRule)
+		return d.DecodeElement(e.Rule, &start)
+	case "filterref":
+		e.FilterRef = new(NWFilterRef)
+		return d.DecodeElement(e.FilterRef, &start)
+	}
+	e.Unknown = new(Element)
+	return d.DecodeElement(e.Unknown, &start)
+}
+
+func (e NWFilterEntry) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	switch {
+	case e.Rule != nil:
+		return enc.EncodeElement(e.Rule, xml.StartElement{Name: xml.Name{Local: "rule"}})
+	case e.FilterRef != nil:
+		return enc.EncodeElement(e.FilterRef, xml.StartElement{Name: xml.Name{Local: "filterref"}})
+	case e.Unknown != nil:
+		return enc.Encode(e.Unknown)
+	}
+	return nil
+}
+
+type NWFilterRef struct {
+	Filter       string                       `xml:"filter,attr"`
+	Priority     *int                         `xml:"priority,attr"`
+	Parameters   []DomainInterfaceFilterParam `xml:"parameter"`
+	UnknownAttrs []Attr                       `xml:",any,attr"`
+	Unknown      []Element                    `xml:",any"`
+}
+
+type NWFilterRule struct {
+	Action       string          `xml:"action,attr"`
+	Direction    string          `xml:"direction,attr"`
+	Priority     *int            `xml:"priority,attr"`
+	StateMatch   string          `xml:"statematch,attr,omitempty"`
+	Matches      []NWFilterMatch `xml:",any"`
+	UnknownAttrs []Attr          `xml:",any,attr"`
+}
+
+// NWFilterMatch is the protocol element of a rule, such as <mac>, <ip> or
+// <tcp>, whose name is kept in XMLName. Values may be variables like
+// "$IP".
+type NWFilterMatch struct {
+	XMLName      xml.Name
+	SrcMACAddr   string `xml:"srcmacaddr,attr,omitempty"`
+	SrcMACMask   string `xml:"srcmacmask,attr,omitempty"`
+	DstMACAddr   string `xml:"dstmacaddr,attr,omitempty"`
+	DstMACMask   string `xml:"dstmacmask,attr,omitempty"`
+	ProtocolID   string `xml:"protocolid,attr,omitempty"`
+	SrcIPAddr    string `xml:"srcipaddr,attr,omitempty"`
+	SrcIPMask    string `xml:"srcipmask,attr,omitempty"`
+	DstIPAddr    string `xml:"dstipaddr,attr,omitempty"`
+	DstIPMask    string `xml:"dstipmask,attr,omitempty"`
+	SrcIPFrom    string `xml:"srcipfrom,attr,omitempty"`
+	SrcIPTo      string `xml:"srcipto,attr,omitempty"`
+	DstIPFrom    string `xml:"dstipfrom,attr,omitempty"`
+	DstIPTo      string `xml:"dstipto,attr,omitempty"`
+	Protocol     string `xml:"protocol,attr,omitempty"`
+	SrcPortStart string `xml:"srcportstart,attr,omitempty"`
+	SrcPortEnd   string `xml:"srcportend,attr,omitempty"`
+	DstPortStart string `xml:"dstportstart,attr,omitempty"`
+	DstPortEnd   string `xml:"dstportend,attr,omitempty"`
+	State        string `xml:"state,attr,omitempty"`
+	Comment      string `xml:"comment,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
@@ -0,0 +1,40 @@
+package libvirtxml
+
+import (
+	"encoding/xml"
+)
+
+// Secret is a libvirt secret definition. The secret value itself is never
+// part of it.
+// See https://libvirt.org/formatsecret.html
+type Secret struct {
+	XMLName      xml.Name     `xml:"secret"`
+	Ephemeral    string       `xml:"ephemeral,attr,omitempty"`
+	Private      string       `xml:"private,attr,omitempty"`
+	Description  string       `xml:"description,omitempty"`
+	UUID         string       `xml:"uuid,omitempty"`
+	Usage        *SecretUsage `xml:"usage"`
+	UnknownAttrs []Attr       `xml:",any,attr"`
+	Unknown      []Element    `xml:",any"`
+}
+
+// Unmarshal parses doc into s.
+func (s *Secret) Unmarshal(doc string) error {
+	return unmarshal(doc, s)
+}
+
+// Marshal returns s as an XML document.
+func (s *Secret) Marshal() (string, error) {
+	return marshal(s)
+}
+
+// SecretUsage tells what the secret is for. Which field is set depends on
+// Type: Volume for "volume", Target for "iscsi" and Name otherwise.
+type SecretUsage struct {
+	Type         string    `xml:"type,attr"`
+	Volume       string    `xml:"volume,omitempty"`
+	Name         string    `xml:"name,omitempty"`
+	Target       string    `xml:"target,omitempty"`
+	UnknownAttrs []Attr    `xml:",any,attr"`
+	Unknown      []Element `xml:",any"`
+}
@@ -0,0 +1,192 @@
+package libvirtxml
+
+import (
+	"encoding/xml"
+)
+
+// StoragePool is a libvirt storage pool definition.
+// See https://libvirt.org/formatstorage.html
+type StoragePool struct {
+	XMLName      xml.Name             `xml:"pool"`
+	Type         string               `xml:"type,attr,omitempty"`
+	Name         string               `xml:"name,omitempty"`
+	UUID         string               `xml:"uuid,omitempty"`
+	Capacity     *StorageSize         `xml:"capacity"`
+	Allocation   *StorageSize         `xml:"allocation"`
+	Available    *StorageSize         `xml:"available"`
+	Features     *StoragePoolFeatures `xml:"features"`
+	Source       *StoragePoolSource   `xml:"source"`
+	Target       *StoragePoolTarget   `xml:"target"`
+	UnknownAttrs []Attr               `xml:",any,attr"`
+	Unknown      []Element            `xml:",any"`
+}
+
+// Unmarshal parses doc into p.
+func (p *StoragePool) Unmarshal(doc string) error {
+	return unmarshal(doc, p)
+}
+
+// Marshal returns p as an XML document.
+func (p *StoragePool) Marshal() (string, error) {
+	return marshal(p)
+}
+
+// StorageSize is a size in Unit, bytes if Unit is empty.
+type StorageSize struct {
+	Value        uint64 `xml:",chardata"`
+	Unit         string `xml:"unit,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type StoragePoolFeatures struct {
+	COW          *DomainFeature `xml:"cow"`
+	UnknownAttrs []Attr         `xml:",any,attr"`
+	Unknown      []Element      `xml:",any"`
+}
+
+type StoragePoolSource struct {
+	Name         string                    `xml:"name,omitempty"`
+	Dir          *StoragePoolSourcePath    `xml:"dir"`
+	Hosts        []StoragePoolSourceHost   `xml:"host"`
+	Devices      []StoragePoolSourceDevice `xml:"device"`
+	Adapter      *StoragePoolSourceAdapter `xml:"adapter"`
+	Auth         *StoragePoolSourceAuth    `xml:"auth"`
+	Vendor       *DomainHostdevName        `xml:"vendor"`
+	Product      *DomainHostdevName        `xml:"product"`
+	Format       *DomainDiskFormat         `xml:"format"`
+	Initiator    *StoragePoolInitiator     `xml:"initiator"`
+	UnknownAttrs []Attr                    `xml:",any,attr"`
+	Unknown      []Element                 `xml:",any"`
+}
+
+type StoragePoolSourcePath struct {
+	Path         string `xml:"path,attr"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type StoragePoolSourceHost struct {
+	Name         string `xml:"name,attr"`
+	Port         string `xml:"port,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type StoragePoolSourceDevice struct {
+	Path          string    `xml:"path,attr"`
+	PartSeparator string    `xml:"part_separator,attr,omitempty"`
+	UnknownAttrs  []Attr    `xml:",any,attr"`
+	Unknown       []Element `xml:",any"`
+}
+
+type StoragePoolSourceAdapter struct {
+	Type         string    `xml:"type,attr,omitempty"`
+	Name         string    `xml:"name,attr,omitempty"`
+	Parent       string    `xml:"parent,attr,omitempty"`
+	WWNN         string    `xml:"wwnn,attr,omitempty"`
+	WWPN         string    `xml:"wwpn,attr,omitempty"`
+	UnknownAttrs []Attr    `xml:",any,attr"`
+	Unknown      []Element `xml:",any"`
+}
+
+type StoragePoolSourceAuth struct {
+	Type         string                       `xml:"type,attr"`
+	Username     string                       `xml:"username,attr"`
+	Secret       *StoragePoolSourceAuthSecret `xml:"secret"`
+	UnknownAttrs []Attr                       `xml:",any,attr"`
+	Unknown      []Element                    `xml:",any"`
+}
+
+type StoragePoolSourceAuthSecret struct {
+	Usage        string `xml:"usage,attr,omitempty"`
+	UUID         string `xml:"uuid,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+type StoragePoolInitiator struct {
+	IQN          *DomainHostdevName `xml:"iqn"`
+	UnknownAttrs []Attr             `xml:",any,attr"`
+	Unknown      []Element          `xml:",any"`
+}
+
+type StoragePoolTarget struct {
+	Path         string              `xml:"path,omitempty"`
+	Permissions  *StoragePermissions `xml:"permissions"`
+	UnknownAttrs []Attr              `xml:",any,attr"`
+	Unknown      []Element           `xml:",any"`
+}
+
+type StoragePermissions struct {
+	Owner        string    `xml:"owner,omitempty"`
+	Group        string    `xml:"group,omitempty"`
+	Mode         string    `xml:"mode,omitempty"`
+	Label        string    `xml:"label,omitempty"`
+	UnknownAttrs []Attr    `xml:",any,attr"`
+	Unknown      []Element `xml:",any"`
+}
+
+// StorageVolume is a libvirt storage volume definition.
+// See https://libvirt.org/formatstorage.html
+type StorageVolume struct {
+	XMLName      xml.Name                   `xml:"volume"`
+	Type         string                     `xml:"type,attr,omitempty"`
+	Name         string                     `xml:"name,omitempty"`
+	Key          string                     `xml:"key,omitempty"`
+	Capacity     *StorageSize               `xml:"capacity"`
+	Allocation   *StorageSize               `xml:"allocation"`
+	Physical     *StorageSize               `xml:"physical"`
+	Target       *StorageVolumeTarget       `xml:"target"`
+	BackingStore *StorageVolumeBackingStore `xml:"backingStore"`
+	UnknownAttrs []Attr                     `xml:",any,attr"`
+	Unknown      []Element                  `xml:",any"`
+}
+
+// Unmarshal parses doc into v.
+func (v *StorageVolume) Unmarshal(doc string) error {
+	return unmarshal(doc, v)
+}
+
+// Marshal returns v as an XML document.
+func (v *StorageVolume) Marshal() (string, error) {
+	return marshal(v)
+}
+
+type StorageVolumeTarget struct {
+	Path         string                 `xml:"path,omitempty"`
+	Format       *DomainDiskFormat      `xml:"format"`
+	Permissions  *StoragePermissions    `xml:"permissions"`
+	Compat       string                 `xml:"compat,omitempty"`
+	NoCOW        *Empty                 `xml:"nocow"`
+	Features     *StorageVolumeFeatures `xml:"features"`
+	Encryption   *StorageEncryption     `xml:"encryption"`
+	UnknownAttrs []Attr                 `xml:",any,attr"`
+	Unknown      []Element              `xml:",any"`
+}
+
+type StorageVolumeFeatures struct {
+	LazyRefcounts *Empty    `xml:"lazy_refcounts"`
+	UnknownAttrs  []Attr    `xml:",any,attr"`
+	Unknown       []Element `xml:",any"`
+}
+
+type StorageVolumeBackingStore struct {
+	Path         string              `xml:"path,omitempty"`
+	Format       *DomainDiskFormat   `xml:"format"`
+	Permissions  *StoragePermissions `xml:"permissions"`
+	UnknownAttrs []Attr              `xml:",any,attr"`
+	Unknown      []Element           `xml:",any"`
+}
+
+// StorageEncryption describes how a volume is encrypted; the secrets are
+// referenced by UUID or usage.
+type StorageEncryption struct {
+	Format       string                    `xml:"format,attr"`
+	Secrets      []StorageEncryptionSecret `xml:"secret"`
+	UnknownAttrs []Attr                    `xml:",any,attr"`
+	Unknown      []Element                 `xml:",any"`
+}
+
+type StorageEncryptionSecret struct {
+	Type         string `xml:"type,attr"`
+	UUID         string `xml:"uuid,attr,omitempty"`
+	Usage        string `xml:"usage,attr,omitempty"`
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
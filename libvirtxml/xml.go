@@ -0,0 +1,92 @@
+// Package libvirtxml models the XML documents libvirt exchanges, such as
+// the domain definitions passed to Connect.DomainDefineXML and returned by
+// Domain.GetXMLDesc.
+//
+// Every type keeps the attributes and child elements it has no field for in
+// UnknownAttrs and Unknown, so a document survives an Unmarshal/Marshal
+// round trip even when it uses parts of the schema not modelled here. Only
+// the order of child elements within their parent may change, and a prefixed
+// element such as <qemu:commandline> is written with a default namespace
+// declaration instead; neither makes a difference to libvirt.
+package libvirtxml
+
+import (
+	"encoding/xml"
+)
+
+// Attr is an XML attribute the model has no field for.
+type Attr xml.Attr
+
+// UnmarshalXMLAttr keeps namespace declarations in a form that encoding/xml
+// writes back unchanged.
+func (a *Attr) UnmarshalXMLAttr(attr xml.Attr) error {
+	if attr.Name.Space == "xmlns" {
+		attr.Name = xml.Name{Local: "xmlns:" + attr.Name.Local}
+	}
+	*a = Attr(attr)
+	return nil
+}
+
+func (a Attr) MarshalXMLAttr(xml.Name) (xml.Attr, error) {
+	return xml.Attr(a), nil
+}
+
+// Element is an XML element the model has no field for. Its content is kept
+// verbatim.
+type Element struct {
+	XMLName xml.Name
+	Attrs   []Attr
+	Inner   string
+}
+
+func (e *Element) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var inner struct {
+		Inner string `xml:",innerxml"`
+	}
+	if err := d.DecodeElement(&inner, &start); err != nil {
+		return err
+	}
+
+	e.XMLName = start.Name
+	e.Attrs = nil
+	for _, attr := range start.Attr {
+		// the default namespace is written back from XMLName
+		if attr.Name.Space == "" && attr.Name.Local == "xmlns" {
+			continue
+		}
+		var a Attr
+		a.UnmarshalXMLAttr(attr)
+		e.Attrs = append(e.Attrs, a)
+	}
+	e.Inner = inner.Inner
+	return nil
+}
+
+func (e Element) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	start.Name = e.XMLName
+	start.Attr = nil
+	for _, a := range e.Attrs {
+		start.Attr = append(start.Attr, xml.Attr(a))
+	}
+	return enc.EncodeElement(struct {
+		Inner string `xml:",innerxml"`
+	}{e.Inner}, start)
+}
+
+// Empty is an element that has a meaning by its mere presence, such as
+// <readonly/>.
+type Empty struct {
+	UnknownAttrs []Attr `xml:",any,attr"`
+}
+
+func unmarshal(doc string, v interface{}) error {
+	return xml.Unmarshal([]byte(doc), v)
+}
+
+func marshal(v interface{}) (string, error) {
+	buf, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(buf), nil
+}
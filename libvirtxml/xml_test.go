@@ -0,0 +1,151 @@
+package libvirtxml
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type document interface {
+	Unmarshal(doc string) error
+	Marshal() (string, error)
+}
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		new  func() document
+		want []string
+	}{
+		{
+			name: "network",
+			doc: `<network>
+  <name>default</name>
+  <forward mode='nat'><nat><port start='1024' end='65535'/></nat></forward>
+  <bridge name='virbr0' stp='on' delay='0'/>
+  <ip address='192.168.122.1' netmask='255.255.255.0'>
+    <dhcp>
+      <range start='192.168.122.2' end='192.168.122.254'/>
+      <host mac='52:54:00:12:34:56' name='guest' ip='192.168.122.10'/>
+    </dhcp>
+  </ip>
+</network>`,
+			new:  func() document { return new(Network) },
+			want: []string{`<host mac="52:54:00:12:34:56" name="guest" ip="192.168.122.10">`},
+		},
+		{
+			name: "pool",
+			doc: `<pool type='dir'>
+  <name>default</name>
+  <capacity unit='bytes'>105088212992</capacity>
+  <target><path>/var/lib/libvirt/images</path><permissions><mode>0711</mode></permissions></target>
+</pool>`,
+			new:  func() document { return new(StoragePool) },
+			want: []string{`<capacity unit="bytes">105088212992</capacity>`, `<mode>0711</mode>`},
+		},
+		{
+			name: "volume",
+			doc: `<volume type='file'>
+  <name>guest.qcow2</name>
+  <capacity unit='G'>10</capacity>
+  <target><format type='qcow2'/><compat>1.1</compat><features><lazy_refcounts/></features></target>
+</volume>`,
+			new:  func() document { return new(StorageVolume) },
+			want: []string{`<lazy_refcounts></lazy_refcounts>`},
+		},
+		{
+			name: "secret",
+			doc: `<secret ephemeral='no' private='yes'>
+  <usage type='ceph'><name>client.admin</name></usage>
+</secret>`,
+			new:  func() document { return new(Secret) },
+			want: []string{`<usage type="ceph">`},
+		},
+		{
+			name: "nwfilter",
+			doc: `<filter name='clean-traffic' chain='root'>
+  <filterref filter='no-mac-spoofing'/>
+  <rule action='accept' direction='out' priority='-650'><mac protocolid='ipv4'/></rule>
+  <filterref filter='no-ip-spoofing'/>
+  <rule action='drop' direction='inout'><all/></rule>
+</filter>`,
+			new: func() document { return new(NWFilter) },
+			want: []string{`<filterref filter="no-mac-spoofing"></filterref>
+  <rule action="accept" direction="out" priority="-650">
+    <mac protocolid="ipv4"></mac>
+  </rule>
+  <filterref filter="no-ip-spoofing"></filterref>`},
+		},
+		{
+			name: "nodedev",
+			doc: `<device>
+  <name>pci_0000_00_1f_2</name>
+  <parent>computer</parent>
+  <driver><name>ahci</name></driver>
+  <capability type='pci'>
+    <domain>0</domain><bus>0</bus><slot>31</slot><function>2</function>
+    <product id='0x2922'>82801IR/IO/IH (ICH9R/DO/DH) 6 port SATA Controller [AHCI mode]</product>
+    <vendor id='0x8086'>Intel Corporation</vendor>
+    <pci-express/>
+  </capability>
+</device>`,
+			new:  func() document { return new(NodeDevice) },
+			want: []string{`<vendor id="0x8086">Intel Corporation</vendor>`, `<pci-express></pci-express>`},
+		},
+		{
+			name: "capabilities",
+			doc: `<capabilities>
+  <host>
+    <cpu><arch>x86_64</arch><pages unit='KiB' size='4'/></cpu>
+    <topology><cells num='1'><cell id='0'><pages unit='KiB' size='4'>1000</pages></cell></cells></topology>
+  </host>
+  <guest>
+    <os_type>hvm</os_type>
+    <arch name='x86_64'><wordsize>64</wordsize><domain type='kvm'/></arch>
+  </guest>
+</capabilities>`,
+			new:  func() document { return new(Caps) },
+			want: []string{`<pages size="4" unit="KiB"></pages>`, `<pages size="4" unit="KiB">1000</pages>`},
+		},
+		{
+			name: "domain capabilities",
+			doc: `<domainCapabilities>
+  <path>/usr/bin/qemu-system-x86_64</path>
+  <domain>kvm</domain>
+  <arch>x86_64</arch>
+  <devices>
+    <disk supported='yes'><enum name='bus'><value>virtio</value><value>sata</value></enum></disk>
+  </devices>
+</domainCapabilities>`,
+			new:  func() document { return new(DomainCaps) },
+			want: []string{`<value>virtio</value>`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := tt.new()
+			if err := v.Unmarshal(tt.doc); err != nil {
+				t.Fatal(err)
+			}
+			doc, err := v.Marshal()
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, s := range tt.want {
+				if !strings.Contains(doc, s) {
+					t.Errorf("%s missing from\n%s", s, doc)
+				}
+			}
+
+			again := tt.new()
+			if err := again.Unmarshal(doc); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(v, again) {
+				t.Errorf("round trip changed the document:\n%s", doc)
+			}
+		})
+	}
+}
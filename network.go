@@ -1,18 +1,14 @@
 package libvirt
 
 import (
-	"sync"
+	"context"
 
 	"github.com/godbus/dbus"
 )
 
 type Network struct {
-	conn   *Conn
-	object dbus.BusObject
-	path   dbus.ObjectPath
-
-	sigs  map[<-chan *dbus.Signal]struct{}
-	sigmu sync.Mutex
+	conn *Conn
+	path dbus.ObjectPath
 
 	//Active bool
 	//Autostart bool
@@ -21,91 +17,177 @@ type Network struct {
 	//UUID string
 }
 
+// NetworkDHCPLease is an element of the leases returned by Network.GetDHCPLeases.
+type NetworkDHCPLease struct {
+	Iface      string
+	ExpiryTime int64
+	Type       int32
+	Mac        string
+	Iaid       string
+	IPAddr     string
+	Prefix     uint32
+	Hostname   string
+	ClientID   string
+}
+
 // NewNetwork() TODO
 func NewNetwork(c *Conn, path dbus.ObjectPath) *Network {
-	m := &Network{conn: c}
-	if path != "" {
-		m.object = c.conn.Object("org.libvirt", path)
-	} else {
-		m.object = c.object
+	m := &Network{conn: c, path: path}
+	if path == "" {
+		m.path = c.path
 	}
-	m.path = c.object.Path()
-
-	m.sigmu.Lock()
-	m.sigs = make(map[<-chan *dbus.Signal]struct{})
-	m.sigmu.Unlock()
 
 	return m
 }
 
 // Create See https://libvirt.org/html/libvirt-libvirt-network.html#virNetworkCreate
 func (m *Network) Create() (err error) {
-	err = m.object.Call("org.libvirt.Network.Create", 0).Store()
+	return m.CreateContext(context.Background())
+}
+
+// CreateContext is like Create but gives up waiting for the reply once ctx is done.
+func (m *Network) CreateContext(ctx context.Context) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Network.Create").Store()
 	return
 }
 
 // Destroy See https://libvirt.org/html/libvirt-libvirt-network.html#virNetworkDestroy
 func (m *Network) Destroy() (err error) {
-	err = m.object.Call("org.libvirt.Network.Destroy", 0).Store()
+	return m.DestroyContext(context.Background())
+}
+
+// DestroyContext is like Destroy but gives up waiting for the reply once ctx is done.
+func (m *Network) DestroyContext(ctx context.Context) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Network.Destroy").Store()
 	return
 }
 
 // GetDHCPLeases See https://libvirt.org/html/libvirt-libvirt-network.html#virNetworkGetDHCPLeases Empty string can be used to pass a NULL as @mac argument. Empty string will be returned in output for NULL variables.
-func (m *Network) GetDHCPLeases(mac string, flags uint32) (leases []interface{}, err error) {
-	err = m.object.Call("org.libvirt.Network.GetDHCPLeases", 0, mac, flags).Store(&leases)
+func (m *Network) GetDHCPLeases(mac string, flags uint32) (leases []NetworkDHCPLease, err error) {
+	return m.GetDHCPLeasesContext(context.Background(), mac, flags)
+}
+
+// GetDHCPLeasesContext is like GetDHCPLeases but gives up waiting for the reply once ctx is done.
+func (m *Network) GetDHCPLeasesContext(ctx context.Context, mac string, flags uint32) (leases []NetworkDHCPLease, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Network.GetDHCPLeases", mac, flags).Store(&leases)
 	return
 }
 
 // GetXMLDesc See https://libvirt.org/html/libvirt-libvirt-network.html#virNetworkGetXMLDesc
-func (m *Network) GetXMLDesc(flags uint32) (xml string, err error) {
-	err = m.object.Call("org.libvirt.Network.GetXMLDesc", 0, flags).Store(&xml)
+func (m *Network) GetXMLDesc(flags NetworkXMLFlags) (xml string, err error) {
+	return m.GetXMLDescContext(context.Background(), flags)
+}
+
+// GetXMLDescContext is like GetXMLDesc but gives up waiting for the reply once ctx is done.
+func (m *Network) GetXMLDescContext(ctx context.Context, flags NetworkXMLFlags) (xml string, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Network.GetXMLDesc", flags).Store(&xml)
 	return
 }
 
 // Undefine See https://libvirt.org/html/libvirt-libvirt-network.html#virNetworkUndefine
 func (m *Network) Undefine() (err error) {
-	err = m.object.Call("org.libvirt.Network.Undefine", 0).Store()
+	return m.UndefineContext(context.Background())
+}
+
+// UndefineContext is like Undefine but gives up waiting for the reply once ctx is done.
+func (m *Network) UndefineContext(ctx context.Context) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Network.Undefine").Store()
 	return
 }
 
 // Update See https://libvirt.org/html/libvirt-libvirt-network.html#virNetworkUpdate
-func (m *Network) Update(command uint32, section uint32, parentIndex int32, xml string, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Network.Update", 0, command, section, parentIndex, xml, flags).Store()
+func (m *Network) Update(command NetworkUpdateCommand, section NetworkUpdateSection, parentIndex int32, xml string, flags NetworkUpdateFlags) (err error) {
+	return m.UpdateContext(context.Background(), command, section, parentIndex, xml, flags)
+}
+
+// UpdateContext is like Update but gives up waiting for the reply once ctx is done.
+func (m *Network) UpdateContext(ctx context.Context, command NetworkUpdateCommand, section NetworkUpdateSection, parentIndex int32, xml string, flags NetworkUpdateFlags) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Network.Update", command, section, parentIndex, xml, flags).Store()
 	return
 }
 
 // GetActive See https://libvirt.org/html/libvirt-libvirt-network.html#virNetworkIsActive
 func (m *Network) GetActive() (v bool, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.Network", "Active").Store(&v)
+	return m.GetActiveContext(context.Background())
+}
+
+// GetActiveContext is like GetActive but gives up waiting for the reply once ctx is done.
+func (m *Network) GetActiveContext(ctx context.Context) (v bool, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.Network", "Active").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
 
 // SetAutostart See https://libvirt.org/html/libvirt-libvirt-network.html#virNetworkGetAutostart and https://libvirt.org/html/libvirt-libvirt-network.html#virNetworkSetAutostart
 func (m *Network) SetAutostart(v bool) (err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Set", 0, "org.libvirt.Network", "Autostart", dbus.MakeVariant(v)).Store()
+	return m.SetAutostartContext(context.Background(), v)
+}
+
+// SetAutostartContext is like SetAutostart but gives up waiting for the reply once ctx is done.
+func (m *Network) SetAutostartContext(ctx context.Context, v bool) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Set", "org.libvirt.Network", "Autostart", dbus.MakeVariant(v)).Store()
 	return
 }
 
 // GetAutostart See https://libvirt.org/html/libvirt-libvirt-network.html#virNetworkGetAutostart and https://libvirt.org/html/libvirt-libvirt-network.html#virNetworkSetAutostart
 func (m *Network) GetAutostart() (v bool, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.Network", "Autostart").Store(&v)
+	return m.GetAutostartContext(context.Background())
+}
+
+// GetAutostartContext is like GetAutostart but gives up waiting for the reply once ctx is done.
+func (m *Network) GetAutostartContext(ctx context.Context) (v bool, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.Network", "Autostart").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
 
 // GetName See https://libvirt.org/html/libvirt-libvirt-network.html#virNetworkGetName
 func (m *Network) GetName() (v string, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.Network", "Name").Store(&v)
+	return m.GetNameContext(context.Background())
+}
+
+// GetNameContext is like GetName but gives up waiting for the reply once ctx is done.
+func (m *Network) GetNameContext(ctx context.Context) (v string, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.Network", "Name").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
 
 // GetPersistent See https://libvirt.org/html/libvirt-libvirt-network.html#virNetworkIsPersistent
 func (m *Network) GetPersistent() (v bool, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.Network", "Persistent").Store(&v)
+	return m.GetPersistentContext(context.Background())
+}
+
+// GetPersistentContext is like GetPersistent but gives up waiting for the reply once ctx is done.
+func (m *Network) GetPersistentContext(ctx context.Context) (v bool, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.Network", "Persistent").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
 
 // GetUUID See https://libvirt.org/html/libvirt-libvirt-network.html#virNetworkGetUUIDString
 func (m *Network) GetUUID() (v string, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.Network", "UUID").Store(&v)
+	return m.GetUUIDContext(context.Background())
+}
+
+// GetUUIDContext is like GetUUID but gives up waiting for the reply once ctx is done.
+func (m *Network) GetUUIDContext(ctx context.Context) (v string, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.Network", "UUID").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
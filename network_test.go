@@ -1,22 +1,65 @@
 package libvirt
 
-import "testing"
+import (
+	"context"
+	"testing"
+	"time"
+)
 
 func TestListNetworks(t *testing.T) {
-	c, err := NewConn(DriverQEMU)
+	c, _ := newTestConn(t)
+	conn := NewConnect(c, "")
+
+	network, err := conn.NetworkLookupByNameObject("default")
 	if err != nil {
 		t.Fatal(err)
 	}
-	defer c.Close()
-	conn := NewConnect(c, "")
-	npath, err := conn.NetworkLookupByName("default")
+	def, err := network.Definition()
 	if err != nil {
 		t.Fatal(err)
 	}
-	network := NewNetwork(c, npath)
-	_, err = network.GetXMLDesc(0)
+	if def.UUID != fakeNetworkUUID || def.Bridge == nil || def.Bridge.Name != "virbr0" {
+		t.Errorf("got definition %+v", def)
+	}
+	if active, err := network.GetActive(); err != nil || !active {
+		t.Errorf("got active %v, %v", active, err)
+	}
+}
+
+func TestNetworkLifecycle(t *testing.T) {
+	c, _ := newTestConn(t)
+	conn := NewConnect(c, "")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := conn.NetworkEvents(ctx)
 	if err != nil {
 		t.Fatal(err)
 	}
 
+	network, err := conn.NetworkDefineXMLObject("<network><name>isolated</name></network>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, step := range []struct {
+		op    func() error
+		event NetworkEventType
+	}{
+		{func() error { return nil }, NetworkEventDefined},
+		{network.Create, NetworkEventStarted},
+		{network.Destroy, NetworkEventStopped},
+		{network.Undefine, NetworkEventUndefined},
+	} {
+		if err = step.op(); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case ev := <-events:
+			if ev.Event != step.event || ev.Network.path != network.path {
+				t.Errorf("got %+v, want %v", ev, step.event)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no %v event", step.event)
+		}
+	}
 }
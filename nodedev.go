@@ -1,18 +1,14 @@
 package libvirt
 
 import (
-	"sync"
+	"context"
 
 	"github.com/godbus/dbus"
 )
 
 type NodeDevice struct {
-	conn   *Conn
-	object dbus.BusObject
-	path   dbus.ObjectPath
-
-	sigs  map[<-chan *dbus.Signal]struct{}
-	sigmu sync.Mutex
+	conn *Conn
+	path dbus.ObjectPath
 
 	//Name string
 	//Parent string
@@ -20,65 +16,106 @@ type NodeDevice struct {
 
 // NewNodeDevice() TODO
 func NewNodeDevice(c *Conn, path dbus.ObjectPath) *NodeDevice {
-	m := &NodeDevice{conn: c}
-	if path != "" {
-		m.object = c.conn.Object("org.libvirt", path)
-	} else {
-		m.object = c.object
+	m := &NodeDevice{conn: c, path: path}
+	if path == "" {
+		m.path = c.path
 	}
-	m.path = c.object.Path()
-
-	m.sigmu.Lock()
-	m.sigs = make(map[<-chan *dbus.Signal]struct{})
-	m.sigmu.Unlock()
 
 	return m
 }
 
 // Destroy See https://libvirt.org/html/libvirt-libvirt-nodedev.html#virNodeDeviceDestroy
 func (m *NodeDevice) Destroy() (err error) {
-	err = m.object.Call("org.libvirt.NodeDevice.Destroy", 0).Store()
+	return m.DestroyContext(context.Background())
+}
+
+// DestroyContext is like Destroy but gives up waiting for the reply once ctx is done.
+func (m *NodeDevice) DestroyContext(ctx context.Context) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.NodeDevice.Destroy").Store()
 	return
 }
 
 // Detach See https://libvirt.org/html/libvirt-libvirt-nodedev.html#virNodeDeviceDetachFlags
 func (m *NodeDevice) Detach(driverName string, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.NodeDevice.Detach", 0, driverName, flags).Store()
+	return m.DetachContext(context.Background(), driverName, flags)
+}
+
+// DetachContext is like Detach but gives up waiting for the reply once ctx is done.
+func (m *NodeDevice) DetachContext(ctx context.Context, driverName string, flags uint32) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.NodeDevice.Detach", driverName, flags).Store()
 	return
 }
 
 // GetXMLDesc See https://libvirt.org/html/libvirt-libvirt-nodedev.html#virNodeDeviceGetXMLDesc
 func (m *NodeDevice) GetXMLDesc(flags uint32) (xml string, err error) {
-	err = m.object.Call("org.libvirt.NodeDevice.GetXMLDesc", 0, flags).Store(&xml)
+	return m.GetXMLDescContext(context.Background(), flags)
+}
+
+// GetXMLDescContext is like GetXMLDesc but gives up waiting for the reply once ctx is done.
+func (m *NodeDevice) GetXMLDescContext(ctx context.Context, flags uint32) (xml string, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.NodeDevice.GetXMLDesc", flags).Store(&xml)
 	return
 }
 
 // ListCaps See https://libvirt.org/html/libvirt-libvirt-nodedev.html#virNodeDeviceListCaps
 func (m *NodeDevice) ListCaps() (names []string, err error) {
-	err = m.object.Call("org.libvirt.NodeDevice.ListCaps", 0).Store(&names)
+	return m.ListCapsContext(context.Background())
+}
+
+// ListCapsContext is like ListCaps but gives up waiting for the reply once ctx is done.
+func (m *NodeDevice) ListCapsContext(ctx context.Context) (names []string, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.NodeDevice.ListCaps").Store(&names)
 	return
 }
 
 // ReAttach See https://libvirt.org/html/libvirt-libvirt-nodedev.html#virNodeDeviceReAttach
 func (m *NodeDevice) ReAttach() (err error) {
-	err = m.object.Call("org.libvirt.NodeDevice.ReAttach", 0).Store()
+	return m.ReAttachContext(context.Background())
+}
+
+// ReAttachContext is like ReAttach but gives up waiting for the reply once ctx is done.
+func (m *NodeDevice) ReAttachContext(ctx context.Context) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.NodeDevice.ReAttach").Store()
 	return
 }
 
 // Reset See https://libvirt.org/html/libvirt-libvirt-nodedev.html#virNodeDeviceReset
 func (m *NodeDevice) Reset() (err error) {
-	err = m.object.Call("org.libvirt.NodeDevice.Reset", 0).Store()
+	return m.ResetContext(context.Background())
+}
+
+// ResetContext is like Reset but gives up waiting for the reply once ctx is done.
+func (m *NodeDevice) ResetContext(ctx context.Context) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.NodeDevice.Reset").Store()
 	return
 }
 
 // GetName See https://libvirt.org/html/libvirt-libvirt-nodedev.html#virNodeDeviceGetName
 func (m *NodeDevice) GetName() (v string, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.NodeDevice", "Name").Store(&v)
+	return m.GetNameContext(context.Background())
+}
+
+// GetNameContext is like GetName but gives up waiting for the reply once ctx is done.
+func (m *NodeDevice) GetNameContext(ctx context.Context) (v string, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.NodeDevice", "Name").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
 
 // GetParent See https://libvirt.org/html/libvirt-libvirt-nodedev.html#virNodeDeviceGetParent
 func (m *NodeDevice) GetParent() (v string, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.NodeDevice", "Parent").Store(&v)
+	return m.GetParentContext(context.Background())
+}
+
+// GetParentContext is like GetParent but gives up waiting for the reply once ctx is done.
+func (m *NodeDevice) GetParentContext(ctx context.Context) (v string, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.NodeDevice", "Parent").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
@@ -0,0 +1,38 @@
+package libvirt
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestListNodeDevices(t *testing.T) {
+	c, _ := newTestConn(t)
+	conn := NewConnect(c, "")
+
+	devs, err := conn.ListNodeDevicesObjects(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(devs) != 2 {
+		t.Fatalf("got %d devices, want 2", len(devs))
+	}
+
+	dev, err := conn.NodeDeviceLookupByNameObject("scsi_host1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parent, err := dev.GetParent(); err != nil || parent != "computer" {
+		t.Errorf("got parent %q, %v", parent, err)
+	}
+	if caps, err := dev.ListCaps(); err != nil || len(caps) != 1 || caps[0] != "scsi_host" {
+		t.Errorf("got capabilities %q, %v", caps, err)
+	}
+	def, err := dev.Definition()
+	if err != nil || len(def.Capabilities) != 1 || def.Capabilities[0].Host == nil || *def.Capabilities[0].Host != 1 {
+		t.Errorf("got definition %+v, %v", def, err)
+	}
+
+	if _, err = conn.NodeDeviceLookupByName("pci_0000_00_02_0"); !errors.Is(err, ErrNoNodeDevice) {
+		t.Errorf("got %v, want ErrNoNodeDevice", err)
+	}
+}
@@ -1,18 +1,14 @@
 package libvirt
 
 import (
-	"sync"
+	"context"
 
 	"github.com/godbus/dbus"
 )
 
 type NWFilter struct {
-	conn   *Conn
-	object dbus.BusObject
-	path   dbus.ObjectPath
-
-	sigs  map[<-chan *dbus.Signal]struct{}
-	sigmu sync.Mutex
+	conn *Conn
+	path dbus.ObjectPath
 
 	//Name string
 	//UUID string
@@ -20,41 +16,62 @@ type NWFilter struct {
 
 // NewNWFilter() TODO
 func NewNWFilter(c *Conn, path dbus.ObjectPath) *NWFilter {
-	m := &NWFilter{conn: c}
-	if path != "" {
-		m.object = c.conn.Object("org.libvirt", path)
-	} else {
-		m.object = c.object
+	m := &NWFilter{conn: c, path: path}
+	if path == "" {
+		m.path = c.path
 	}
-	m.path = c.object.Path()
-
-	m.sigmu.Lock()
-	m.sigs = make(map[<-chan *dbus.Signal]struct{})
-	m.sigmu.Unlock()
 
 	return m
 }
 
 // GetXMLDesc See https://libvirt.org/html/libvirt-libvirt-nwfilter.html#virNWFilterGetXMLDesc
 func (m *NWFilter) GetXMLDesc(flags uint32) (xml string, err error) {
-	err = m.object.Call("org.libvirt.NWFilter.GetXMLDesc", 0, flags).Store(&xml)
+	return m.GetXMLDescContext(context.Background(), flags)
+}
+
+// GetXMLDescContext is like GetXMLDesc but gives up waiting for the reply once ctx is done.
+func (m *NWFilter) GetXMLDescContext(ctx context.Context, flags uint32) (xml string, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.NWFilter.GetXMLDesc", flags).Store(&xml)
 	return
 }
 
 // Undefine See https://libvirt.org/html/libvirt-libvirt-nwfilter.html#virNWFilterUndefine
 func (m *NWFilter) Undefine() (err error) {
-	err = m.object.Call("org.libvirt.NWFilter.Undefine", 0).Store()
+	return m.UndefineContext(context.Background())
+}
+
+// UndefineContext is like Undefine but gives up waiting for the reply once ctx is done.
+func (m *NWFilter) UndefineContext(ctx context.Context) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.NWFilter.Undefine").Store()
 	return
 }
 
 // GetName See https://libvirt.org/html/libvirt-libvirt-nwfilter.html#virNWFilterGetName
 func (m *NWFilter) GetName() (v string, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.NWFilter", "Name").Store(&v)
+	return m.GetNameContext(context.Background())
+}
+
+// GetNameContext is like GetName but gives up waiting for the reply once ctx is done.
+func (m *NWFilter) GetNameContext(ctx context.Context) (v string, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.NWFilter", "Name").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
 
 // GetUUID See https://libvirt.org/html/libvirt-libvirt-nwfilter.html#virNWFilterGetUUIDString
 func (m *NWFilter) GetUUID() (v string, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.NWFilter", "UUID").Store(&v)
+	return m.GetUUIDContext(context.Background())
+}
+
+// GetUUIDContext is like GetUUID but gives up waiting for the reply once ctx is done.
+func (m *NWFilter) GetUUIDContext(ctx context.Context) (v string, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.NWFilter", "UUID").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
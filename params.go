@@ -0,0 +1,229 @@
+package libvirt
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/godbus/dbus"
+)
+
+// The structs below stand for the typed parameter (a{sv}) arguments listed
+// in gen.go's paramTypes. Every field is optional: nil fields are left out
+// of the parameters sent and stay nil when libvirt does not report them.
+// The param tag gives the libvirt parameter name; the field type decides
+// the D-Bus variant type, which must match the libvirt one exactly.
+
+// DomainBlockIOParameters are the blkio tunables of a domain. The Device
+// fields are comma separated lists of path and value pairs like
+// "/dev/sda,100,/dev/sdb,200".
+type DomainBlockIOParameters struct {
+	Weight              *uint32 `param:"weight"`
+	DeviceWeight        *string `param:"device_weight"`
+	DeviceReadIopsSec   *string `param:"device_read_iops_sec"`
+	DeviceWriteIopsSec  *string `param:"device_write_iops_sec"`
+	DeviceReadBytesSec  *string `param:"device_read_bytes_sec"`
+	DeviceWriteBytesSec *string `param:"device_write_bytes_sec"`
+}
+
+// DomainBlockIOTune are the I/O limits of a disk.
+type DomainBlockIOTune struct {
+	TotalBytesSec          *uint64 `param:"total_bytes_sec"`
+	ReadBytesSec           *uint64 `param:"read_bytes_sec"`
+	WriteBytesSec          *uint64 `param:"write_bytes_sec"`
+	TotalIopsSec           *uint64 `param:"total_iops_sec"`
+	ReadIopsSec            *uint64 `param:"read_iops_sec"`
+	WriteIopsSec           *uint64 `param:"write_iops_sec"`
+	TotalBytesSecMax       *uint64 `param:"total_bytes_sec_max"`
+	ReadBytesSecMax        *uint64 `param:"read_bytes_sec_max"`
+	WriteBytesSecMax       *uint64 `param:"write_bytes_sec_max"`
+	TotalIopsSecMax        *uint64 `param:"total_iops_sec_max"`
+	ReadIopsSecMax         *uint64 `param:"read_iops_sec_max"`
+	WriteIopsSecMax        *uint64 `param:"write_iops_sec_max"`
+	TotalBytesSecMaxLength *uint64 `param:"total_bytes_sec_max_length"`
+	ReadBytesSecMaxLength  *uint64 `param:"read_bytes_sec_max_length"`
+	WriteBytesSecMaxLength *uint64 `param:"write_bytes_sec_max_length"`
+	TotalIopsSecMaxLength  *uint64 `param:"total_iops_sec_max_length"`
+	ReadIopsSecMaxLength   *uint64 `param:"read_iops_sec_max_length"`
+	WriteIopsSecMaxLength  *uint64 `param:"write_iops_sec_max_length"`
+	SizeIopsSec            *uint64 `param:"size_iops_sec"`
+	GroupName              *string `param:"group_name"`
+}
+
+// DomainMemoryParamUnlimited is the value of a DomainMemoryParameters
+// limit that is not set.
+const DomainMemoryParamUnlimited uint64 = 9007199254740991
+
+// DomainMemoryParameters are the memory limits of a domain in KiB.
+type DomainMemoryParameters struct {
+	HardLimit     *uint64 `param:"hard_limit"`
+	SoftLimit     *uint64 `param:"soft_limit"`
+	MinGuarantee  *uint64 `param:"min_guarantee"`
+	SwapHardLimit *uint64 `param:"swap_hard_limit"`
+}
+
+// DomainNumatuneMemMode mirrors virDomainNumatuneMemMode.
+type DomainNumatuneMemMode int32
+
+const (
+	DomainNumatuneMemStrict DomainNumatuneMemMode = iota
+	DomainNumatuneMemPreferred
+	DomainNumatuneMemInterleave
+)
+
+// DomainNumaParameters are the NUMA memory placement of a domain.
+type DomainNumaParameters struct {
+	Mode    *DomainNumatuneMemMode `param:"numa_mode"`
+	Nodeset *string                `param:"numa_nodeset"`
+}
+
+// DomainSchedulerParameters are the CPU scheduler tunables of a domain.
+// Which of them apply depends on the hypervisor: QEMU uses the shares,
+// period and quota fields, Xen Weight and Cap and ESX the reservation,
+// limit and Shares.
+type DomainSchedulerParameters struct {
+	CPUShares      *uint64 `param:"cpu_shares"`
+	GlobalPeriod   *uint64 `param:"global_period"`
+	GlobalQuota    *int64  `param:"global_quota"`
+	VcpuPeriod     *uint64 `param:"vcpu_period"`
+	VcpuQuota      *int64  `param:"vcpu_quota"`
+	EmulatorPeriod *uint64 `param:"emulator_period"`
+	EmulatorQuota  *int64  `param:"emulator_quota"`
+	IOThreadPeriod *uint64 `param:"iothread_period"`
+	IOThreadQuota  *int64  `param:"iothread_quota"`
+	Weight         *uint32 `param:"weight"`
+	Cap            *uint32 `param:"cap"`
+	Reservation    *int64  `param:"reservation"`
+	Limit          *int64  `param:"limit"`
+	Shares         *int32  `param:"shares"`
+}
+
+// DomainInterfaceParameters are the bandwidth limits of an interface;
+// averages and peaks are in KiB/s, bursts in KiB.
+type DomainInterfaceParameters struct {
+	InboundAverage  *uint32 `param:"inbound.average"`
+	InboundPeak     *uint32 `param:"inbound.peak"`
+	InboundBurst    *uint32 `param:"inbound.burst"`
+	InboundFloor    *uint32 `param:"inbound.floor"`
+	OutboundAverage *uint32 `param:"outbound.average"`
+	OutboundPeak    *uint32 `param:"outbound.peak"`
+	OutboundBurst   *uint32 `param:"outbound.burst"`
+}
+
+// DomainPerfEvents tells which perf events are enabled for a domain.
+type DomainPerfEvents struct {
+	CMT                   *bool `param:"cmt"`
+	MBMT                  *bool `param:"mbmt"`
+	MBML                  *bool `param:"mbml"`
+	CPUCycles             *bool `param:"cpu_cycles"`
+	Instructions          *bool `param:"instructions"`
+	CacheReferences       *bool `param:"cache_references"`
+	CacheMisses           *bool `param:"cache_misses"`
+	BranchInstructions    *bool `param:"branch_instructions"`
+	BranchMisses          *bool `param:"branch_misses"`
+	BusCycles             *bool `param:"bus_cycles"`
+	StalledCyclesFrontend *bool `param:"stalled_cycles_frontend"`
+	StalledCyclesBackend  *bool `param:"stalled_cycles_backend"`
+	RefCPUCycles          *bool `param:"ref_cpu_cycles"`
+	CPUClock              *bool `param:"cpu_clock"`
+	TaskClock             *bool `param:"task_clock"`
+	PageFaults            *bool `param:"page_faults"`
+	ContextSwitches       *bool `param:"context_switches"`
+	CPUMigrations         *bool `param:"cpu_migrations"`
+	PageFaultsMin         *bool `param:"page_faults_min"`
+	PageFaultsMaj         *bool `param:"page_faults_maj"`
+	AlignmentFaults       *bool `param:"alignment_faults"`
+	EmulationFaults       *bool `param:"emulation_faults"`
+}
+
+// DomainBlockCopyParameters tune a block copy job.
+type DomainBlockCopyParameters struct {
+	Bandwidth   *uint64 `param:"bandwidth"`
+	Granularity *uint32 `param:"granularity"`
+	BufSize     *uint64 `param:"buf-size"`
+}
+
+// DomainMigrateParameters tune a migration. libvirt-dbus passes a single
+// value per parameter, so MigrateDisks and Compression can name one disk
+// and one method only.
+type DomainMigrateParameters struct {
+	URI                    *string `param:"migrate_uri"`
+	DestName               *string `param:"dname"`
+	DestXML                *string `param:"destination_xml"`
+	PersistentXML          *string `param:"persistent_xml"`
+	Bandwidth              *uint64 `param:"bandwidth"`
+	BandwidthPostcopy      *uint64 `param:"bandwidth.postcopy"`
+	GraphicsURI            *string `param:"graphics_uri"`
+	ListenAddress          *string `param:"listen_address"`
+	MigrateDisks           *string `param:"migrate_disks"`
+	DisksPort              *int32  `param:"disks_port"`
+	Compression            *string `param:"compression"`
+	CompressionMTLevel     *int32  `param:"compression.mt.level"`
+	CompressionMTThreads   *int32  `param:"compression.mt.threads"`
+	CompressionMTDThreads  *int32  `param:"compression.mt.dthreads"`
+	CompressionXBZRLECache *uint64 `param:"compression.xbzrle.cache"`
+	AutoConvergeInitial    *int32  `param:"auto_converge.initial"`
+	AutoConvergeIncrement  *int32  `param:"auto_converge.increment"`
+	ParallelConnections    *int32  `param:"parallel.connections"`
+	TLSDestination         *string `param:"tls.destination"`
+}
+
+// encodeParams returns the typed parameters set in v, a pointer to one of
+// the structs above.
+func encodeParams(v interface{}) map[string]dbus.Variant {
+	params := make(map[string]dbus.Variant)
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return params
+	}
+	rv = rv.Elem()
+	for i := 0; i < rv.NumField(); i++ {
+		f := rv.Field(i)
+		if f.IsNil() {
+			continue
+		}
+		name := rv.Type().Field(i).Tag.Get("param")
+		params[name] = dbus.MakeVariant(basicValue(f.Elem()))
+	}
+	return params
+}
+
+// basicValue returns v converted to the predeclared type of its kind, so
+// named types like DomainNumatuneMemMode are sent as what they stand for.
+func basicValue(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.Int32:
+		return int32(v.Int())
+	case reflect.Int64:
+		return v.Int()
+	case reflect.Uint32:
+		return uint32(v.Uint())
+	case reflect.Uint64:
+		return v.Uint()
+	case reflect.Float64:
+		return v.Float()
+	case reflect.Bool:
+		return v.Bool()
+	}
+	return v.String()
+}
+
+// decodeParams sets the fields of v, a pointer to one of the structs above,
+// from params. Parameters v has no field for are ignored.
+func decodeParams(params map[string]dbus.Variant, v interface{}) error {
+	rv := reflect.ValueOf(v).Elem()
+	for i := 0; i < rv.NumField(); i++ {
+		name := rv.Type().Field(i).Tag.Get("param")
+		p, ok := params[name]
+		if !ok {
+			continue
+		}
+		f := rv.Field(i)
+		val := reflect.ValueOf(p.Value())
+		if val.Kind() != f.Type().Elem().Kind() {
+			return fmt.Errorf("libvirt: parameter %s is %s, want %s", name, val.Type(), f.Type().Elem())
+		}
+		f.Set(reflect.New(f.Type().Elem()))
+		f.Elem().Set(val.Convert(f.Type().Elem()))
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package libvirt
+
+import (
+	"context"
+	"time"
+
+	"github.com/godbus/dbus"
+)
+
+const (
+	ownerChangedSignal = "org.freedesktop.DBus.NameOwnerChanged"
+	ownerChangedRule   = "type='signal',sender='org.freedesktop.DBus',path='/org/freedesktop/DBus'," +
+		"interface='org.freedesktop.DBus',member='NameOwnerChanged',arg0='org.libvirt'"
+
+	stateBuffer = 16
+)
+
+// ConnState is a change in the state of a Conn, as reported by
+// Conn.StateEvents.
+type ConnState uint8
+
+const (
+	// ConnDisconnected means the bus connection was lost. Without
+	// WithReconnect this is final; otherwise the Conn is redialing.
+	ConnDisconnected ConnState = iota
+	// ConnReconnected means a new bus connection is up and the match rules
+	// of all subscriptions are installed again. Events that happened while
+	// disconnected are lost, so callers should resynchronise.
+	ConnReconnected
+	// ConnServiceLost means libvirt-dbus left the bus.
+	ConnServiceLost
+	// ConnServiceAvailable means libvirt-dbus (re)joined the bus.
+	ConnServiceAvailable
+	// ConnClosed means Close was called.
+	ConnClosed
+)
+
+var connStateNames = []string{"Disconnected", "Reconnected", "ServiceLost", "ServiceAvailable", "Closed"}
+
+func (s ConnState) String() string {
+	return enumString(int32(s), connStateNames)
+}
+
+// StateEvents returns a channel reporting changes of the connection state.
+// It is closed once ctx is done or the Conn has stopped for good, after
+// delivering the final ConnClosed or ConnDisconnected. States are dropped
+// when the channel is not drained.
+func (c *Conn) StateEvents(ctx context.Context) <-chan ConnState {
+	ch := make(chan ConnState, stateBuffer)
+
+	c.statemu.Lock()
+	defer c.statemu.Unlock()
+	if c.listeners == nil {
+		close(ch)
+		return ch
+	}
+	c.listeners[ch] = struct{}{}
+	done := c.stateDone
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+			return
+		}
+		c.statemu.Lock()
+		defer c.statemu.Unlock()
+		if _, ok := c.listeners[ch]; ok {
+			delete(c.listeners, ch)
+			close(ch)
+		}
+	}()
+	return ch
+}
+
+func (c *Conn) emit(state ConnState) {
+	c.statemu.Lock()
+	defer c.statemu.Unlock()
+	for ch := range c.listeners {
+		select {
+		case ch <- state:
+		default:
+		}
+	}
+}
+
+// closeListeners delivers the final state and closes all state channels.
+func (c *Conn) closeListeners(final ConnState) {
+	c.statemu.Lock()
+	defer c.statemu.Unlock()
+	if c.listeners == nil {
+		return
+	}
+	for ch := range c.listeners {
+		select {
+		case ch <- final:
+		default:
+		}
+		close(ch)
+	}
+	c.listeners = nil
+	close(c.stateDone)
+}
+
+// ownerChanged reports libvirt-dbus leaving or joining the bus.
+func (c *Conn) ownerChanged(v *dbus.Signal) {
+	var name, oldOwner, newOwner string
+	if err := dbus.Store(v.Body, &name, &oldOwner, &newOwner); err != nil || name != "org.libvirt" {
+		return
+	}
+	if newOwner == "" {
+		c.emit(ConnServiceLost)
+	} else {
+		c.emit(ConnServiceAvailable)
+	}
+}
+
+// lost handles the loss of the bus connection. Unless the Conn reconnects
+// it stops all subscriptions and returns nil; otherwise it redials with
+// backoff until it succeeds or the Conn is closed, and returns the new
+// connection and its signal channel.
+func (c *Conn) lost() (*dbus.Conn, chan *dbus.Signal) {
+	select {
+	case <-c.quit:
+		return nil, nil
+	default:
+	}
+	if !c.opts.reconnect {
+		c.stopAll()
+		c.closeListeners(ConnDisconnected)
+		return nil, nil
+	}
+	c.emit(ConnDisconnected)
+
+	delay := c.opts.minBackoff
+	for {
+		select {
+		case <-c.quit:
+			return nil, nil
+		case <-time.After(delay):
+		}
+		if bus, ch, err := c.reconnect(); err == nil {
+			c.emit(ConnReconnected)
+			return bus, ch
+		}
+		if delay *= 2; delay > c.opts.maxBackoff {
+			delay = c.opts.maxBackoff
+		}
+	}
+}
+
+// reconnect dials a new bus connection, makes it current and installs all
+// match rules on it again.
+func (c *Conn) reconnect() (*dbus.Conn, chan *dbus.Signal, error) {
+	bus, err := c.opts.open()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c.matchmu.Lock()
+	defer c.matchmu.Unlock()
+
+	ch := c.attach(bus)
+	for rule := range c.matches {
+		if err = bus.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, rule).Store(); err != nil {
+			bus.Close()
+			return nil, nil, err
+		}
+	}
+
+	select {
+	case <-c.quit:
+		// Close ran meanwhile and may have missed the new connection
+		bus.Close()
+		return nil, nil, errClosed
+	default:
+	}
+	return bus, ch, nil
+}
@@ -0,0 +1,173 @@
+package libvirt
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// nextState returns the next state on states, failing t if none arrives.
+func nextState(t *testing.T, states <-chan ConnState) ConnState {
+	t.Helper()
+	select {
+	case s, ok := <-states:
+		if !ok {
+			t.Fatal("state channel closed")
+		}
+		return s
+	case <-time.After(5 * time.Second):
+		t.Fatal("no state change")
+		return 0
+	}
+}
+
+func TestReconnect(t *testing.T) {
+	f := newFake(t)
+	c, err := NewConnWithOptions(DriverTest, WithBusAddress(f.listen(t)), WithReconnect(10*time.Millisecond, 40*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	conn := NewConnect(c, "")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	states := c.StateEvents(ctx)
+	events, err := conn.DomainEvents(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rule := "type='signal',interface='org.libvirt.Connect',member='DomainEvent',path='/org/libvirt/Test'"
+
+	f.setDown(true)
+	f.drop()
+	if s := nextState(t, states); s != ConnDisconnected {
+		t.Fatalf("got %v, want Disconnected", s)
+	}
+	// Redialing backs off from 10ms to 40ms: a few attempts fail meanwhile.
+	time.Sleep(150 * time.Millisecond)
+	f.busmu.Lock()
+	refused := f.refused
+	f.busmu.Unlock()
+	if refused < 2 || refused > 10 {
+		t.Errorf("redialed %d times in 150ms", refused)
+	}
+	select {
+	case s := <-states:
+		t.Fatalf("got %v while the bus is down", s)
+	default:
+	}
+
+	f.setDown(false)
+	if s := nextState(t, states); s != ConnReconnected {
+		t.Fatalf("got %v, want Reconnected", s)
+	}
+	rules := f.matchRules()
+	if rules[rule] != 1 || rules[ownerChangedRule] != 1 {
+		t.Errorf("got rules %v after reconnecting", rules)
+	}
+
+	domain, err := conn.DomainLookupByUUIDObject(fakeDomainUUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = domain.Suspend(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case ev := <-events:
+		if ev.Event != DomainEventSuspended {
+			t.Errorf("got %v", ev)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription got no event after reconnecting")
+	}
+
+	if err = c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if s := nextState(t, states); s != ConnClosed {
+		t.Errorf("got %v, want Closed", s)
+	}
+}
+
+func TestServiceOwnerChanged(t *testing.T) {
+	f := newFake(t)
+	c, err := NewConnWithOptions(DriverTest, WithBusAddress(f.listen(t)), WithReconnect(0, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	states := c.StateEvents(ctx)
+
+	f.setOwner(":1.1", "")
+	if s := nextState(t, states); s != ConnServiceLost {
+		t.Errorf("got %v, want ServiceLost", s)
+	}
+	f.setOwner("", ":1.7")
+	if s := nextState(t, states); s != ConnServiceAvailable {
+		t.Errorf("got %v, want ServiceAvailable", s)
+	}
+}
+
+func TestDisconnectWithoutReconnect(t *testing.T) {
+	f := newFake(t)
+	c, err := NewConnWithOptions(DriverTest, WithBusAddress(f.listen(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	states := c.StateEvents(context.Background())
+	sub, err := NewConnect(c, "").SubscribeDomainLifecycle(func(DomainLifecycleEvent) {})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f.drop()
+	if s := nextState(t, states); s != ConnDisconnected {
+		t.Fatalf("got %v, want Disconnected", s)
+	}
+	if _, ok := <-states; ok {
+		t.Error("state channel still open after the final state")
+	}
+	select {
+	case <-sub.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription still running after the connection was lost")
+	}
+}
+
+func TestCloseWithBlockedSubscription(t *testing.T) {
+	f := newFake(t)
+	c, err := NewConnWithOptions(DriverTest, WithBusAddress(f.listen(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	release := make(chan struct{})
+	defer close(release)
+	_, err = NewConnect(c, "").SubscribeDomainLifecycle(func(DomainLifecycleEvent) { <-release },
+		WithQueueDepth(1), WithOverflowPolicy(OverflowBlock))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Stall the dispatcher and fill the channel godbus delivers to, so
+	// that godbus has signals in flight when Close runs.
+	for i := 0; i < signalBuffer+64; i++ {
+		f.emit("DomainEvent", fakePath("domain", fakeDomainUUID), int32(DomainEventSuspended), int32(0))
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	closed := make(chan error, 1)
+	go func() { closed <- c.Close() }()
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close blocked")
+	}
+}
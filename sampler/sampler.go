@@ -0,0 +1,253 @@
+// Package sampler turns the cumulative counters libvirt reports for
+// domains and the host into rates: CPU usage in percent, disk operations
+// and bytes per second and network packets and bytes per second.
+//
+// A Sampler keeps the previous sample and computes the rates over the time
+// since then, so the first sample only sets the baseline. Counters that go
+// backwards, as they do when a domain is restarted, are taken to have been
+// reset to zero during the interval.
+package sampler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	libvirt "sdstack.com/sdstack/go-libvirt"
+)
+
+// Source is the part of *libvirt.Connect a Sampler reads.
+type Source interface {
+	AllDomainStatsContext(ctx context.Context, stats libvirt.DomainStatsTypes, flags libvirt.ConnectGetAllDomainStatsFlags) ([]*libvirt.DomainStats, error)
+	NodeGetCPUStatsContext(ctx context.Context, cpuNum int32, flags uint32) (map[string]uint64, error)
+	NodeGetMemoryStatsContext(ctx context.Context, cellNum int32, flags uint32) (map[string]uint64, error)
+}
+
+// domainGroups are the stat groups a Sampler asks for.
+const domainGroups = libvirt.DomainStatsState | libvirt.DomainStatsCPUTotal | libvirt.DomainStatsBalloon |
+	libvirt.DomainStatsVCPU | libvirt.DomainStatsInterface | libvirt.DomainStatsBlock
+
+// Snapshot holds the rates over the Interval ending at Time.
+type Snapshot struct {
+	Time     time.Time
+	Interval time.Duration
+	Host     *HostRates
+	// Domains has an entry for every domain that was also in the previous
+	// sample, inactive ones included.
+	Domains []DomainRates
+}
+
+// HostRates are the CPU usage and memory of the host. The memory figures
+// are in KiB.
+type HostRates struct {
+	CPUPercent    float64
+	UserPercent   float64
+	KernelPercent float64
+	IOWaitPercent float64
+	MemoryTotal   uint64
+	MemoryFree    uint64
+	MemoryBuffers uint64
+	MemoryCached  uint64
+}
+
+// DomainRates are the rates of one domain.
+type DomainRates struct {
+	Domain string
+	// CPUPercent is the CPU time used relative to the interval, so it goes
+	// up to 100 times the number of vCPUs.
+	CPUPercent float64
+	Block      []BlockRates
+	Net        []NetRates
+	// Stats is the sample the rates end at.
+	Stats *libvirt.DomainStats
+}
+
+// BlockRates are the rates of a disk.
+type BlockRates struct {
+	Name             string
+	ReadIOPS         float64
+	WriteIOPS        float64
+	ReadBytesPerSec  float64
+	WriteBytesPerSec float64
+}
+
+// NetRates are the rates of an interface.
+type NetRates struct {
+	Name            string
+	RxPacketsPerSec float64
+	TxPacketsPerSec float64
+	RxBytesPerSec   float64
+	TxBytesPerSec   float64
+	RxDropsPerSec   float64
+	TxDropsPerSec   float64
+}
+
+// Sampler computes rates from successive samples of a Source. Its methods
+// may be called from several goroutines; samples are then taken one after
+// the other.
+type Sampler struct {
+	src Source
+	now func() time.Time
+
+	// mu is held while a sample is read and compared with the previous
+	// one, so that the counters and their time always go together.
+	mu       sync.Mutex
+	last     time.Time
+	domains  map[string]*libvirt.DomainStats
+	hostCPU  map[string]uint64
+	hasPrior bool
+}
+
+// New returns a Sampler reading src.
+func New(src Source) *Sampler {
+	return &Sampler{src: src, now: time.Now}
+}
+
+// Run calls Sample every interval until ctx is done and passes each
+// snapshot, or the error getting it, to fn. It returns ctx.Err().
+func (s *Sampler) Run(ctx context.Context, interval time.Duration, fn func(*Snapshot, error)) error {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		snap, err := s.Sample(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		fn(snap, err)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Sample reads the counters and returns the rates since the previous
+// call. The first call returns a Snapshot without Host and Domains. The
+// Snapshot is timed by when the domain statistics arrived, since they
+// change faster than the host's.
+func (s *Sampler) Sample(ctx context.Context) (*Snapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	domains, err := s.src.AllDomainStatsContext(ctx, domainGroups, 0)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	cpu, err := s.src.NodeGetCPUStatsContext(ctx, libvirt.NodeCPUStatsAllCPUs, 0)
+	if err != nil {
+		return nil, err
+	}
+	mem, err := s.src.NodeGetMemoryStatsContext(ctx, libvirt.NodeMemoryStatsAllCells, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	snap := &Snapshot{Time: now}
+	if s.hasPrior {
+		snap.Interval = now.Sub(s.last)
+		secs := snap.Interval.Seconds()
+		snap.Host = hostRates(s.hostCPU, cpu, mem)
+		for _, cur := range domains {
+			if prev, ok := s.domains[cur.Domain]; ok && secs > 0 {
+				snap.Domains = append(snap.Domains, domainRates(prev, cur, secs))
+			}
+		}
+	}
+
+	s.last, s.hostCPU, s.hasPrior = now, cpu, true
+	s.domains = make(map[string]*libvirt.DomainStats, len(domains))
+	for _, d := range domains {
+		s.domains[d.Domain] = d
+	}
+	return snap, nil
+}
+
+// delta returns how much a counter grew from prev to cur, assuming it was
+// reset to zero if it went backwards.
+func delta(prev, cur uint64) uint64 {
+	if cur < prev {
+		return cur
+	}
+	return cur - prev
+}
+
+func perSec(prev, cur uint64, secs float64) float64 {
+	return float64(delta(prev, cur)) / secs
+}
+
+func hostRates(prev, cur, mem map[string]uint64) *HostRates {
+	h := &HostRates{
+		MemoryTotal:   mem["total"],
+		MemoryFree:    mem["free"],
+		MemoryBuffers: mem["buffers"],
+		MemoryCached:  mem["cached"],
+	}
+	// Some drivers report the utilization instead of the times.
+	if u, ok := cur["utilization"]; ok {
+		h.CPUPercent = float64(u)
+		return h
+	}
+	var total uint64
+	d := make(map[string]uint64, len(cur))
+	for k, v := range cur {
+		d[k] = delta(prev[k], v)
+		total += d[k]
+	}
+	if total == 0 {
+		return h
+	}
+	pct := func(k string) float64 { return 100 * float64(d[k]) / float64(total) }
+	h.UserPercent = pct("user")
+	h.KernelPercent = pct("kernel")
+	h.IOWaitPercent = pct("iowait")
+	h.CPUPercent = 100 - pct("idle")
+	return h
+}
+
+// domainRates compares the samples of one domain. Counters of a domain
+// that was not running in prev are missing there and count from zero.
+func domainRates(prev, cur *libvirt.DomainStats, secs float64) DomainRates {
+	r := DomainRates{Domain: cur.Domain, Stats: cur}
+	if cur.CPU != nil {
+		var prevTime uint64
+		if prev.CPU != nil {
+			prevTime = prev.CPU.Time
+		}
+		r.CPUPercent = 100 * float64(delta(prevTime, cur.CPU.Time)) / (secs * 1e9)
+	}
+
+	// Devices are matched by name since hotplug renumbers them.
+	prevBlock := make(map[string]libvirt.DomainBlockStats, len(prev.Block))
+	for _, b := range prev.Block {
+		prevBlock[b.Name] = b
+	}
+	for _, b := range cur.Block {
+		p := prevBlock[b.Name]
+		r.Block = append(r.Block, BlockRates{
+			Name:             b.Name,
+			ReadIOPS:         perSec(p.RdReqs, b.RdReqs, secs),
+			WriteIOPS:        perSec(p.WrReqs, b.WrReqs, secs),
+			ReadBytesPerSec:  perSec(p.RdBytes, b.RdBytes, secs),
+			WriteBytesPerSec: perSec(p.WrBytes, b.WrBytes, secs),
+		})
+	}
+	prevNet := make(map[string]libvirt.DomainNetStats, len(prev.Net))
+	for _, n := range prev.Net {
+		prevNet[n.Name] = n
+	}
+	for _, n := range cur.Net {
+		p := prevNet[n.Name]
+		r.Net = append(r.Net, NetRates{
+			Name:            n.Name,
+			RxPacketsPerSec: perSec(p.RxPkts, n.RxPkts, secs),
+			TxPacketsPerSec: perSec(p.TxPkts, n.TxPkts, secs),
+			RxBytesPerSec:   perSec(p.RxBytes, n.RxBytes, secs),
+			TxBytesPerSec:   perSec(p.TxBytes, n.TxBytes, secs),
+			RxDropsPerSec:   perSec(p.RxDrop, n.RxDrop, secs),
+			TxDropsPerSec:   perSec(p.TxDrop, n.TxDrop, secs),
+		})
+	}
+	return r
+}
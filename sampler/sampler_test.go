@@ -0,0 +1,176 @@
+package sampler
+
+import (
+	"context"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	libvirt "sdstack.com/sdstack/go-libvirt"
+)
+
+type fakeSource struct {
+	domains []*libvirt.DomainStats
+	cpu     map[string]uint64
+	// slow, if set, is called in each call for the domain and host
+	// statistics.
+	slow func()
+}
+
+func (f *fakeSource) AllDomainStatsContext(ctx context.Context, stats libvirt.DomainStatsTypes, flags libvirt.ConnectGetAllDomainStatsFlags) ([]*libvirt.DomainStats, error) {
+	if f.slow != nil {
+		f.slow()
+	}
+	return f.domains, nil
+}
+
+func (f *fakeSource) NodeGetCPUStatsContext(ctx context.Context, cpuNum int32, flags uint32) (map[string]uint64, error) {
+	if f.slow != nil {
+		f.slow()
+	}
+	return f.cpu, nil
+}
+
+func (f *fakeSource) NodeGetMemoryStatsContext(ctx context.Context, cellNum int32, flags uint32) (map[string]uint64, error) {
+	return map[string]uint64{"total": 16384, "free": 4096}, nil
+}
+
+func running(name string, cpuTime, rdReqs, rxBytes uint64) *libvirt.DomainStats {
+	return &libvirt.DomainStats{
+		Domain: name,
+		CPU:    &libvirt.DomainCPUStats{Time: cpuTime},
+		Block:  []libvirt.DomainBlockStats{{Name: "vda", RdReqs: rdReqs}},
+		Net:    []libvirt.DomainNetStats{{Name: "vnet0", RxBytes: rxBytes}},
+	}
+}
+
+func TestSample(t *testing.T) {
+	src := &fakeSource{
+		domains: []*libvirt.DomainStats{
+			running("web", 10e9, 1000, 1e6),
+			running("db", 50e9, 5000, 2e6),
+			{Domain: "stopped"},
+		},
+		cpu: map[string]uint64{"user": 100, "kernel": 100, "idle": 800, "iowait": 0},
+	}
+	now := time.Unix(1000, 0)
+	s := New(src)
+	s.now = func() time.Time { return now }
+
+	snap, err := s.Sample(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snap.Host != nil || snap.Domains != nil {
+		t.Fatalf("first sample has rates: %+v", snap)
+	}
+
+	now = now.Add(10 * time.Second)
+	src.domains = []*libvirt.DomainStats{
+		running("web", 15e9, 1500, 3e6),
+		// Restarted: its counters start over.
+		running("db", 2e9, 100, 1e5),
+		running("stopped", 1e9, 50, 0),
+		running("new", 1e9, 10, 10),
+	}
+	src.cpu = map[string]uint64{"user": 400, "kernel": 200, "idle": 1300, "iowait": 100}
+	snap, err = s.Sample(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snap.Interval != 10*time.Second {
+		t.Errorf("got interval %v", snap.Interval)
+	}
+	if h := snap.Host; h == nil || !near(h.CPUPercent, 50) || !near(h.UserPercent, 30) || h.MemoryFree != 4096 {
+		t.Errorf("got host %+v", h)
+	}
+
+	want := []struct {
+		name     string
+		cpu      float64
+		readIOPS float64
+		rxBps    float64
+	}{
+		{"web", 50, 50, 2e5},
+		{"db", 20, 10, 1e4},
+		{"stopped", 10, 5, 0},
+	}
+	if len(snap.Domains) != len(want) {
+		t.Fatalf("got %d domains, want %d", len(snap.Domains), len(want))
+	}
+	for i, w := range want {
+		d := snap.Domains[i]
+		if d.Domain != w.name || !near(d.CPUPercent, w.cpu) ||
+			!near(d.Block[0].ReadIOPS, w.readIOPS) || !near(d.Net[0].RxBytesPerSec, w.rxBps) {
+			t.Errorf("got %s: cpu %v, read IOPS %v, rx %v; want %+v",
+				d.Domain, d.CPUPercent, d.Block[0].ReadIOPS, d.Net[0].RxBytesPerSec, w)
+		}
+	}
+}
+
+func TestSampleConcurrently(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		now      = time.Unix(1000, 0)
+		inFlight int
+		overlap  bool
+	)
+	src := &fakeSource{
+		domains: []*libvirt.DomainStats{running("web", 10e9, 1000, 1e6)},
+		cpu:     map[string]uint64{"user": 100, "idle": 900},
+	}
+	// Every call takes a second, and samples must not overlap.
+	src.slow = func() {
+		mu.Lock()
+		inFlight++
+		overlap = overlap || inFlight > 1
+		now = now.Add(time.Second)
+		mu.Unlock()
+		time.Sleep(time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+	}
+	s := New(src)
+	s.now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+
+	var wg sync.WaitGroup
+	snaps := make(chan *Snapshot, 8)
+	for i := 0; i < cap(snaps); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			snap, err := s.Sample(context.Background())
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			snaps <- snap
+		}()
+	}
+	wg.Wait()
+	close(snaps)
+
+	if overlap {
+		t.Error("samples were read concurrently")
+	}
+	for snap := range snaps {
+		// The time is taken between the domain and the host statistics,
+		// each of which advances the clock by a second.
+		if snap.Time.Unix()%2 != 1 {
+			t.Errorf("sample timed at %v", snap.Time.Unix())
+		}
+		if snap.Host != nil && snap.Interval != 2*time.Second {
+			t.Errorf("got interval %v, want 2s", snap.Interval)
+		}
+	}
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
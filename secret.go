@@ -1,18 +1,14 @@
 package libvirt
 
 import (
-	"sync"
+	"context"
 
 	"github.com/godbus/dbus"
 )
 
 type Secret struct {
-	conn   *Conn
-	object dbus.BusObject
-	path   dbus.ObjectPath
-
-	sigs  map[<-chan *dbus.Signal]struct{}
-	sigmu sync.Mutex
+	conn *Conn
+	path dbus.ObjectPath
 
 	//UUID string
 	//UsageID string
@@ -21,59 +17,99 @@ type Secret struct {
 
 // NewSecret() TODO
 func NewSecret(c *Conn, path dbus.ObjectPath) *Secret {
-	m := &Secret{conn: c}
-	if path != "" {
-		m.object = c.conn.Object("org.libvirt", path)
-	} else {
-		m.object = c.object
+	m := &Secret{conn: c, path: path}
+	if path == "" {
+		m.path = c.path
 	}
-	m.path = c.object.Path()
-
-	m.sigmu.Lock()
-	m.sigs = make(map[<-chan *dbus.Signal]struct{})
-	m.sigmu.Unlock()
 
 	return m
 }
 
 // GetValue See https://libvirt.org/html/libvirt-libvirt-secret.html#virSecretGetValue
 func (m *Secret) GetValue(flags uint32) (value []byte, err error) {
-	err = m.object.Call("org.libvirt.Secret.GetValue", 0, flags).Store(&value)
+	return m.GetValueContext(context.Background(), flags)
+}
+
+// GetValueContext is like GetValue but gives up waiting for the reply once ctx is done.
+func (m *Secret) GetValueContext(ctx context.Context, flags uint32) (value []byte, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Secret.GetValue", flags).Store(&value)
 	return
 }
 
 // GetXMLDesc See https://libvirt.org/html/libvirt-libvirt-secret.html#virSecretGetXMLDesc
 func (m *Secret) GetXMLDesc(flags uint32) (xml string, err error) {
-	err = m.object.Call("org.libvirt.Secret.GetXMLDesc", 0, flags).Store(&xml)
+	return m.GetXMLDescContext(context.Background(), flags)
+}
+
+// GetXMLDescContext is like GetXMLDesc but gives up waiting for the reply once ctx is done.
+func (m *Secret) GetXMLDescContext(ctx context.Context, flags uint32) (xml string, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Secret.GetXMLDesc", flags).Store(&xml)
 	return
 }
 
 // SetValue See https://libvirt.org/html/libvirt-libvirt-secret.html#virSecretSetValue
 func (m *Secret) SetValue(value []byte, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.Secret.SetValue", 0, value, flags).Store()
+	return m.SetValueContext(context.Background(), value, flags)
+}
+
+// SetValueContext is like SetValue but gives up waiting for the reply once ctx is done.
+func (m *Secret) SetValueContext(ctx context.Context, value []byte, flags uint32) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Secret.SetValue", value, flags).Store()
 	return
 }
 
 // Undefine See https://libvirt.org/html/libvirt-libvirt-secret.html#virSecretUndefine
 func (m *Secret) Undefine() (err error) {
-	err = m.object.Call("org.libvirt.Secret.Undefine", 0).Store()
+	return m.UndefineContext(context.Background())
+}
+
+// UndefineContext is like Undefine but gives up waiting for the reply once ctx is done.
+func (m *Secret) UndefineContext(ctx context.Context) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.Secret.Undefine").Store()
 	return
 }
 
 // GetUUID See https://libvirt.org/html/libvirt-libvirt-secret.html#virSecretGetUUIDString
 func (m *Secret) GetUUID() (v string, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.Secret", "UUID").Store(&v)
+	return m.GetUUIDContext(context.Background())
+}
+
+// GetUUIDContext is like GetUUID but gives up waiting for the reply once ctx is done.
+func (m *Secret) GetUUIDContext(ctx context.Context) (v string, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.Secret", "UUID").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
 
 // GetUsageID See https://libvirt.org/html/libvirt-libvirt-secret.html#virSecretGetUsageID
 func (m *Secret) GetUsageID() (v string, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.Secret", "UsageID").Store(&v)
+	return m.GetUsageIDContext(context.Background())
+}
+
+// GetUsageIDContext is like GetUsageID but gives up waiting for the reply once ctx is done.
+func (m *Secret) GetUsageIDContext(ctx context.Context) (v string, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.Secret", "UsageID").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
 
 // GetUsageType See https://libvirt.org/html/libvirt-libvirt-secret.html#virSecretGetUsageType
 func (m *Secret) GetUsageType() (v int32, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.Secret", "UsageType").Store(&v)
+	return m.GetUsageTypeContext(context.Background())
+}
+
+// GetUsageTypeContext is like GetUsageType but gives up waiting for the reply once ctx is done.
+func (m *Secret) GetUsageTypeContext(ctx context.Context) (v int32, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.Secret", "UsageType").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
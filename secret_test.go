@@ -0,0 +1,46 @@
+package libvirt
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestSecretValue(t *testing.T) {
+	c, _ := newTestConn(t)
+	conn := NewConnect(c, "")
+
+	secret, err := conn.SecretDefineXMLObject(
+		"<secret ephemeral='no' private='no'><usage type='volume'><volume>/images/disk.img</volume></usage></secret>", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = secret.GetValue(0); !errors.Is(err, ErrNoSecret) {
+		t.Errorf("got %v before a value was set, want ErrNoSecret", err)
+	}
+	if err = secret.SetValue([]byte("hunter2"), 0); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := secret.GetValue(0); err != nil || !bytes.Equal(v, []byte("hunter2")) {
+		t.Errorf("got value %q, %v", v, err)
+	}
+
+	found, err := conn.SecretLookupByUsageObject(SecretUsageTypeVolume, "/images/disk.img")
+	if err != nil || found.path != secret.path {
+		t.Fatalf("got %v, %v", found, err)
+	}
+	if typ, err := found.GetUsageType(); err != nil || SecretUsageType(typ) != SecretUsageTypeVolume {
+		t.Errorf("got usage type %d, %v", typ, err)
+	}
+	def, err := found.Definition()
+	if err != nil || def.Usage == nil || def.Usage.Volume != "/images/disk.img" {
+		t.Errorf("got definition %+v, %v", def, err)
+	}
+
+	if err = secret.Undefine(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = conn.SecretLookupByUUID(def.UUID); !errors.Is(err, ErrNoSecret) {
+		t.Errorf("got %v, want ErrNoSecret", err)
+	}
+}
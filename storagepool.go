@@ -1,18 +1,14 @@
 package libvirt
 
 import (
-	"sync"
+	"context"
 
 	"github.com/godbus/dbus"
 )
 
 type StoragePool struct {
-	conn   *Conn
-	object dbus.BusObject
-	path   dbus.ObjectPath
-
-	sigs  map[<-chan *dbus.Signal]struct{}
-	sigmu sync.Mutex
+	conn *Conn
+	path dbus.ObjectPath
 
 	//Active bool
 	//Autostart bool
@@ -21,157 +17,341 @@ type StoragePool struct {
 	//UUID string
 }
 
+// StoragePoolInfo holds the info returned by StoragePool.GetInfo.
+type StoragePoolInfo struct {
+	State      int32
+	Capacity   uint64
+	Allocation uint64
+	Available  uint64
+}
+
 // NewStoragePool() TODO
 func NewStoragePool(c *Conn, path dbus.ObjectPath) *StoragePool {
-	m := &StoragePool{conn: c}
-	if path != "" {
-		m.object = c.conn.Object("org.libvirt", path)
-	} else {
-		m.object = c.object
+	m := &StoragePool{conn: c, path: path}
+	if path == "" {
+		m.path = c.path
 	}
-	m.path = c.object.Path()
-
-	m.sigmu.Lock()
-	m.sigs = make(map[<-chan *dbus.Signal]struct{})
-	m.sigmu.Unlock()
 
 	return m
 }
 
 // SubscribeRefresh See https://libvirt.org/html/libvirt-libvirt-storage.html#virConnectStoragePoolEventGenericCallback
-func (m *StoragePool) SubscribeRefresh(callback func()) <-chan *dbus.Signal {
+//
+// The callback sees the signals one at a time but not necessarily in the
+// order they were sent; see Subscription and OverflowPolicy.
+func (m *StoragePool) SubscribeRefresh(callback func(), opts ...SubscribeOption) (*Subscription, error) {
 	if callback == nil {
-		return nil
+		return nil, errNilCallback
 	}
-	m.sigmu.Lock()
-	ch := make(chan *dbus.Signal)
-	m.sigs[ch] = struct{}{}
-	m.conn.conn.Signal(ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, "type='signal',interface='org.libvirt.StoragePool',member='Refresh'")
-	go func() {
-		for v := range ch {
-			if v.Path != m.path || v.Name != "org.libvirt.StoragePool.Refresh" || 0 != len(v.Body) {
-				continue
-			}
-			callback()
+	return m.conn.subscribe(signalSpec{"org.libvirt.StoragePool", "Refresh", "", m.path}, func(body []interface{}) error {
+		if err := dbus.Store(body); err != nil {
+			return err
 		}
-	}()
-	return ch
+		callback()
+		return nil
+	}, opts)
 }
 
 // UnSubscribeRefresh See https://libvirt.org/html/libvirt-libvirt-storage.html#virConnectStoragePoolEventGenericCallback
-func (m *StoragePool) UnSubscribeRefresh(ch <-chan *dbus.Signal) {
-	m.sigmu.Lock()
-	delete(m.sigs, ch)
-	m.sigmu.Unlock()
-	m.conn.conn.BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, "type='signal',interface='org.libvirt.StoragePool',member='Refresh'")
+func (m *StoragePool) UnSubscribeRefresh(sub *Subscription) error {
+	return sub.Close()
+}
+
+// StoragePoolRefreshEvent carries the arguments of the Refresh signal.
+type StoragePoolRefreshEvent struct {
+}
+
+// RefreshEvents is like SubscribeRefresh but delivers the signals on a channel that is closed once ctx is done or the connection is lost.
+func (m *StoragePool) RefreshEvents(ctx context.Context, opts ...SubscribeOption) (<-chan StoragePoolRefreshEvent, error) {
+	ch := make(chan StoragePoolRefreshEvent)
+	err := stream(ctx, func(quit <-chan struct{}) (*Subscription, error) {
+		return m.SubscribeRefresh(func() {
+			select {
+			case ch <- StoragePoolRefreshEvent{}:
+			case <-quit:
+			}
+		}, opts...)
+	}, func() { close(ch) })
+	if err != nil {
+		return nil, err
+	}
+	return ch, nil
 }
 
 // Build See https://libvirt.org/html/libvirt-libvirt-storage.html#virStoragePoolBuild
-func (m *StoragePool) Build(flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.StoragePool.Build", 0, flags).Store()
+func (m *StoragePool) Build(flags StoragePoolBuildFlags) (err error) {
+	return m.BuildContext(context.Background(), flags)
+}
+
+// BuildContext is like Build but gives up waiting for the reply once ctx is done.
+func (m *StoragePool) BuildContext(ctx context.Context, flags StoragePoolBuildFlags) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.StoragePool.Build", flags).Store()
 	return
 }
 
 // Create See https://libvirt.org/html/libvirt-libvirt-storage.html#virStoragePoolCreate
-func (m *StoragePool) Create(flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.StoragePool.Create", 0, flags).Store()
+func (m *StoragePool) Create(flags StoragePoolCreateFlags) (err error) {
+	return m.CreateContext(context.Background(), flags)
+}
+
+// CreateContext is like Create but gives up waiting for the reply once ctx is done.
+func (m *StoragePool) CreateContext(ctx context.Context, flags StoragePoolCreateFlags) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.StoragePool.Create", flags).Store()
 	return
 }
 
 // Delete See https://libvirt.org/html/libvirt-libvirt-storage.html#virStoragePoolDelete
-func (m *StoragePool) Delete(flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.StoragePool.Delete", 0, flags).Store()
+func (m *StoragePool) Delete(flags StoragePoolDeleteFlags) (err error) {
+	return m.DeleteContext(context.Background(), flags)
+}
+
+// DeleteContext is like Delete but gives up waiting for the reply once ctx is done.
+func (m *StoragePool) DeleteContext(ctx context.Context, flags StoragePoolDeleteFlags) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.StoragePool.Delete", flags).Store()
 	return
 }
 
 // Destroy See https://libvirt.org/html/libvirt-libvirt-storage.html#virStoragePoolDestroy
 func (m *StoragePool) Destroy() (err error) {
-	err = m.object.Call("org.libvirt.StoragePool.Destroy", 0).Store()
+	return m.DestroyContext(context.Background())
+}
+
+// DestroyContext is like Destroy but gives up waiting for the reply once ctx is done.
+func (m *StoragePool) DestroyContext(ctx context.Context) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.StoragePool.Destroy").Store()
 	return
 }
 
 // GetInfo See https://libvirt.org/html/libvirt-libvirt-storage.html#virStoragePoolGetInfo
-func (m *StoragePool) GetInfo() (info interface{}, err error) {
-	err = m.object.Call("org.libvirt.StoragePool.GetInfo", 0).Store(&info)
+func (m *StoragePool) GetInfo() (info StoragePoolInfo, err error) {
+	return m.GetInfoContext(context.Background())
+}
+
+// GetInfoContext is like GetInfo but gives up waiting for the reply once ctx is done.
+func (m *StoragePool) GetInfoContext(ctx context.Context) (info StoragePoolInfo, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.StoragePool.GetInfo").Store(&info)
 	return
 }
 
 // GetXMLDesc See https://libvirt.org/html/libvirt-libvirt-storage.html#virStoragePoolGetXMLDesc
-func (m *StoragePool) GetXMLDesc(flags uint32) (xml string, err error) {
-	err = m.object.Call("org.libvirt.StoragePool.GetXMLDesc", 0, flags).Store(&xml)
+func (m *StoragePool) GetXMLDesc(flags StorageXMLFlags) (xml string, err error) {
+	return m.GetXMLDescContext(context.Background(), flags)
+}
+
+// GetXMLDescContext is like GetXMLDesc but gives up waiting for the reply once ctx is done.
+func (m *StoragePool) GetXMLDescContext(ctx context.Context, flags StorageXMLFlags) (xml string, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.StoragePool.GetXMLDesc", flags).Store(&xml)
 	return
 }
 
 // ListStorageVolumes See https://libvirt.org/html/libvirt-libvirt-storage.html#virStoragePoolListAllVolumes
 func (m *StoragePool) ListStorageVolumes(flags uint32) (storageVols []dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.StoragePool.ListStorageVolumes", 0, flags).Store(&storageVols)
+	return m.ListStorageVolumesContext(context.Background(), flags)
+}
+
+// ListStorageVolumesContext is like ListStorageVolumes but gives up waiting for the reply once ctx is done.
+func (m *StoragePool) ListStorageVolumesContext(ctx context.Context, flags uint32) (storageVols []dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.StoragePool.ListStorageVolumes", flags).Store(&storageVols)
 	return
 }
 
+// ListStorageVolumesObjects is like ListStorageVolumes but returns []*StorageVol instead of object paths.
+func (m *StoragePool) ListStorageVolumesObjects(flags uint32) (storageVols []*StorageVol, err error) {
+	return m.ListStorageVolumesObjectsContext(context.Background(), flags)
+}
+
+// ListStorageVolumesObjectsContext is like ListStorageVolumesObjects but gives up waiting for the reply once ctx is done.
+func (m *StoragePool) ListStorageVolumesObjectsContext(ctx context.Context, flags uint32) (storageVols []*StorageVol, err error) {
+	objPaths, err := m.ListStorageVolumesContext(ctx, flags)
+	if err != nil {
+		return nil, err
+	}
+	storageVols = make([]*StorageVol, len(objPaths))
+	for i, objPath := range objPaths {
+		storageVols[i] = NewStorageVol(m.conn, objPath)
+	}
+	return storageVols, nil
+}
+
 // Refresh See https://libvirt.org/html/libvirt-libvirt-storage.html#virStoragePoolRefresh
 func (m *StoragePool) Refresh(flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.StoragePool.Refresh", 0, flags).Store()
+	return m.RefreshContext(context.Background(), flags)
+}
+
+// RefreshContext is like Refresh but gives up waiting for the reply once ctx is done.
+func (m *StoragePool) RefreshContext(ctx context.Context, flags uint32) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.StoragePool.Refresh", flags).Store()
 	return
 }
 
 // StorageVolCreateXML See https://libvirt.org/html/libvirt-libvirt-storage.html#virStorageVolCreateXML
-func (m *StoragePool) StorageVolCreateXML(xml string, flags uint32) (storageVol dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.StoragePool.StorageVolCreateXML", 0, xml, flags).Store(&storageVol)
+func (m *StoragePool) StorageVolCreateXML(xml string, flags StorageVolCreateFlags) (storageVol dbus.ObjectPath, err error) {
+	return m.StorageVolCreateXMLContext(context.Background(), xml, flags)
+}
+
+// StorageVolCreateXMLContext is like StorageVolCreateXML but gives up waiting for the reply once ctx is done.
+func (m *StoragePool) StorageVolCreateXMLContext(ctx context.Context, xml string, flags StorageVolCreateFlags) (storageVol dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.StoragePool.StorageVolCreateXML", xml, flags).Store(&storageVol)
 	return
 }
 
+// StorageVolCreateXMLObject is like StorageVolCreateXML but returns *StorageVol instead of an object path.
+func (m *StoragePool) StorageVolCreateXMLObject(xml string, flags StorageVolCreateFlags) (storageVol *StorageVol, err error) {
+	return m.StorageVolCreateXMLObjectContext(context.Background(), xml, flags)
+}
+
+// StorageVolCreateXMLObjectContext is like StorageVolCreateXMLObject but gives up waiting for the reply once ctx is done.
+func (m *StoragePool) StorageVolCreateXMLObjectContext(ctx context.Context, xml string, flags StorageVolCreateFlags) (storageVol *StorageVol, err error) {
+	objPath, err := m.StorageVolCreateXMLContext(ctx, xml, flags)
+	if err != nil {
+		return nil, err
+	}
+	return NewStorageVol(m.conn, objPath), nil
+}
+
 // StorageVolCreateXMLFrom See https://libvirt.org/html/libvirt-libvirt-storage.html#virStorageVolCreateXMLFrom Call with @key argument set to the key of the storage volume to be cloned.
-func (m *StoragePool) StorageVolCreateXMLFrom(xml string, key string, flags uint32) (storageVol dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.StoragePool.StorageVolCreateXMLFrom", 0, xml, key, flags).Store(&storageVol)
+func (m *StoragePool) StorageVolCreateXMLFrom(xml string, key string, flags StorageVolCreateFlags) (storageVol dbus.ObjectPath, err error) {
+	return m.StorageVolCreateXMLFromContext(context.Background(), xml, key, flags)
+}
+
+// StorageVolCreateXMLFromContext is like StorageVolCreateXMLFrom but gives up waiting for the reply once ctx is done.
+func (m *StoragePool) StorageVolCreateXMLFromContext(ctx context.Context, xml string, key string, flags StorageVolCreateFlags) (storageVol dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.StoragePool.StorageVolCreateXMLFrom", xml, key, flags).Store(&storageVol)
 	return
 }
 
+// StorageVolCreateXMLFromObject is like StorageVolCreateXMLFrom but returns *StorageVol instead of an object path.
+func (m *StoragePool) StorageVolCreateXMLFromObject(xml string, key string, flags StorageVolCreateFlags) (storageVol *StorageVol, err error) {
+	return m.StorageVolCreateXMLFromObjectContext(context.Background(), xml, key, flags)
+}
+
+// StorageVolCreateXMLFromObjectContext is like StorageVolCreateXMLFromObject but gives up waiting for the reply once ctx is done.
+func (m *StoragePool) StorageVolCreateXMLFromObjectContext(ctx context.Context, xml string, key string, flags StorageVolCreateFlags) (storageVol *StorageVol, err error) {
+	objPath, err := m.StorageVolCreateXMLFromContext(ctx, xml, key, flags)
+	if err != nil {
+		return nil, err
+	}
+	return NewStorageVol(m.conn, objPath), nil
+}
+
 // StorageVolLookupByName See https://libvirt.org/html/libvirt-libvirt-storage.html#virStorageVolLookupByName
 func (m *StoragePool) StorageVolLookupByName(name string) (storageVol dbus.ObjectPath, err error) {
-	err = m.object.Call("org.libvirt.StoragePool.StorageVolLookupByName", 0, name).Store(&storageVol)
+	return m.StorageVolLookupByNameContext(context.Background(), name)
+}
+
+// StorageVolLookupByNameContext is like StorageVolLookupByName but gives up waiting for the reply once ctx is done.
+func (m *StoragePool) StorageVolLookupByNameContext(ctx context.Context, name string) (storageVol dbus.ObjectPath, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.StoragePool.StorageVolLookupByName", name).Store(&storageVol)
 	return
 }
 
+// StorageVolLookupByNameObject is like StorageVolLookupByName but returns *StorageVol instead of an object path.
+func (m *StoragePool) StorageVolLookupByNameObject(name string) (storageVol *StorageVol, err error) {
+	return m.StorageVolLookupByNameObjectContext(context.Background(), name)
+}
+
+// StorageVolLookupByNameObjectContext is like StorageVolLookupByNameObject but gives up waiting for the reply once ctx is done.
+func (m *StoragePool) StorageVolLookupByNameObjectContext(ctx context.Context, name string) (storageVol *StorageVol, err error) {
+	objPath, err := m.StorageVolLookupByNameContext(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return NewStorageVol(m.conn, objPath), nil
+}
+
 // Undefine See https://libvirt.org/html/libvirt-libvirt-storage.html#virStoragePoolUndefine
 func (m *StoragePool) Undefine() (err error) {
-	err = m.object.Call("org.libvirt.StoragePool.Undefine", 0).Store()
+	return m.UndefineContext(context.Background())
+}
+
+// UndefineContext is like Undefine but gives up waiting for the reply once ctx is done.
+func (m *StoragePool) UndefineContext(ctx context.Context) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.StoragePool.Undefine").Store()
 	return
 }
 
 // GetActive See https://libvirt.org/html/libvirt-libvirt-storage.html#virStoragePoolIsActive
 func (m *StoragePool) GetActive() (v bool, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.StoragePool", "Active").Store(&v)
+	return m.GetActiveContext(context.Background())
+}
+
+// GetActiveContext is like GetActive but gives up waiting for the reply once ctx is done.
+func (m *StoragePool) GetActiveContext(ctx context.Context) (v bool, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.StoragePool", "Active").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
 
 // SetAutostart See https://libvirt.org/html/libvirt-libvirt-storage.html#virStoragePoolGetAutostart https://libvirt.org/html/libvirt-libvirt-storage.html#virStoragePoolSetAutostart
 func (m *StoragePool) SetAutostart(v bool) (err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Set", 0, "org.libvirt.StoragePool", "Autostart", dbus.MakeVariant(v)).Store()
+	return m.SetAutostartContext(context.Background(), v)
+}
+
+// SetAutostartContext is like SetAutostart but gives up waiting for the reply once ctx is done.
+func (m *StoragePool) SetAutostartContext(ctx context.Context, v bool) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Set", "org.libvirt.StoragePool", "Autostart", dbus.MakeVariant(v)).Store()
 	return
 }
 
 // GetAutostart See https://libvirt.org/html/libvirt-libvirt-storage.html#virStoragePoolGetAutostart https://libvirt.org/html/libvirt-libvirt-storage.html#virStoragePoolSetAutostart
 func (m *StoragePool) GetAutostart() (v bool, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.StoragePool", "Autostart").Store(&v)
+	return m.GetAutostartContext(context.Background())
+}
+
+// GetAutostartContext is like GetAutostart but gives up waiting for the reply once ctx is done.
+func (m *StoragePool) GetAutostartContext(ctx context.Context) (v bool, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.StoragePool", "Autostart").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
 
 // GetName See https://libvirt.org/html/libvirt-libvirt-storage.html#virStoragePoolGetName
 func (m *StoragePool) GetName() (v string, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.StoragePool", "Name").Store(&v)
+	return m.GetNameContext(context.Background())
+}
+
+// GetNameContext is like GetName but gives up waiting for the reply once ctx is done.
+func (m *StoragePool) GetNameContext(ctx context.Context) (v string, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.StoragePool", "Name").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
 
 // GetPersistent See https://libvirt.org/html/libvirt-libvirt-storage.html#virStoragePoolIsPersistent
 func (m *StoragePool) GetPersistent() (v bool, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.StoragePool", "Persistent").Store(&v)
+	return m.GetPersistentContext(context.Background())
+}
+
+// GetPersistentContext is like GetPersistent but gives up waiting for the reply once ctx is done.
+func (m *StoragePool) GetPersistentContext(ctx context.Context) (v bool, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.StoragePool", "Persistent").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
 
 // GetUUID See https://libvirt.org/html/libvirt-libvirt-storage.html#virStoragePoolGetUUIDString
 func (m *StoragePool) GetUUID() (v string, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.StoragePool", "UUID").Store(&v)
+	return m.GetUUIDContext(context.Background())
+}
+
+// GetUUIDContext is like GetUUID but gives up waiting for the reply once ctx is done.
+func (m *StoragePool) GetUUIDContext(ctx context.Context) (v string, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.StoragePool", "UUID").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
@@ -0,0 +1,129 @@
+package libvirt
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStoragePoolLifecycle(t *testing.T) {
+	c, _ := newTestConn(t)
+	conn := NewConnect(c, "")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := conn.StoragePoolEvents(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	next := func(want StoragePoolEventType) {
+		t.Helper()
+		select {
+		case ev := <-events:
+			if ev.Event != want {
+				t.Errorf("got %v, want %v", ev.Event, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no %v event", want)
+		}
+	}
+
+	pool, err := conn.StoragePoolDefineXMLObject("<pool type='dir'><name>images</name></pool>", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	next(StoragePoolEventDefined)
+	if err = pool.Create(0); err != nil {
+		t.Fatal(err)
+	}
+	next(StoragePoolEventStarted)
+
+	vol, err := pool.StorageVolCreateXMLObject("<volume><name>disk.img</name><capacity>1048576</capacity></volume>", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found, err := conn.StorageVolLookupByPathObject("/images/disk.img")
+	if err != nil || found.path != vol.path {
+		t.Fatalf("got %v, %v", found, err)
+	}
+	if info, err := vol.GetInfo(0); err != nil || info.Capacity != 1048576 {
+		t.Errorf("got volume info %+v, %v", info, err)
+	}
+	info, err := pool.GetInfo()
+	if err != nil || info.State != fakePoolRunning || info.Allocation != 1048576 {
+		t.Errorf("got pool info %+v, %v", info, err)
+	}
+	def, err := vol.Definition()
+	if err != nil || def.Name != "disk.img" || def.Key != "/images/disk.img" {
+		t.Errorf("got definition %+v, %v", def, err)
+	}
+	if err = vol.Delete(0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = pool.StorageVolLookupByName("disk.img"); !errors.Is(err, ErrNoStorageVol) {
+		t.Errorf("got %v, want ErrNoStorageVol", err)
+	}
+
+	if err = pool.Undefine(); !errors.Is(err, ErrOperationInvalid) {
+		t.Errorf("undefine of an active pool: got %v, want ErrOperationInvalid", err)
+	}
+	if err = pool.Destroy(); err != nil {
+		t.Fatal(err)
+	}
+	next(StoragePoolEventStopped)
+	if err = pool.Undefine(); err != nil {
+		t.Fatal(err)
+	}
+	next(StoragePoolEventUndefined)
+	if _, err = conn.StoragePoolLookupByName("images"); !errors.Is(err, ErrNoStoragePool) {
+		t.Errorf("got %v, want ErrNoStoragePool", err)
+	}
+}
+
+func TestStoragePoolRefresh(t *testing.T) {
+	c, f := newTestConn(t)
+	conn := NewConnect(c, "")
+
+	pool, err := conn.StoragePoolLookupByUUIDObject(fakePoolUUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := conn.StoragePoolDefineXMLObject("<pool type='dir'><name>other</name></pool>", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = other.Create(0); err != nil {
+		t.Fatal(err)
+	}
+
+	refreshed := make(chan struct{}, 2)
+	sub, err := pool.SubscribeRefresh(func() { refreshed <- struct{}{} })
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Close()
+	rule := "type='signal',interface='org.libvirt.StoragePool',member='Refresh',path='" + string(pool.path) + "'"
+	if n := f.matchRules()[rule]; n != 1 {
+		t.Fatalf("rule %s installed %d times", rule, n)
+	}
+
+	// The refresh of the other pool goes first, so it would have arrived
+	// by the time the one subscribed to does.
+	if err = other.Refresh(0); err != nil {
+		t.Fatal(err)
+	}
+	if err = pool.Refresh(0); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-refreshed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no Refresh signal")
+	}
+	select {
+	case <-refreshed:
+		t.Error("got the Refresh signal of another pool")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
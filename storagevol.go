@@ -1,85 +1,133 @@
 package libvirt
 
 import (
-	"sync"
+	"context"
 
 	"github.com/godbus/dbus"
 )
 
 type StorageVol struct {
-	conn   *Conn
-	object dbus.BusObject
-	path   dbus.ObjectPath
-
-	sigs  map[<-chan *dbus.Signal]struct{}
-	sigmu sync.Mutex
+	conn *Conn
+	path dbus.ObjectPath
 
 	//Name string
 	//Key string
 	//Path string
 }
 
+// StorageVolInfo holds the info returned by StorageVol.GetInfo.
+type StorageVolInfo struct {
+	Type       int32
+	Capacity   uint64
+	Allocation uint64
+}
+
 // NewStorageVol() TODO
 func NewStorageVol(c *Conn, path dbus.ObjectPath) *StorageVol {
-	m := &StorageVol{conn: c}
-	if path != "" {
-		m.object = c.conn.Object("org.libvirt", path)
-	} else {
-		m.object = c.object
+	m := &StorageVol{conn: c, path: path}
+	if path == "" {
+		m.path = c.path
 	}
-	m.path = c.object.Path()
-
-	m.sigmu.Lock()
-	m.sigs = make(map[<-chan *dbus.Signal]struct{})
-	m.sigmu.Unlock()
 
 	return m
 }
 
 // Delete See https://libvirt.org/html/libvirt-libvirt-storage.html#virStorageVolDelete
-func (m *StorageVol) Delete(flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.StorageVol.Delete", 0, flags).Store()
+func (m *StorageVol) Delete(flags StorageVolDeleteFlags) (err error) {
+	return m.DeleteContext(context.Background(), flags)
+}
+
+// DeleteContext is like Delete but gives up waiting for the reply once ctx is done.
+func (m *StorageVol) DeleteContext(ctx context.Context, flags StorageVolDeleteFlags) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.StorageVol.Delete", flags).Store()
 	return
 }
 
 // GetInfo See https://libvirt.org/html/libvirt-libvirt-storage.html#virStorageVolGetInfoFlags
-func (m *StorageVol) GetInfo(flags uint32) (info interface{}, err error) {
-	err = m.object.Call("org.libvirt.StorageVol.GetInfo", 0, flags).Store(&info)
+func (m *StorageVol) GetInfo(flags StorageVolInfoFlags) (info StorageVolInfo, err error) {
+	return m.GetInfoContext(context.Background(), flags)
+}
+
+// GetInfoContext is like GetInfo but gives up waiting for the reply once ctx is done.
+func (m *StorageVol) GetInfoContext(ctx context.Context, flags StorageVolInfoFlags) (info StorageVolInfo, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.StorageVol.GetInfo", flags).Store(&info)
 	return
 }
 
 // GetXMLDesc See https://libvirt.org/html/libvirt-libvirt-storage.html#virStorageVolGetXMLDesc
 func (m *StorageVol) GetXMLDesc(flags uint32) (xml string, err error) {
-	err = m.object.Call("org.libvirt.StorageVol.GetXMLDesc", 0, flags).Store(&xml)
+	return m.GetXMLDescContext(context.Background(), flags)
+}
+
+// GetXMLDescContext is like GetXMLDesc but gives up waiting for the reply once ctx is done.
+func (m *StorageVol) GetXMLDescContext(ctx context.Context, flags uint32) (xml string, err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.StorageVol.GetXMLDesc", flags).Store(&xml)
 	return
 }
 
 // Resize See https://libvirt.org/html/libvirt-libvirt-storage.html#virStorageVolResize
-func (m *StorageVol) Resize(capacity uint64, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.StorageVol.Resize", 0, capacity, flags).Store()
+func (m *StorageVol) Resize(capacity uint64, flags StorageVolResizeFlags) (err error) {
+	return m.ResizeContext(context.Background(), capacity, flags)
+}
+
+// ResizeContext is like Resize but gives up waiting for the reply once ctx is done.
+func (m *StorageVol) ResizeContext(ctx context.Context, capacity uint64, flags StorageVolResizeFlags) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.StorageVol.Resize", capacity, flags).Store()
 	return
 }
 
 // Wipe See https://libvirt.org/html/libvirt-libvirt-storage.html#virStorageVolWipePattern
-func (m *StorageVol) Wipe(pattern uint32, flags uint32) (err error) {
-	err = m.object.Call("org.libvirt.StorageVol.Wipe", 0, pattern, flags).Store()
+func (m *StorageVol) Wipe(pattern StorageVolWipeAlgorithm, flags uint32) (err error) {
+	return m.WipeContext(context.Background(), pattern, flags)
+}
+
+// WipeContext is like Wipe but gives up waiting for the reply once ctx is done.
+func (m *StorageVol) WipeContext(ctx context.Context, pattern StorageVolWipeAlgorithm, flags uint32) (err error) {
+	err = callContext(ctx, m.conn.object(m.path), "org.libvirt.StorageVol.Wipe", pattern, flags).Store()
 	return
 }
 
 // GetName See https://libvirt.org/html/libvirt-libvirt-storage.html#virStorageVolGetName
 func (m *StorageVol) GetName() (v string, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.StorageVol", "Name").Store(&v)
+	return m.GetNameContext(context.Background())
+}
+
+// GetNameContext is like GetName but gives up waiting for the reply once ctx is done.
+func (m *StorageVol) GetNameContext(ctx context.Context) (v string, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.StorageVol", "Name").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
 
 // GetKey See https://libvirt.org/html/libvirt-libvirt-storage.html#virStorageVolGetKey
 func (m *StorageVol) GetKey() (v string, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.StorageVol", "Key").Store(&v)
+	return m.GetKeyContext(context.Background())
+}
+
+// GetKeyContext is like GetKey but gives up waiting for the reply once ctx is done.
+func (m *StorageVol) GetKeyContext(ctx context.Context) (v string, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.StorageVol", "Key").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
 
 // GetPath See https://libvirt.org/html/libvirt-libvirt-storage.html#virStorageVolGetPath
 func (m *StorageVol) GetPath() (v string, err error) {
-	err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "org.libvirt.StorageVol", "Path").Store(&v)
+	return m.GetPathContext(context.Background())
+}
+
+// GetPathContext is like GetPath but gives up waiting for the reply once ctx is done.
+func (m *StorageVol) GetPathContext(ctx context.Context) (v string, err error) {
+	var variant dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "org.libvirt.StorageVol", "Path").Store(&variant); err != nil {
+		return
+	}
+	err = dbus.Store([]interface{}{variant.Value()}, &v)
 	return
 }
@@ -0,0 +1,379 @@
+package libvirt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/godbus/dbus"
+)
+
+var errNilCallback = errors.New("libvirt: nil callback")
+
+// signalSpec identifies the signal a subscription listens to.
+type signalSpec struct {
+	iface     string
+	member    string
+	signature string
+	path      dbus.ObjectPath
+}
+
+func (sp signalSpec) rule() string {
+	return "type='signal',interface='" + sp.iface + "',member='" + sp.member + "',path='" + string(sp.path) + "'"
+}
+
+func (sp signalSpec) matches(v *dbus.Signal) bool {
+	return v.Path == sp.path && v.Name == sp.iface+"."+sp.member
+}
+
+// SignalError reports a signal that could not be handed to the callback,
+// either because its body did not match the signature the bindings were
+// generated for or because the subscription queue was full. The
+// subscription keeps running.
+type SignalError struct {
+	Name      string
+	Path      dbus.ObjectPath
+	Signature string
+	Body      []interface{}
+	Err       error
+}
+
+func (e *SignalError) Error() string {
+	return fmt.Sprintf("libvirt: cannot decode signal %s from %s (expected signature %q): %v",
+		e.Name, e.Path, e.Signature, e.Err)
+}
+
+func (e *SignalError) Unwrap() error {
+	return e.Err
+}
+
+var errSignature = errors.New("signature mismatch")
+
+// Subscription is a signal handler registered by one of the Subscribe
+// methods. Close it to stop receiving signals.
+//
+// The callback is handed one signal at a time, on a goroutine of the
+// subscription. godbus passes each signal on from a goroutine of its own,
+// so signals may reach the callback in a different order from the one
+// they were sent in. What happens to signals while the callback falls
+// behind depends on the OverflowPolicy.
+type Subscription struct {
+	conn    *Conn
+	spec    signalSpec
+	policy  OverflowPolicy
+	timeout time.Duration // how long OverflowBlock waits
+	handle  func([]interface{}) error
+	queue   chan *dbus.Signal
+	errs    chan error
+
+	once    sync.Once
+	closing chan struct{}
+	done    chan struct{}
+}
+
+// subscribe installs the match rule for spec on the bus (shared with any
+// other subscription using the same rule), registers the subscription with
+// the connection's dispatcher and calls handle with the body of every
+// matching signal until the subscription is closed. It fails with errClosed
+// once the connection has stopped.
+func (c *Conn) subscribe(spec signalSpec, handle func([]interface{}) error, opts []SubscribeOption) (*Subscription, error) {
+	cfg := subscribeConfig{depth: defaultQueueDepth, policy: OverflowDropOldest, timeout: blockTimeout}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if err := c.addMatch(spec.rule()); err != nil {
+		return nil, err
+	}
+
+	s := &Subscription{
+		conn:    c,
+		spec:    spec,
+		policy:  cfg.policy,
+		timeout: cfg.timeout,
+		handle:  handle,
+		queue:   make(chan *dbus.Signal, cfg.depth),
+		errs:    make(chan error, 16),
+		closing: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	if !c.register(s) {
+		c.removeMatch(spec.rule())
+		return nil, errClosed
+	}
+	go s.run()
+
+	return s, nil
+}
+
+// stream backs the channel variants of the Subscribe methods. subscribe
+// registers a callback that sends on the channel, giving up once quit is
+// closed; done closes the channel. The subscription is closed when ctx is
+// done, and done runs once the callback can no longer be invoked, whether
+// the stream ended through ctx or through loss of the connection.
+func stream(ctx context.Context, subscribe func(quit <-chan struct{}) (*Subscription, error), done func()) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	quit := make(chan struct{})
+	sub, err := subscribe(quit)
+	if err != nil {
+		return err
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+			sub.Close()
+		case <-sub.closing:
+		}
+		close(quit)
+		<-sub.done
+		done()
+	}()
+	return nil
+}
+
+func (s *Subscription) run() {
+	defer close(s.done)
+
+	for {
+		select {
+		case v := <-s.queue:
+			select {
+			case <-s.closing:
+				return
+			default:
+			}
+			s.deliver(v)
+		case <-s.closing:
+			return
+		}
+	}
+}
+
+// enqueue hands v to the subscription's worker, applying the overflow
+// policy when its queue is full. It is only called by the dispatcher.
+func (s *Subscription) enqueue(v *dbus.Signal) {
+	switch s.policy {
+	case OverflowBlock:
+		select {
+		case s.queue <- v:
+			return
+		default:
+		}
+		t := time.NewTimer(s.timeout)
+		defer t.Stop()
+		select {
+		case s.queue <- v:
+		case <-t.C:
+			s.report(v, ErrQueueFull)
+		case <-s.closing:
+		}
+	case OverflowReport:
+		select {
+		case s.queue <- v:
+		default:
+			s.report(v, ErrQueueFull)
+		}
+	default:
+		for {
+			select {
+			case s.queue <- v:
+				return
+			default:
+			}
+			select {
+			case <-s.queue:
+			default:
+			}
+		}
+	}
+}
+
+// deliver decodes v and passes it on, reporting bodies that do not match
+// the expected signature instead of handing them to the callback.
+func (s *Subscription) deliver(v *dbus.Signal) {
+	err := errSignature
+	if matchSignature(v.Body, s.spec.signature) {
+		err = s.handle(v.Body)
+	}
+	if err != nil {
+		s.report(v, err)
+	}
+}
+
+func (s *Subscription) report(v *dbus.Signal, err error) {
+	serr := &SignalError{
+		Name:      v.Name,
+		Path:      v.Path,
+		Signature: s.spec.signature,
+		Body:      v.Body,
+		Err:       err,
+	}
+	select {
+	case s.errs <- serr:
+	default:
+		// nobody is reading errors; drop rather than stall delivery
+	}
+}
+
+// Errors returns a channel on which signals that could not be decoded or
+// queued are reported as *SignalError. Errors are dropped when the channel
+// is full.
+func (s *Subscription) Errors() <-chan error {
+	return s.errs
+}
+
+// Close stops the subscription and removes its match rule from the bus
+// once no other subscription uses it. The callback is not invoked for
+// signals received after Close. It is safe to call Close more than once,
+// including from within the callback.
+func (s *Subscription) Close() error {
+	var err error
+	s.once.Do(func() {
+		close(s.closing)
+		s.conn.unregister(s)
+		err = s.conn.removeMatch(s.spec.rule())
+	})
+	return err
+}
+
+// stop ends the subscription without touching the bus, for use once the
+// connection is gone.
+func (s *Subscription) stop() {
+	s.once.Do(func() {
+		close(s.closing)
+	})
+}
+
+// Done returns a channel that is closed once the subscription has stopped,
+// either because it was closed or because the connection went away.
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+// addMatch adds rule to the bus unless it is already installed, keeping a
+// reference count per rule.
+func (c *Conn) addMatch(rule string) error {
+	c.matchmu.Lock()
+	defer c.matchmu.Unlock()
+
+	if c.matches[rule] == 0 {
+		bus, _ := c.bus()
+		err := bus.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, rule).Store()
+		if err != nil {
+			return err
+		}
+	}
+	c.matches[rule]++
+	return nil
+}
+
+// removeMatch drops a reference to rule and removes it from the bus when
+// it was the last one.
+func (c *Conn) removeMatch(rule string) error {
+	c.matchmu.Lock()
+	defer c.matchmu.Unlock()
+
+	if c.matches[rule] == 0 {
+		return nil
+	}
+	c.matches[rule]--
+	if c.matches[rule] > 0 {
+		return nil
+	}
+	delete(c.matches, rule)
+	bus, _ := c.bus()
+	return bus.BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, rule).Store()
+}
+
+// matchSignature reports whether the decoded values in body have exactly
+// the D-Bus types listed in sig.
+func matchSignature(body []interface{}, sig string) bool {
+	for _, v := range body {
+		if sig == "" {
+			return false
+		}
+		n := sigLen(sig)
+		if !matchValue(reflect.ValueOf(v), sig[:n]) {
+			return false
+		}
+		sig = sig[n:]
+	}
+	return sig == ""
+}
+
+func matchValue(v reflect.Value, sig string) bool {
+	if !v.IsValid() {
+		return false
+	}
+	switch sig[0] {
+	case '(':
+		fields, ok := v.Interface().([]interface{})
+		return ok && matchSignature(fields, sig[1:len(sig)-1])
+	case 'v':
+		_, ok := v.Interface().(dbus.Variant)
+		return ok
+	case 'a':
+		if sig[1] == '{' {
+			if v.Kind() != reflect.Map {
+				return false
+			}
+			ksig := sig[2:3]
+			vsig := sig[3 : len(sig)-1]
+			for _, k := range v.MapKeys() {
+				if !matchValue(k, ksig) || !matchValue(v.MapIndex(k), vsig) {
+					return false
+				}
+			}
+			return true
+		}
+		if v.Kind() != reflect.Slice {
+			return false
+		}
+		if sig[1] != '(' && sig[1] != 'v' && sig[1] != 'a' {
+			return dbus.SignatureOfType(v.Type()).String() == sig
+		}
+		for i := 0; i < v.Len(); i++ {
+			if !matchValue(v.Index(i), sig[1:]) {
+				return false
+			}
+		}
+		return true
+	default:
+		if v.Kind() == reflect.Interface {
+			v = v.Elem()
+			if !v.IsValid() {
+				return false
+			}
+		}
+		return dbus.SignatureOfType(v.Type()).String() == sig
+	}
+}
+
+// sigLen returns the length of the first complete type in sig. gen.go has
+// a copy, since it runs on its own and cannot import the package it
+// generates; change both together.
+func sigLen(sig string) int {
+	switch sig[0] {
+	case 'a':
+		return 1 + sigLen(sig[1:])
+	case '(', '{':
+		depth := 0
+		for i, c := range sig {
+			switch c {
+			case '(', '{':
+				depth++
+			case ')', '}':
+				depth--
+				if depth == 0 {
+					return i + 1
+				}
+			}
+		}
+	}
+	return 1
+}
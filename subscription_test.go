@@ -0,0 +1,249 @@
+package libvirt
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus"
+)
+
+func TestMatchSignature(t *testing.T) {
+	graphics := []interface{}{
+		int32(0),
+		[]interface{}{int32(0), "127.0.0.1", "5900"},
+		[]interface{}{int32(0), "10.0.0.1", "41000"},
+		"vnc",
+		[][]interface{}{{"x509dname", "CN=client"}},
+	}
+	tests := []struct {
+		body []interface{}
+		sig  string
+		ok   bool
+	}{
+		{[]interface{}{dbus.ObjectPath("/org/libvirt/QEMU/domain/x"), int32(1), int32(2)}, "oii", true},
+		{[]interface{}{dbus.ObjectPath("/org/libvirt/QEMU/domain/x"), uint32(1), int32(2)}, "oii", false},
+		{[]interface{}{dbus.ObjectPath("/org/libvirt/QEMU/domain/x"), int32(1)}, "oii", false},
+		{[]interface{}{map[string]dbus.Variant{"a": dbus.MakeVariant(uint64(1))}}, "a{sv}", true},
+		{graphics, "i(iss)(iss)sa(ss)", true},
+		{graphics, "i(iss)(isi)sa(ss)", false},
+		{nil, "", true},
+	}
+	for _, tt := range tests {
+		if got := matchSignature(tt.body, tt.sig); got != tt.ok {
+			t.Errorf("matchSignature(%v, %q) = %v, want %v", tt.body, tt.sig, got, tt.ok)
+		}
+	}
+}
+
+func TestSubscriptionReportsBadSignals(t *testing.T) {
+	var calls int
+	s := &Subscription{
+		spec: signalSpec{"org.libvirt.Connect", "DomainEvent", "oii", "/org/libvirt/QEMU"},
+		errs: make(chan error, 1),
+		handle: func(body []interface{}) error {
+			calls++
+			return nil
+		},
+	}
+
+	s.deliver(&dbus.Signal{Name: "org.libvirt.Connect.DomainEvent", Body: []interface{}{"oops"}})
+	var serr *SignalError
+	if err := <-s.Errors(); !errors.As(err, &serr) || serr.Signature != "oii" {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	s.deliver(&dbus.Signal{Name: "org.libvirt.Connect.DomainEvent",
+		Body: []interface{}{dbus.ObjectPath("/org/libvirt/QEMU/domain/x"), int32(1), int32(2)}})
+	if calls != 1 {
+		t.Fatalf("handler called %d times, want 1", calls)
+	}
+}
+
+func TestSubscriptionOverflow(t *testing.T) {
+	sig := func(i int32) *dbus.Signal {
+		return &dbus.Signal{Body: []interface{}{i}}
+	}
+	newSub := func(p OverflowPolicy) *Subscription {
+		return &Subscription{
+			policy:  p,
+			timeout: time.Second,
+			queue:   make(chan *dbus.Signal, 2),
+			errs:    make(chan error, 4),
+			closing: make(chan struct{}),
+		}
+	}
+
+	s := newSub(OverflowDropOldest)
+	for i := int32(0); i < 3; i++ {
+		s.enqueue(sig(i))
+	}
+	if v := <-s.queue; v.Body[0] != int32(1) {
+		t.Errorf("drop oldest: got %v first, want 1", v.Body[0])
+	}
+
+	s = newSub(OverflowReport)
+	for i := int32(0); i < 3; i++ {
+		s.enqueue(sig(i))
+	}
+	if err := <-s.Errors(); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("report: got %v, want ErrQueueFull", err)
+	}
+	if v := <-s.queue; v.Body[0] != int32(0) {
+		t.Errorf("report: got %v first, want 0", v.Body[0])
+	}
+
+	s = newSub(OverflowBlock)
+	s.enqueue(sig(0))
+	s.enqueue(sig(1))
+	blocked := make(chan struct{})
+	go func() {
+		s.enqueue(sig(2))
+		close(blocked)
+	}()
+	select {
+	case <-blocked:
+		t.Fatal("block: enqueue returned on a full queue")
+	case <-time.After(10 * time.Millisecond):
+	}
+	<-s.queue
+	<-blocked
+
+	s = newSub(OverflowBlock)
+	s.timeout = 10 * time.Millisecond
+	for i := int32(0); i < 3; i++ {
+		s.enqueue(sig(i))
+	}
+	if err := <-s.Errors(); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("block: got %v after the timeout, want ErrQueueFull", err)
+	}
+	if n := len(s.queue); n != 2 {
+		t.Errorf("block: %d signals queued, want 2", n)
+	}
+}
+
+func TestStreamClosesWhenSubscriptionStops(t *testing.T) {
+	s := &Subscription{
+		spec:    signalSpec{signature: "i"},
+		queue:   make(chan *dbus.Signal, 1),
+		errs:    make(chan error, 1),
+		closing: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	ch := make(chan int32)
+	err := stream(context.Background(), func(quit <-chan struct{}) (*Subscription, error) {
+		s.handle = func(body []interface{}) error {
+			select {
+			case ch <- body[0].(int32):
+			case <-quit:
+			}
+			return nil
+		}
+		go s.run()
+		return s, nil
+	}, func() { close(ch) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.enqueue(&dbus.Signal{Body: []interface{}{int32(7)}})
+	if v := <-ch; v != 7 {
+		t.Fatalf("got %d, want 7", v)
+	}
+	// a pending send must not keep the stream open once the connection is gone
+	s.enqueue(&dbus.Signal{Body: []interface{}{int32(8)}})
+	time.Sleep(10 * time.Millisecond)
+	s.stop()
+	select {
+	case _, ok := <-ch:
+		if ok {
+			// the pending value may still win the race; the close must follow
+			_, ok = <-ch
+		}
+		if ok {
+			t.Fatal("channel not closed")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("channel not closed after the subscription stopped")
+	}
+}
+
+func TestSubscriptionCloseSharesRule(t *testing.T) {
+	c, f := newTestConn(t)
+	conn := NewConnect(c, "")
+	rule := "type='signal',interface='org.libvirt.Connect',member='DomainEvent',path='/org/libvirt/Test'"
+
+	events := make(chan DomainLifecycleEvent, 4)
+	first, err := conn.SubscribeDomainLifecycle(func(DomainLifecycleEvent) {})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := conn.SubscribeDomainLifecycle(func(ev DomainLifecycleEvent) { events <- ev })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := f.matchRules()[rule]; n != 1 {
+		t.Fatalf("rule installed %d times with two subscriptions, want 1", n)
+	}
+
+	if err = first.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err = first.Close(); err != nil {
+		t.Errorf("second Close: %v", err)
+	}
+	select {
+	case <-first.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("Done not closed after Close")
+	}
+	if n := f.matchRules()[rule]; n != 1 {
+		t.Fatalf("rule removed while a subscription still uses it")
+	}
+
+	domain, err := conn.DomainLookupByUUIDObject(fakeDomainUUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = domain.Suspend(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case ev := <-events:
+		if ev.Event != DomainEventSuspended {
+			t.Errorf("got %v", ev)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("remaining subscription got no event")
+	}
+
+	if err = second.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if n := f.matchRules()[rule]; n != 0 {
+		t.Errorf("rule still installed after the last subscription closed")
+	}
+}
+
+func TestSubscribeAfterClose(t *testing.T) {
+	c, f := newTestConn(t)
+	conn := NewConnect(c, "")
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The bus passed in with WithDBusConn stays open, so a rule added
+	// for the subscription would outlive the Conn.
+	if _, err := conn.SubscribeDomainLifecycle(func(DomainLifecycleEvent) {}); err != errClosed {
+		t.Errorf("got %v, want errClosed", err)
+	}
+	if rules := f.matchRules(); len(rules) != 0 {
+		t.Errorf("rules %v left on the bus", rules)
+	}
+	c.matchmu.Lock()
+	defer c.matchmu.Unlock()
+	if len(c.matches) != 0 {
+		t.Errorf("match references %v left", c.matches)
+	}
+}
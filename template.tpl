@@ -1,6 +1,7 @@
 package {{PkgName}}
 
 import (
+	"context"
 	"os"
 	"strconv"
 
@@ -9,32 +10,25 @@ import (
 
 type {{ExportName}} struct {
 	conn   *Conn
-	object dbus.BusObject
 	path dbus.ObjectPath
-
-  {{if or .Properties .Signals}}
-  sigs map[<-chan *dbus.Signal]struct{}
-  sigmu sync.Mutex
-  {{end}}
 	{{range .Properties}}
-	//{{.Name}} {{GuessType .Name .Type DbusInterface}}{{end}}
+	//{{.Name}} {{GuessType .Name .Type ExportName}}{{end}}
 }
 
+{{range Structs .}}
+// {{.Name}} {{.Doc}}
+type {{.Name}} struct {
+{{range .Fields}}	{{.Name}} {{.Type}}
+{{end}}}
+{{end}}
+
 // New{{ExportName}}() TODO
 func New{{ExportName}}(c *Conn, path dbus.ObjectPath) (*{{ExportName}}) {
-	m := &{{ExportName}}{conn: c}
-	if path != "" {
-	  m.object = c.conn.Object("org.libvirt", path)
-  } else {
-    m.object = c.object
-  }
-  m.path = c.object.Path()
+	m := &{{ExportName}}{conn: c, path: path}
+	if path == "" {
+	  m.path = c.path
+	}
 
-  {{if or .Properties .Signals}}
-  m.sigmu.Lock()
-  m.sigs = make(map[<-chan *dbus.Signal]struct{})
-  m.sigmu.Unlock()
-  {{end}}
 	return m
 }
 
@@ -42,59 +36,180 @@ func New{{ExportName}}(c *Conn, path dbus.ObjectPath) (*{{ExportName}}) {
 {{$methodName := .Name}}
 {{- range .Annotations}}// Subscribe{{$methodName}} {{AnnotationComment .Value}}
 {{- end}}
-func (m *{{ExportName}}) Subscribe{{.Name}}(callback func({{GetParamterOutsProto .Args}})) <-chan *dbus.Signal {
+//
+// The callback sees the signals one at a time but not necessarily in the
+// order they were sent; see Subscription and OverflowPolicy.
+func (m *{{ExportName}}) Subscribe{{.Name}}(callback func({{GetParamterOutsProto (print ExportName "." .Name) .Args}}), opts ...SubscribeOption) (*Subscription, error) {
   if callback == nil {
-    return nil
+    return nil, errNilCallback
   }
-  m.sigmu.Lock()
-  ch := make(chan *dbus.Signal)
-  m.sigs[ch] = struct{}{}
-  m.conn.conn.Signal(ch)
-  m.sigmu.Unlock()
-  m.conn.conn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, "type='signal',interface='{{DbusInterface}}',member='{{.Name}}'")
-  go func() {
-    for v := range ch {
-      if v.Path != m.path || v.Name != "{{DbusInterface}}.{{.Name}}" || {{len .Args}} != len(v.Body) {
-        continue
-      }
-      callback({{range $index, $arg := .Args}}{{if $index}},{{end}}v.Body[{{$index}}].({{GuessType $arg.Name $arg.Type ""}}){{end}})
-     }
-  }()
-  return ch
+  return m.conn.subscribe(signalSpec{"{{DbusInterface}}", "{{.Name}}", "{{Signature .Args}}", m.path}, func(body []interface{}) error {
+    {{- $key := print ExportName "." .Name}}
+    {{- if .Args}}
+    var (
+    {{- range .Args}}
+      {{ArgName .}} {{GuessType .Name .Type $key}}
+    {{- end}}
+    )
+    {{- end}}
+    if err := dbus.Store(body{{range .Args}}, &{{ArgName .}}{{end}}); err != nil {
+      return err
+    }
+    callback({{range $index, $arg := .Args}}{{if $index}}, {{end}}{{ArgName $arg}}{{end}})
+    return nil
+  }, opts)
 }
 
 {{ range .Annotations}}// UnSubscribe{{$methodName}} {{AnnotationComment .Value}}
 {{- end}}
-func (m *{{ExportName}}) UnSubscribe{{.Name}}(ch <-chan *dbus.Signal) {
-  m.sigmu.Lock()
-  delete(m.sigs, ch)
-  m.sigmu.Unlock()
-  m.conn.conn.BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, "type='signal',interface='{{DbusInterface}}',member='{{.Name}}'")
+func (m *{{ExportName}}) UnSubscribe{{.Name}}(sub *Subscription) error {
+  return sub.Close()
 }
-{{end}}
+{{if Streamed $key}}
+// {{ExportName}}{{EventName .Name}}Event carries the arguments of the {{.Name}} signal.
+type {{ExportName}}{{EventName .Name}}Event struct {
+{{range .Args}}	{{FieldName .}} {{GuessType .Name .Type $key}}
+{{end}}}
+
+// {{EventName .Name}}Events is like Subscribe{{.Name}} but delivers the signals on a channel that is closed once ctx is done or the connection is lost.
+func (m *{{ExportName}}) {{EventName .Name}}Events(ctx context.Context, opts ...SubscribeOption) (<-chan {{ExportName}}{{EventName .Name}}Event, error) {
+  ch := make(chan {{ExportName}}{{EventName .Name}}Event)
+  err := stream(ctx, func(quit <-chan struct{}) (*Subscription, error) {
+    return m.Subscribe{{.Name}}(func({{GetParamterOutsProto $key .Args}}) {
+      select {
+      case ch <- {{ExportName}}{{EventName .Name}}Event{ {{- range $index, $arg := .Args}}{{if $index}}, {{end}}{{ArgName $arg}}{{end -}} }:
+      case <-quit:
+      }
+    }, opts...)
+  }, func() { close(ch) })
+  if err != nil {
+    return nil, err
+  }
+  return ch, nil
+}
+{{else}}{{with Lifecycle $key}}
+// {{.}}Events is like Subscribe{{.}}Lifecycle but delivers the events on a
+// channel that is closed once ctx is done or the connection is lost.
+func (m *{{ExportName}}) {{.}}Events(ctx context.Context, opts ...SubscribeOption) (<-chan {{.}}LifecycleEvent, error) {
+  ch := make(chan {{.}}LifecycleEvent)
+  err := stream(ctx, func(quit <-chan struct{}) (*Subscription, error) {
+    return m.Subscribe{{.}}Lifecycle(func(e {{.}}LifecycleEvent) {
+      select {
+      case ch <- e:
+      case <-quit:
+      }
+    }, opts...)
+  }, func() { close(ch) })
+  if err != nil {
+    return nil, err
+  }
+  return ch, nil
+}
+{{end}}{{end}}{{end}}
 
 {{range .Methods}}
 {{$methodName := .Name}}
 {{- range .Annotations}}{{if eq .Name "org.gtk.GDBus.DocString"}}// {{$methodName}} {{AnnotationComment .Value}}{{end}}
 {{- end}}
-func (m *{{ExportName}}) {{.Name}}({{GetParamterInsProto .Args}}) ({{GetParamterOutsProto .Args}}{{with GetParamterOuts .Args}}, {{end}}err error) {
-	err = m.object.Call("{{DbusInterface}}.{{.Name}}", 0{{GetParamterNames .Args}}).Store({{GetParamterOuts .Args}})
+func (m *{{ExportName}}) {{.Name}}({{GetParamterInsProto (print ExportName "." .Name) .Args}}) ({{GetParamterOutsProto (print ExportName "." .Name) .Args}}{{with GetParamterOuts .Args}}, {{end}}err error) {
+	return m.{{.Name}}Context(context.Background(){{GetParamterNames .Args}})
+}
+
+// {{.Name}}Context is like {{.Name}} but gives up waiting for the reply once ctx is done.
+{{- $args := .Args}}{{$member := print ExportName "." .Name}}
+func (m *{{ExportName}}) {{.Name}}Context(ctx context.Context{{with GetParamterInsProto $member .Args}}, {{.}}{{end}}) ({{GetParamterOutsProto $member .Args}}{{with GetParamterOuts .Args}}, {{end}}err error) {
+{{- with ParamOut $member .Args}}
+	var params map[string]dbus.Variant
+	if err = callContext(ctx, m.conn.object(m.path), "{{DbusInterface}}.{{$methodName}}"{{CallArgs $member $args}}).Store(&params); err != nil {
+		return
+	}
+	{{.Name}} = new({{.Type}})
+	err = decodeParams(params, {{.Name}})
+{{- else}}
+	err = callContext(ctx, m.conn.object(m.path), "{{DbusInterface}}.{{.Name}}"{{CallArgs $member .Args}}).Store({{GetParamterOuts .Args}})
+{{- end}}
 	return
 }
-{{end}}
+{{with ObjectOut $member .Args}}
+// {{$methodName}}{{.Suffix}} is like {{$methodName}} but returns {{if .Slice}}[]*{{.Type}} instead of object paths{{else}}*{{.Type}} instead of an object path{{end}}.
+func (m *{{ExportName}}) {{$methodName}}{{.Suffix}}({{GetParamterInsProto $member $args}}) ({{.Name}} {{if .Slice}}[]{{end}}*{{.Type}}, err error) {
+	return m.{{$methodName}}{{.Suffix}}Context(context.Background(){{GetParamterNames $args}})
+}
+
+// {{$methodName}}{{.Suffix}}Context is like {{$methodName}}{{.Suffix}} but gives up waiting for the reply once ctx is done.
+func (m *{{ExportName}}) {{$methodName}}{{.Suffix}}Context(ctx context.Context{{with GetParamterInsProto $member $args}}, {{.}}{{end}}) ({{.Name}} {{if .Slice}}[]{{end}}*{{.Type}}, err error) {
+{{- if .Slice}}
+	objPaths, err := m.{{$methodName}}Context(ctx{{GetParamterNames $args}})
+	if err != nil {
+		return nil, err
+	}
+	{{.Name}} = make([]*{{.Type}}, len(objPaths))
+	for i, objPath := range objPaths {
+		{{.Name}}[i] = New{{.Type}}(m.conn, objPath)
+	}
+	return {{.Name}}, nil
+{{- else}}
+	objPath, err := m.{{$methodName}}Context(ctx{{GetParamterNames $args}})
+	if err != nil {
+		return nil, err
+	}
+	return New{{.Type}}(m.conn, objPath), nil
+{{- end}}
+}
+{{end}}{{end}}
 
 {{range .Properties}}
 {{$propName := .Name}}
 {{if PropWritable .}}{{range .Annotations}}{{if eq .Name "org.gtk.GDBus.DocString"}}// Set{{$propName}} {{AnnotationComment .Value}}{{end}}{{end}}
-func (m *{{ExportName}}) Set{{.Name}}(v {{GuessType .Name .Type ""}}) (err error) {
-  err = m.object.Call("org.freedesktop.DBus.Properties.Set", 0, "{{DbusInterface}}", "{{.Name}}", dbus.MakeVariant(v)).Store()
+func (m *{{ExportName}}) Set{{.Name}}(v {{GuessType .Name .Type ExportName}}) (err error) {
+  return m.Set{{.Name}}Context(context.Background(), v)
+}
+
+// Set{{.Name}}Context is like Set{{.Name}} but gives up waiting for the reply once ctx is done.
+func (m *{{ExportName}}) Set{{.Name}}Context(ctx context.Context, v {{GuessType .Name .Type ExportName}}) (err error) {
+  err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Set", "{{DbusInterface}}", "{{.Name}}", dbus.MakeVariant(v)).Store()
   return
 }
 {{end}}
 {{- range .Annotations}}{{if eq .Name "org.gtk.GDBus.DocString"}}// Get{{$propName}} {{AnnotationComment .Value}}{{end}}
 {{- end}}
-func (m *{{ExportName}}) Get{{.Name}}() (v {{GuessType .Name .Type ""}}, err error) {
-  err = m.object.Call("org.freedesktop.DBus.Properties.Get", 0, "{{DbusInterface}}", "{{.Name}}").Store(&v)
+func (m *{{ExportName}}) Get{{.Name}}() (v {{GuessType .Name .Type ExportName}}, err error) {
+  return m.Get{{.Name}}Context(context.Background())
+}
+
+// Get{{.Name}}Context is like Get{{.Name}} but gives up waiting for the reply once ctx is done.
+func (m *{{ExportName}}) Get{{.Name}}Context(ctx context.Context) (v {{GuessType .Name .Type ExportName}}, err error) {
+  var variant dbus.Variant
+  if err = callContext(ctx, m.conn.object(m.path), "org.freedesktop.DBus.Properties.Get", "{{DbusInterface}}", "{{.Name}}").Store(&variant); err != nil {
+    return
+  }
+  err = dbus.Store([]interface{}{variant.Value()}, &v)
   return
 }
-{{end}}
+{{with ObjectProp ExportName .}}
+// Get{{$propName}}{{.Suffix}} is like Get{{$propName}} but returns {{if .Slice}}[]*{{.Type}} instead of object paths{{else}}*{{.Type}} instead of an object path{{end}}.
+func (m *{{ExportName}}) Get{{$propName}}{{.Suffix}}() (v {{if .Slice}}[]{{end}}*{{.Type}}, err error) {
+  return m.Get{{$propName}}{{.Suffix}}Context(context.Background())
+}
+
+// Get{{$propName}}{{.Suffix}}Context is like Get{{$propName}}{{.Suffix}} but gives up waiting for the reply once ctx is done.
+func (m *{{ExportName}}) Get{{$propName}}{{.Suffix}}Context(ctx context.Context) (v {{if .Slice}}[]{{end}}*{{.Type}}, err error) {
+{{- if .Slice}}
+  objPaths, err := m.Get{{$propName}}Context(ctx)
+  if err != nil {
+    return nil, err
+  }
+  v = make([]*{{.Type}}, len(objPaths))
+  for i, objPath := range objPaths {
+    v[i] = New{{.Type}}(m.conn, objPath)
+  }
+  return v, nil
+{{- else}}
+  objPath, err := m.Get{{$propName}}Context(ctx)
+  if err != nil {
+    return nil, err
+  }
+  return New{{.Type}}(m.conn, objPath), nil
+{{- end}}
+}
+{{end}}{{end}}
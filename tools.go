@@ -0,0 +1,8 @@
+// +build tools
+
+package libvirt
+
+// Packages used by gen.go, listed here so that they are vendored.
+import (
+	_ "github.com/godbus/dbus/introspect"
+)